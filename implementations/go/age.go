@@ -0,0 +1,21 @@
+package microsharduuid
+
+import "time"
+
+// ==========================================
+// Age & TTL
+// ==========================================
+
+// Age returns how long before now the ID was created, at microsecond
+// precision. It is negative for IDs from the future (clock skew between
+// the issuing host and this one).
+func (u MicroShardUUID) Age(now time.Time) time.Duration {
+	return time.Duration(now.UnixMicro()-int64(u.micros())) * time.Microsecond
+}
+
+// OlderThan reports whether the ID was created more than d ago by the
+// system clock, for TTL, retention, and garbage collection decisions. Use
+// Age with an explicit time to evaluate a whole batch against one instant.
+func (u MicroShardUUID) OlderThan(d time.Duration) bool {
+	return u.Age(time.Now()) > d
+}
@@ -0,0 +1,30 @@
+package microsharduuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAge(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	id, _ := FromTime(now.Add(-90*time.Minute-time.Microsecond), 1)
+
+	if got := id.Age(now); got != 90*time.Minute+time.Microsecond {
+		t.Errorf("Age = %v, expected 1h30m0.000001s", got)
+	}
+	if got := id.Age(now.Add(-2 * time.Hour)); got != -30*time.Minute+time.Microsecond {
+		t.Errorf("Future ID: Age = %v", got)
+	}
+}
+
+func TestOlderThan(t *testing.T) {
+	old, _ := FromTime(time.Now().Add(-2*time.Hour), 1)
+	fresh, _ := Generate(1)
+
+	if !old.OlderThan(time.Hour) || old.OlderThan(3*time.Hour) {
+		t.Error("2h old ID misclassified")
+	}
+	if fresh.OlderThan(time.Minute) {
+		t.Error("Fresh ID reported as older than a minute")
+	}
+}
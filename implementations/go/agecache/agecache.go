@@ -0,0 +1,119 @@
+// Package agecache provides an in-memory cache keyed by MicroShardUUID that
+// evicts entries by the age embedded in their key.
+//
+// For caches where recency equals relevance (sessions, recent orders, feed
+// items) the key itself says when the entry became stale, so no per-entry
+// timestamps or LRU bookkeeping are needed: entries whose ID is older than
+// the configured horizon are evicted, oldest first.
+package agecache
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Cache is an age-partitioned cache. It is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	horizon time.Duration
+	now     func() time.Time
+	entries map[microsharduuid.MicroShardUUID]interface{}
+	order   idHeap // Min-heap of keys (oldest first), may hold deleted keys
+}
+
+// New creates a Cache that evicts entries whose key was created more than horizon ago.
+func New(horizon time.Duration) *Cache {
+	return &Cache{
+		horizon: horizon,
+		now:     time.Now,
+		entries: make(map[microsharduuid.MicroShardUUID]interface{}),
+	}
+}
+
+// Set stores value under key. Keys that are already past the horizon are not stored.
+// Expired entries are evicted as a side effect.
+func (c *Cache) Set(key microsharduuid.MicroShardUUID, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cutoff := c.cutoff()
+	c.evict(cutoff)
+	if key.Time().Before(cutoff) {
+		return
+	}
+
+	if _, ok := c.entries[key]; !ok {
+		heap.Push(&c.order, key)
+	}
+	c.entries[key] = value
+}
+
+// Get returns the value stored under key. Expired entries are never returned,
+// even if they have not been evicted yet.
+func (c *Cache) Get(key microsharduuid.MicroShardUUID) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.entries[key]
+	if !ok || key.Time().Before(c.cutoff()) {
+		return nil, false
+	}
+	return value, true
+}
+
+// Delete removes key.
+func (c *Cache) Delete(key microsharduuid.MicroShardUUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key) // The heap entry is dropped lazily
+}
+
+// Len returns the number of stored entries, including expired ones not yet evicted.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// EvictExpired removes all entries older than the horizon and returns how many were removed.
+// Call it periodically if the cache sees few writes.
+func (c *Cache) EvictExpired() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.evict(c.cutoff())
+}
+
+func (c *Cache) cutoff() time.Time {
+	return c.now().Add(-c.horizon)
+}
+
+// evict pops keys older than cutoff. Keys sort by time first, so the
+// heap minimum is always the oldest remaining entry.
+func (c *Cache) evict(cutoff time.Time) int {
+	removed := 0
+	for len(c.order) > 0 && c.order[0].Time().Before(cutoff) {
+		key := heap.Pop(&c.order).(microsharduuid.MicroShardUUID)
+		if _, ok := c.entries[key]; ok {
+			delete(c.entries, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// idHeap implements heap.Interface ordered by MicroShardUUID.Compare.
+type idHeap []microsharduuid.MicroShardUUID
+
+func (h idHeap) Len() int            { return len(h) }
+func (h idHeap) Less(i, j int) bool  { return h[i].Before(h[j]) }
+func (h idHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *idHeap) Push(x interface{}) { *h = append(*h, x.(microsharduuid.MicroShardUUID)) }
+func (h *idHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,59 @@
+package agecache
+
+import (
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestEvictByEmbeddedTime(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := New(time.Hour)
+	c.now = func() time.Time { return now }
+
+	old := microsharduuid.MustFromTime(now.Add(-30*time.Minute), 1)
+	fresh := microsharduuid.MustFromTime(now.Add(-5*time.Minute), 2)
+	stale := microsharduuid.MustFromTime(now.Add(-2*time.Hour), 3)
+
+	c.Set(old, "old")
+	c.Set(fresh, "fresh")
+	c.Set(stale, "stale") // Already past the horizon
+
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", c.Len())
+	}
+	if _, ok := c.Get(stale); ok {
+		t.Error("Stale key should not have been stored")
+	}
+
+	// Advance the clock so "old" crosses the horizon
+	now = now.Add(45 * time.Minute)
+	if _, ok := c.Get(old); ok {
+		t.Error("Expired entry must not be returned")
+	}
+	if n := c.EvictExpired(); n != 1 {
+		t.Errorf("Expected 1 eviction, got %d", n)
+	}
+	if v, ok := c.Get(fresh); !ok || v != "fresh" {
+		t.Error("Fresh entry should survive eviction")
+	}
+}
+
+func TestDeleteThenEvict(t *testing.T) {
+	now := time.Now()
+	c := New(time.Minute)
+	c.now = func() time.Time { return now }
+
+	id := microsharduuid.MustFromTime(now, 1)
+	c.Set(id, 1)
+	c.Delete(id)
+
+	now = now.Add(2 * time.Minute)
+	if n := c.EvictExpired(); n != 0 {
+		t.Errorf("Deleted entries should not count as evictions, got %d", n)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Expected empty cache, got %d", c.Len())
+	}
+}
@@ -0,0 +1,96 @@
+package microsharduuid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ==========================================
+// Shard Allocation
+// ==========================================
+
+// ErrNoShardAvailable is returned by Allocator.Acquire when every Shard ID
+// of the allocator's range is held by another instance.
+var ErrNoShardAvailable = errors.New("no shard available")
+
+// Allocator assigns the process a Shard ID that no other live instance
+// holds, typically through a lease in a coordination service, so fleets
+// that scale up and down need no manual shard assignment. Implementations
+// for etcd, Consul, and ZooKeeper live in contrib.
+type Allocator interface {
+	// Acquire claims a free Shard ID. The returned channel is closed when
+	// the claim is lost: the lease expired, the session was invalidated, or
+	// Release was called.
+	Acquire(ctx context.Context) (shardID uint32, lost <-chan struct{}, err error)
+	// Release gives the claim up, so another instance can take the Shard ID.
+	Release(ctx context.Context) error
+}
+
+// allocation wraps the Allocator of a Generator, so Reconfigure can tell
+// whether it was replaced without comparing interface values.
+type allocation struct {
+	allocator Allocator
+}
+
+// WithAllocator makes NewGenerator claim its Shard ID from a instead of
+// using defaultShardID. Once the claim is lost, generation fails with a
+// *GenerateError wrapping ErrLeaseExpired rather than risk duplicates with
+// the instance that takes the shard over; call Reacquire to claim a new
+// Shard ID. Call a.Release on shutdown to free the shard right away.
+//
+// The allocator cannot be changed with Reconfigure, and WithShardID is
+// rejected while it is set.
+func WithAllocator(a Allocator) Option {
+	return func(c *generatorConfig) {
+		c.allocation = &allocation{allocator: a}
+	}
+}
+
+// Reacquire claims a new Shard ID from the Generator's Allocator, e.g.
+// after generation failed with ErrLeaseExpired, and switches to it
+// atomically. It returns an error wrapping ErrInvalidOption if the
+// Generator has no Allocator.
+func (g *Generator) Reacquire(ctx context.Context) error {
+	alloc := g.cfg().allocation
+	if alloc == nil {
+		return fmt.Errorf("%w: generator has no allocator", ErrInvalidOption)
+	}
+	shardID, lost, err := acquireShard(ctx, alloc.allocator)
+	if err != nil {
+		return err
+	}
+	for {
+		old := g.cfg()
+		next := *old
+		next.shardID, next.lost = shardID, lost
+		if g.config.CompareAndSwap(old, &next) {
+			return nil
+		}
+	}
+}
+
+// acquireShard calls a.Acquire and validates its result.
+func acquireShard(ctx context.Context, a Allocator) (uint32, <-chan struct{}, error) {
+	shardID, lost, err := a.Acquire(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	if lost == nil {
+		return 0, nil, fmt.Errorf("%w: allocator returned no lost channel", ErrInvalidOption)
+	}
+	return shardID, lost, nil
+}
+
+// checkLease fails once the Allocator's claim on the Shard ID is lost.
+func (c *generatorConfig) checkLease() error {
+	if c.lost == nil {
+		return nil
+	}
+	select {
+	case <-c.lost:
+		return &GenerateError{ShardID: c.shardID, Reason: "shard lease lost", Err: ErrLeaseExpired}
+	default:
+		return nil
+	}
+}
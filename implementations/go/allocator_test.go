@@ -0,0 +1,151 @@
+package microsharduuid
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAllocator hands out increasing Shard IDs from a fixed range.
+type fakeAllocator struct {
+	mu    sync.Mutex
+	next  uint32
+	last  uint32
+	lost  chan struct{}
+	freed int
+}
+
+func (a *fakeAllocator) Acquire(ctx context.Context) (uint32, <-chan struct{}, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.next > a.last {
+		return 0, nil, ErrNoShardAvailable
+	}
+	shard := a.next
+	a.next++
+	a.lost = make(chan struct{})
+	return shard, a.lost, nil
+}
+
+func (a *fakeAllocator) Release(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.freed++
+	close(a.lost)
+	return nil
+}
+
+// expire simulates a lapsed lease.
+func (a *fakeAllocator) expire() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	close(a.lost)
+}
+
+func TestWithAllocator(t *testing.T) {
+	alloc := &fakeAllocator{next: 500, last: 501}
+	g, err := NewGenerator(7, WithAllocator(alloc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.ShardID() != 500 {
+		t.Errorf("Shard ID should come from the allocator, got %d", g.ShardID())
+	}
+	id, err := g.NewID()
+	if err != nil || id.ShardID() != 500 {
+		t.Fatalf("Unexpected ID %v (%v)", id, err)
+	}
+
+	alloc.expire()
+	for name, generate := range map[string]func() error{
+		"NewID":   func() error { _, err := g.NewID(); return err },
+		"NewIDs":  func() error { _, err := g.NewIDs(3); return err },
+		"NewIDAt": func() error { _, err := g.NewIDAt(time.Now()); return err },
+	} {
+		err := generate()
+		var ge *GenerateError
+		if !errors.Is(err, ErrLeaseExpired) || !errors.As(err, &ge) || ge.ShardID != 500 {
+			t.Errorf("%s after a lost lease should fail with ErrLeaseExpired, got %v", name, err)
+		}
+	}
+
+	if err := g.Reacquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := g.NewID(); err != nil || id.ShardID() != 501 {
+		t.Errorf("Reacquire should switch to a new shard, got %v (%v)", id, err)
+	}
+
+	if err := g.Reacquire(context.Background()); !errors.Is(err, ErrNoShardAvailable) {
+		t.Errorf("An exhausted range should fail with ErrNoShardAvailable, got %v", err)
+	}
+	if err := alloc.Release(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.NewID(); !errors.Is(err, ErrLeaseExpired) {
+		t.Errorf("Release should stop generation, got %v", err)
+	}
+}
+
+func TestWithAllocatorErrors(t *testing.T) {
+	if _, err := NewGenerator(0, WithAllocator(&fakeAllocator{next: 1, last: 0})); !errors.Is(err, ErrNoShardAvailable) {
+		t.Errorf("NewGenerator should return the Acquire error, got %v", err)
+	}
+	if _, err := NewGenerator(0, WithAllocator(nil)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("A nil allocator should be rejected, got %v", err)
+	}
+	if _, err := NewGenerator(0, WithShardID(5), WithAllocator(&fakeAllocator{next: 3, last: 10})); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("WithShardID should conflict with WithAllocator in NewGenerator, got %v", err)
+	}
+
+	g, err := NewGenerator(0, WithAllocator(&fakeAllocator{next: 3, last: 10}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Reconfigure(WithShardID(4)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("WithShardID should conflict with the allocator, got %v", err)
+	}
+	if err := g.Reconfigure(WithAllocator(&fakeAllocator{})); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Reconfigure should not replace the allocator, got %v", err)
+	}
+	if err := g.Reconfigure(WithDryRun()); err != nil || g.ShardID() != 3 {
+		t.Errorf("Other options should keep the claimed shard: %v, shard %d", err, g.ShardID())
+	}
+
+	if err := g.Reconfigure(WithShardID(3)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("WithShardID should conflict with the allocator even for the claimed shard, got %v", err)
+	}
+
+	plain, _ := NewGenerator(1)
+	if err := plain.Reacquire(context.Background()); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Reacquire without an allocator should fail with ErrInvalidOption, got %v", err)
+	}
+}
+
+// hangingAllocator never answers, like an unreachable coordination service.
+type hangingAllocator struct{}
+
+func (hangingAllocator) Acquire(ctx context.Context) (uint32, <-chan struct{}, error) {
+	<-ctx.Done()
+	return 0, nil, ctx.Err()
+}
+
+func (hangingAllocator) Release(ctx context.Context) error { return nil }
+
+func TestNewGeneratorContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := NewGeneratorContext(ctx, 0, WithAllocator(hangingAllocator{})); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("The deadline should bound Acquire, got %v", err)
+	}
+
+	g, err := NewGeneratorContext(ctx, 7)
+	if err != nil {
+		t.Fatalf("Without an allocator, ctx is unused: %v", err)
+	}
+	if g.ShardID() != 7 {
+		t.Errorf("Expected shard 7, got %d", g.ShardID())
+	}
+}
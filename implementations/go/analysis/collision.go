@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// randomSpace is the number of distinct random values per microsecond and shard.
+const randomSpace = float64(microsharduuid.MaxRandom) + 1
+
+// CollisionProbability returns the probability that at least two IDs of one
+// shard collide during d, when independent generators of that shard issue
+// idsPerMicro IDs per microsecond on average (Poisson arrivals).
+//
+// IDs can only collide within the same microsecond, so by the birthday
+// bound each microsecond contributes idsPerMicro^2 / 2 expected pairs, each
+// colliding with probability 2^-36, giving 1 - exp(-d_us * idsPerMicro^2 / 2^37).
+//
+// It applies to the default mode; a single Generator with
+// WithMonotonicCounter never repeats its own IDs. Use it before adopting the
+// format to check that a write rate is safe: for example 1000 IDs per second
+// (0.001 per microsecond) on one shard for a year gives about 2.3e-4, while
+// a million per second (1 per microsecond) gives about 0.47 within a day.
+func CollisionProbability(idsPerMicro float64, d time.Duration) float64 {
+	return -math.Expm1(-ExpectedCollisions(idsPerMicro, d))
+}
+
+// ExpectedCollisions returns the expected number of colliding ID pairs in
+// one shard during d at idsPerMicro IDs per microsecond (see
+// CollisionProbability). Multiply by the number of equally busy shards for
+// a fleet-wide figure.
+func ExpectedCollisions(idsPerMicro float64, d time.Duration) float64 {
+	if idsPerMicro <= 0 || d <= 0 {
+		return 0
+	}
+	micros := float64(d) / float64(time.Microsecond)
+	return micros * idsPerMicro * idsPerMicro / 2 / randomSpace
+}
+
+// MaxSafeRate returns the highest rate, in IDs per microsecond and shard,
+// at which the collision probability over d stays at or below p
+// (0 < p < 1); it inverts CollisionProbability.
+func MaxSafeRate(p float64, d time.Duration) float64 {
+	if p <= 0 || d <= 0 {
+		return 0
+	}
+	if p >= 1 {
+		return math.Inf(1)
+	}
+	micros := float64(d) / float64(time.Microsecond)
+	return math.Sqrt(-math.Log1p(-p) * 2 * randomSpace / micros)
+}
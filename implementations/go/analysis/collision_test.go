@@ -0,0 +1,63 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCollisionProbability(t *testing.T) {
+	year := 365 * 24 * time.Hour
+
+	// 0.001 IDs/µs for a year: 3.1536e13 µs * 1e-6 / 2^37
+	want := -math.Expm1(-3.1536e13 * 1e-6 / (1 << 37))
+	if got := CollisionProbability(0.001, year); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Unexpected probability: %v (expected %v)", got, want)
+	}
+	if got := CollisionProbability(1, 24*time.Hour); math.Abs(got-0.4667) > 1e-3 {
+		t.Errorf("Unexpected probability for 1 ID/µs over a day: %v", got)
+	}
+
+	if CollisionProbability(0, year) != 0 || CollisionProbability(1, 0) != 0 || CollisionProbability(-1, year) != 0 {
+		t.Error("Zero rates and durations must never collide")
+	}
+	if got := CollisionProbability(1000, year); got != 1 {
+		t.Errorf("Saturated rates should give 1, got %v", got)
+	}
+
+	prev := 0.0
+	for _, rate := range []float64{1e-6, 1e-4, 1e-2, 1, 10} {
+		p := CollisionProbability(rate, time.Hour)
+		if p <= prev || p > 1 {
+			t.Errorf("Probability should grow with the rate: %v at %v after %v", p, rate, prev)
+		}
+		prev = p
+	}
+}
+
+func TestExpectedCollisions(t *testing.T) {
+	// One microsecond at 2^18.5 IDs/µs yields 2^37 / 2 / 2^36 = 1 pair
+	rate := math.Sqrt(1 << 37)
+	if got := ExpectedCollisions(rate, time.Microsecond); math.Abs(got-1) > 1e-9 {
+		t.Errorf("Unexpected expected collisions: %v", got)
+	}
+	if got := ExpectedCollisions(rate, time.Second); math.Abs(got-1e6) > 1e-3 {
+		t.Errorf("Expected collisions should scale with the duration: %v", got)
+	}
+}
+
+func TestMaxSafeRate(t *testing.T) {
+	year := 365 * 24 * time.Hour
+	for _, p := range []float64{1e-9, 1e-6, 0.01, 0.5} {
+		rate := MaxSafeRate(p, year)
+		if got := CollisionProbability(rate, year); math.Abs(got-p)/p > 1e-6 {
+			t.Errorf("MaxSafeRate(%v) = %v gives probability %v", p, rate, got)
+		}
+	}
+	if MaxSafeRate(0, year) != 0 || MaxSafeRate(0.5, 0) != 0 {
+		t.Error("Zero targets should give a zero rate")
+	}
+	if !math.IsInf(MaxSafeRate(1, year), 1) {
+		t.Error("A target of 1 should allow any rate")
+	}
+}
@@ -0,0 +1,147 @@
+// Package analysis derives operational statistics from streams of
+// MicroShardUUIDs using only the timestamps and shards embedded in the IDs,
+// so capacity planning needs no separate event timestamps.
+package analysis
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// histogramBuckets covers every possible gap of the 54-bit timestamp.
+const histogramBuckets = 56
+
+// Histogram is an exponential (base 2) histogram of durations in microseconds.
+// Bucket 0 counts zero gaps and bucket k counts gaps in [2^(k-1), 2^k).
+// Relative error of reported percentiles is therefore bounded by 2x, while
+// memory stays constant regardless of the number of observations.
+type Histogram struct {
+	Buckets  [histogramBuckets]uint64
+	Count    uint64 // Number of recorded gaps
+	Negative uint64 // Out-of-order gaps (not recorded in Buckets)
+	Sum      uint64 // Sum of recorded gaps in microseconds
+}
+
+// Add records a gap in microseconds. Negative gaps (out-of-order IDs) are
+// only counted in Negative.
+func (h *Histogram) Add(gapMicros int64) {
+	if gapMicros < 0 {
+		h.Negative++
+		return
+	}
+	h.Buckets[bits.Len64(uint64(gapMicros))]++
+	h.Count++
+	h.Sum += uint64(gapMicros)
+}
+
+// Merge adds all observations of other into h.
+func (h *Histogram) Merge(other *Histogram) {
+	for i, n := range other.Buckets {
+		h.Buckets[i] += n
+	}
+	h.Count += other.Count
+	h.Negative += other.Negative
+	h.Sum += other.Sum
+}
+
+// Mean returns the average recorded gap.
+func (h *Histogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return time.Duration(h.Sum/h.Count) * time.Microsecond
+}
+
+// Percentile returns the approximate p-th percentile (0 < p <= 1) of the
+// recorded gaps, interpolating linearly inside the matching bucket.
+func (h *Histogram) Percentile(p float64) time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	if p > 1 {
+		p = 1
+	}
+
+	rank := p * float64(h.Count)
+	var cumulative float64
+	for k, n := range h.Buckets {
+		if n == 0 {
+			continue
+		}
+		if cumulative+float64(n) >= rank {
+			if k == 0 {
+				return 0
+			}
+			lower := float64(uint64(1) << uint(k-1))
+			frac := (rank - cumulative) / float64(n)
+			return time.Duration(lower+frac*lower) * time.Microsecond
+		}
+		cumulative += float64(n)
+	}
+	// Unreachable for p <= 1 (rank never exceeds Count)
+	return time.Duration(math.MaxInt64)
+}
+
+// InterArrival computes per-shard inter-arrival time distributions from an ID stream.
+// It is not safe for concurrent use.
+type InterArrival struct {
+	shards map[uint32]*interArrivalShard
+}
+
+type interArrivalShard struct {
+	last uint64 // Last seen timestamp (Unix Microseconds)
+	hist Histogram
+}
+
+// NewInterArrival creates an empty analyzer.
+func NewInterArrival() *InterArrival {
+	return &InterArrival{shards: make(map[uint32]*interArrivalShard)}
+}
+
+// Observe records an ID. The first ID of each shard only establishes its baseline.
+func (a *InterArrival) Observe(id microsharduuid.MicroShardUUID) {
+	micros := uint64(id.Time().UnixMicro())
+	shard := id.ShardID()
+
+	s, ok := a.shards[shard]
+	if !ok {
+		a.shards[shard] = &interArrivalShard{last: micros}
+		return
+	}
+
+	s.hist.Add(int64(micros) - int64(s.last))
+	if micros > s.last {
+		s.last = micros
+	}
+}
+
+// Shards returns the observed Shard IDs in ascending order.
+func (a *InterArrival) Shards() []uint32 {
+	out := make([]uint32, 0, len(a.shards))
+	for shard := range a.shards {
+		out = append(out, shard)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// Shard returns a copy of the histogram for one shard (empty if the shard was never seen).
+func (a *InterArrival) Shard(shardID uint32) Histogram {
+	if s, ok := a.shards[shardID]; ok {
+		return s.hist
+	}
+	return Histogram{}
+}
+
+// Total returns the merged histogram of all shards.
+func (a *InterArrival) Total() Histogram {
+	var total Histogram
+	for _, s := range a.shards {
+		total.Merge(&s.hist)
+	}
+	return total
+}
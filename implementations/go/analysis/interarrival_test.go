@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestHistogramPercentiles(t *testing.T) {
+	var h Histogram
+	for i := 0; i < 90; i++ {
+		h.Add(1000) // 1ms
+	}
+	for i := 0; i < 10; i++ {
+		h.Add(100000) // 100ms
+	}
+
+	p50 := h.Percentile(0.5)
+	if p50 < 512*time.Microsecond || p50 > 1024*time.Microsecond {
+		t.Errorf("p50 should be within the 1ms bucket, got %v", p50)
+	}
+
+	p99 := h.Percentile(0.99)
+	if p99 < 65536*time.Microsecond || p99 > 131072*time.Microsecond {
+		t.Errorf("p99 should be within the 100ms bucket, got %v", p99)
+	}
+
+	if h.Mean() != 10900*time.Microsecond {
+		t.Errorf("Unexpected mean: %v", h.Mean())
+	}
+
+	h.Add(-5)
+	if h.Negative != 1 || h.Count != 100 {
+		t.Errorf("Negative gaps must be counted separately: %+v", h)
+	}
+}
+
+func TestInterArrivalPerShard(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := NewInterArrival()
+
+	// Shard 1 every 10ms, shard 2 every second, interleaved
+	for i := 0; i < 100; i++ {
+		a.Observe(microsharduuid.MustFromTime(base.Add(time.Duration(i)*10*time.Millisecond), 1))
+		if i%10 == 0 {
+			a.Observe(microsharduuid.MustFromTime(base.Add(time.Duration(i)*100*time.Millisecond), 2))
+		}
+	}
+
+	if shards := a.Shards(); len(shards) != 2 || shards[0] != 1 || shards[1] != 2 {
+		t.Fatalf("Unexpected shards: %v", shards)
+	}
+
+	fast := a.Shard(1)
+	if fast.Count != 99 || fast.Mean() != 10*time.Millisecond {
+		t.Errorf("Unexpected shard 1 stats: count=%d mean=%v", fast.Count, fast.Mean())
+	}
+
+	slow := a.Shard(2)
+	if slow.Count != 9 || slow.Mean() != time.Second {
+		t.Errorf("Unexpected shard 2 stats: count=%d mean=%v", slow.Count, slow.Mean())
+	}
+
+	if total := a.Total(); total.Count != 108 {
+		t.Errorf("Unexpected total count: %d", total.Count)
+	}
+}
@@ -0,0 +1,172 @@
+package analysis
+
+import (
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Defaults for OrderConfig.
+const (
+	DefaultOrderWindow        = 4096
+	DefaultOrderMaxViolations = 100
+)
+
+// OrderConfig configures an OrderValidator.
+type OrderConfig struct {
+	// PerShard checks time order within each shard instead of across the
+	// whole stream, for streams that interleave hosts with slightly
+	// different clocks. It keeps one timestamp per shard.
+	PerShard bool
+	// Window is how many recent IDs are remembered to detect duplicates
+	// (DefaultOrderWindow if zero). Duplicates further apart are missed.
+	Window int
+	// MaxViolations is how many violations are kept with their positions
+	// (DefaultOrderMaxViolations if zero); later ones are only counted.
+	MaxViolations int
+}
+
+// ViolationKind classifies a Violation.
+type ViolationKind int
+
+const (
+	// OutOfOrder is an ID older than the newest ID seen before it.
+	OutOfOrder ViolationKind = iota + 1
+	// Duplicate is an ID equal to one seen within the window.
+	Duplicate
+)
+
+func (k ViolationKind) String() string {
+	switch k {
+	case OutOfOrder:
+		return "out of order"
+	case Duplicate:
+		return "duplicate"
+	}
+	return "unknown"
+}
+
+// Violation is an ordering problem found by an OrderValidator.
+type Violation struct {
+	Position uint64 // Zero-based index of the ID in the stream
+	Kind     ViolationKind
+	ID       microsharduuid.MicroShardUUID
+	// Regression is how far ID's timestamp lies behind the newest timestamp
+	// seen before it (zero for duplicates).
+	Regression time.Duration
+}
+
+// OrderReport summarizes an OrderValidator run.
+type OrderReport struct {
+	Count         uint64
+	OutOfOrder    uint64
+	Duplicates    uint64
+	MaxRegression time.Duration // Largest backwards time jump, a measure of clock trouble
+	Violations    []Violation   // First MaxViolations violations, in stream order
+}
+
+// Sorted reports whether no violation was found.
+func (r OrderReport) Sorted() bool {
+	return r.OutOfOrder == 0 && r.Duplicates == 0
+}
+
+// OrderValidator checks a stream of IDs (exports, CDC feeds) for time
+// ordering and duplicates with bounded memory, to detect clock problems.
+// It is not safe for concurrent use.
+type OrderValidator struct {
+	cfg    OrderConfig
+	report OrderReport
+
+	newest      uint64            // Newest timestamp so far (global mode)
+	shardNewest map[uint32]uint64 // Newest timestamp per shard (PerShard mode)
+
+	ring   []microsharduuid.MicroShardUUID // Last Window IDs
+	next   int                             // Ring write position
+	recent map[microsharduuid.MicroShardUUID]int
+}
+
+// NewOrderValidator creates a validator for cfg.
+func NewOrderValidator(cfg OrderConfig) *OrderValidator {
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultOrderWindow
+	}
+	if cfg.MaxViolations <= 0 {
+		cfg.MaxViolations = DefaultOrderMaxViolations
+	}
+	return &OrderValidator{
+		cfg:         cfg,
+		shardNewest: make(map[uint32]uint64),
+		ring:        make([]microsharduuid.MicroShardUUID, 0, cfg.Window),
+		recent:      make(map[microsharduuid.MicroShardUUID]int, cfg.Window),
+	}
+}
+
+// Observe checks the next ID of the stream.
+func (v *OrderValidator) Observe(id microsharduuid.MicroShardUUID) {
+	pos := v.report.Count
+	v.report.Count++
+	micros := uint64(id.UnixMicro())
+
+	if v.recent[id] > 0 {
+		v.report.Duplicates++
+		v.record(Violation{Position: pos, Kind: Duplicate, ID: id})
+	} else if newest, ok := v.newestFor(id); ok && micros < newest {
+		regression := time.Duration(newest-micros) * time.Microsecond
+		v.report.OutOfOrder++
+		if regression > v.report.MaxRegression {
+			v.report.MaxRegression = regression
+		}
+		v.record(Violation{Position: pos, Kind: OutOfOrder, ID: id, Regression: regression})
+	}
+
+	v.advance(id, micros)
+	v.remember(id)
+}
+
+// Report returns the results so far.
+func (v *OrderValidator) Report() OrderReport {
+	r := v.report
+	r.Violations = append([]Violation(nil), r.Violations...)
+	return r
+}
+
+func (v *OrderValidator) newestFor(id microsharduuid.MicroShardUUID) (uint64, bool) {
+	if v.cfg.PerShard {
+		newest, ok := v.shardNewest[id.ShardID()]
+		return newest, ok
+	}
+	return v.newest, v.report.Count > 1
+}
+
+func (v *OrderValidator) advance(id microsharduuid.MicroShardUUID, micros uint64) {
+	if v.cfg.PerShard {
+		if newest, ok := v.shardNewest[id.ShardID()]; !ok || micros > newest {
+			v.shardNewest[id.ShardID()] = micros
+		}
+		return
+	}
+	if micros > v.newest {
+		v.newest = micros
+	}
+}
+
+// remember adds id to the duplicate window, evicting the oldest entry.
+func (v *OrderValidator) remember(id microsharduuid.MicroShardUUID) {
+	if len(v.ring) < v.cfg.Window {
+		v.ring = append(v.ring, id)
+	} else {
+		old := v.ring[v.next]
+		if v.recent[old]--; v.recent[old] == 0 {
+			delete(v.recent, old)
+		}
+		v.ring[v.next] = id
+		v.next = (v.next + 1) % v.cfg.Window
+	}
+	v.recent[id]++
+}
+
+func (v *OrderValidator) record(vi Violation) {
+	if len(v.report.Violations) < v.cfg.MaxViolations {
+		v.report.Violations = append(v.report.Violations, vi)
+	}
+}
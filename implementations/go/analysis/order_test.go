@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestOrderValidator(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(ms int, shard uint32) microsharduuid.MicroShardUUID {
+		id, _ := microsharduuid.FromTime(base.Add(time.Duration(ms)*time.Millisecond), shard)
+		return id
+	}
+
+	a, b, c := at(0, 1), at(10, 1), at(20, 2)
+	stream := []microsharduuid.MicroShardUUID{a, b, c, b, at(5, 1), at(30, 2), at(30, 2)}
+
+	v := NewOrderValidator(OrderConfig{})
+	for _, id := range stream {
+		v.Observe(id)
+	}
+	r := v.Report()
+
+	if r.Count != 7 || r.Sorted() || r.Duplicates != 1 || r.OutOfOrder != 1 {
+		t.Fatalf("Unexpected report: %+v", r)
+	}
+	if r.MaxRegression != 15*time.Millisecond {
+		t.Errorf("MaxRegression %v, expected 15ms", r.MaxRegression)
+	}
+	if len(r.Violations) != 2 || r.Violations[0] != (Violation{Position: 3, Kind: Duplicate, ID: b}) ||
+		r.Violations[1].Position != 4 || r.Violations[1].Kind != OutOfOrder {
+		t.Errorf("Unexpected violations: %+v", r.Violations)
+	}
+	if r.Violations[0].Kind.String() != "duplicate" {
+		t.Errorf("Unexpected kind name %q", r.Violations[0].Kind)
+	}
+}
+
+func TestOrderValidatorPerShard(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Shard 2's clock runs 50ms behind shard 1's: fine per shard, not globally
+	var stream []microsharduuid.MicroShardUUID
+	for i := 0; i < 10; i++ {
+		s1, _ := microsharduuid.FromTime(base.Add(time.Duration(i*10)*time.Millisecond), 1)
+		s2, _ := microsharduuid.FromTime(base.Add(time.Duration(i*10-50)*time.Millisecond), 2)
+		stream = append(stream, s1, s2)
+	}
+
+	global := NewOrderValidator(OrderConfig{})
+	perShard := NewOrderValidator(OrderConfig{PerShard: true})
+	for _, id := range stream {
+		global.Observe(id)
+		perShard.Observe(id)
+	}
+	if global.Report().OutOfOrder != 10 {
+		t.Errorf("Global mode: expected 10 out-of-order IDs, got %d", global.Report().OutOfOrder)
+	}
+	if !perShard.Report().Sorted() {
+		t.Errorf("Per-shard mode: unexpected violations %+v", perShard.Report().Violations)
+	}
+}
+
+func TestOrderValidatorBounds(t *testing.T) {
+	v := NewOrderValidator(OrderConfig{Window: 2, MaxViolations: 1})
+	a, _ := microsharduuid.Generate(1)
+	b, _ := microsharduuid.Generate(2)
+	c, _ := microsharduuid.Generate(3)
+
+	// a repeats within the window twice, then falls out of it
+	for _, id := range []microsharduuid.MicroShardUUID{a, a, a, b, c, a} {
+		v.Observe(id)
+	}
+	r := v.Report()
+	if r.Duplicates != 2 || len(r.Violations) != 1 || len(v.recent) > 2 {
+		t.Errorf("Unexpected bounded report: %+v (window %d)", r, len(v.recent))
+	}
+}
@@ -0,0 +1,116 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Stats summarizes an ID stream for capacity planning and hot-shard
+// detection: shard distribution, covered time range, per-shard rates, and
+// skew. Memory grows with the number of distinct shards, not IDs.
+// It is not safe for concurrent use.
+type Stats struct {
+	count       uint64
+	first, last uint64 // Time range of all IDs (Unix Microseconds)
+	shards      map[uint32]*shardStats
+}
+
+type shardStats struct {
+	count       uint64
+	first, last uint64
+}
+
+// NewStats creates an empty analyzer.
+func NewStats() *Stats {
+	return &Stats{shards: make(map[uint32]*shardStats)}
+}
+
+// Observe records an ID.
+func (s *Stats) Observe(id microsharduuid.MicroShardUUID) {
+	micros := uint64(id.UnixMicro())
+	if s.count == 0 || micros < s.first {
+		s.first = micros
+	}
+	if micros > s.last {
+		s.last = micros
+	}
+	s.count++
+
+	sh, ok := s.shards[id.ShardID()]
+	if !ok {
+		s.shards[id.ShardID()] = &shardStats{count: 1, first: micros, last: micros}
+		return
+	}
+	sh.count++
+	if micros < sh.first {
+		sh.first = micros
+	}
+	if micros > sh.last {
+		sh.last = micros
+	}
+}
+
+// ShardStats describes one shard of a Report.
+type ShardStats struct {
+	ShardID     uint32
+	Count       uint64
+	First, Last time.Time // Oldest and newest ID of the shard
+	Share       float64   // Fraction of all IDs
+	Rate        float64   // IDs per second over the span of the whole stream
+}
+
+// Report is a snapshot of Stats.
+type Report struct {
+	Count       uint64
+	First, Last time.Time     // Oldest and newest ID (zero if Count is 0)
+	Span        time.Duration // Last - First
+	Rate        float64       // IDs per second over Span (0 if Span is 0)
+	Shards      []ShardStats  // Busiest shard first; ties by ascending Shard ID
+	// Skew is the busiest shard's count divided by the mean count per
+	// observed shard: 1 means perfectly even, 2 means the hottest shard
+	// takes twice its fair share.
+	Skew float64
+}
+
+// Report summarizes the IDs observed so far.
+func (s *Stats) Report() Report {
+	r := Report{Count: s.count}
+	if s.count == 0 {
+		return r
+	}
+	r.First = time.UnixMicro(int64(s.first)).UTC()
+	r.Last = time.UnixMicro(int64(s.last)).UTC()
+	r.Span = r.Last.Sub(r.First)
+	seconds := r.Span.Seconds()
+	if seconds > 0 {
+		r.Rate = float64(s.count) / seconds
+	}
+
+	r.Shards = make([]ShardStats, 0, len(s.shards))
+	for id, sh := range s.shards {
+		st := ShardStats{
+			ShardID: id,
+			Count:   sh.count,
+			First:   time.UnixMicro(int64(sh.first)).UTC(),
+			Last:    time.UnixMicro(int64(sh.last)).UTC(),
+			Share:   float64(sh.count) / float64(s.count),
+		}
+		if seconds > 0 {
+			st.Rate = float64(sh.count) / seconds
+		}
+		r.Shards = append(r.Shards, st)
+	}
+	sort.Slice(r.Shards, func(i, j int) bool {
+		a, b := r.Shards[i], r.Shards[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.ShardID < b.ShardID
+	})
+
+	mean := float64(s.count) / float64(len(s.shards))
+	r.Skew = float64(r.Shards[0].Count) / mean
+	return r
+}
@@ -0,0 +1,59 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestStatsReport(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewStats()
+
+	// Shard 7 is hot: 60 IDs, shards 1 and 2 get 20 each, over 10 seconds
+	for i := 0; i < 100; i++ {
+		shard := uint32(7)
+		if i%5 == 1 {
+			shard = 1
+		} else if i%5 == 2 {
+			shard = 2
+		}
+		id, _ := microsharduuid.FromTime(base.Add(time.Duration(i)*100*time.Millisecond), shard)
+		s.Observe(id)
+	}
+	// An out-of-order ID widens the range backwards
+	early, _ := microsharduuid.FromTime(base.Add(-100*time.Millisecond), 2)
+	s.Observe(early)
+
+	r := s.Report()
+	if r.Count != 101 || !r.First.Equal(base.Add(-100*time.Millisecond)) || r.Span != 10*time.Second {
+		t.Errorf("Unexpected totals: count %d, first %s, span %v", r.Count, r.First, r.Span)
+	}
+	if r.Rate != 10.1 {
+		t.Errorf("Rate %v, expected 10.1/s", r.Rate)
+	}
+	if len(r.Shards) != 3 || r.Shards[0].ShardID != 7 || r.Shards[0].Count != 60 || r.Shards[1].ShardID != 2 {
+		t.Fatalf("Unexpected shards: %+v", r.Shards)
+	}
+	if hot := r.Shards[0]; hot.Rate != 6 || hot.Share != 60.0/101 || !hot.First.Equal(base) {
+		t.Errorf("Unexpected hot shard stats: %+v", hot)
+	}
+	if want := 60 / (101.0 / 3); r.Skew != want {
+		t.Errorf("Skew %v, expected %v", r.Skew, want)
+	}
+}
+
+func TestStatsEmpty(t *testing.T) {
+	r := NewStats().Report()
+	if r.Count != 0 || len(r.Shards) != 0 || r.Skew != 0 || !r.First.IsZero() {
+		t.Errorf("Unexpected empty report: %+v", r)
+	}
+
+	s := NewStats()
+	id, _ := microsharduuid.Generate(3)
+	s.Observe(id)
+	if r := s.Report(); r.Rate != 0 || r.Skew != 1 {
+		t.Errorf("Single ID: rate %v, skew %v", r.Rate, r.Skew)
+	}
+}
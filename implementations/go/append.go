@@ -0,0 +1,124 @@
+package microsharduuid
+
+// ==========================================
+// Allocation-Free Serialization
+// ==========================================
+
+// AppendText appends the canonical string form to b and returns the extended buffer.
+// It implements encoding.TextAppender (Go 1.24) and does not allocate
+// when b has at least 36 bytes of spare capacity.
+func (u MicroShardUUID) AppendText(b []byte) ([]byte, error) {
+	return u.appendCanonical(b), nil
+}
+
+// AppendBinary appends the raw 16-byte Big Endian form to b and returns the extended buffer.
+// It implements encoding.BinaryAppender (Go 1.24) and does not allocate
+// when b has at least 16 bytes of spare capacity.
+func (u MicroShardUUID) AppendBinary(b []byte) ([]byte, error) {
+	for shift := 56; shift >= 0; shift -= 8 {
+		b = append(b, byte(u.High>>uint(shift)))
+	}
+	for shift := 56; shift >= 0; shift -= 8 {
+		b = append(b, byte(u.Low>>uint(shift)))
+	}
+	return b, nil
+}
+
+// appendCanonical appends xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx to b.
+func (u MicroShardUUID) appendCanonical(b []byte) []byte {
+	var buf [36]byte
+	u.encodeCanonical(&buf)
+	return append(b, buf[:]...)
+}
+
+// hexDigits is the lowercase hex lookup table.
+const hexDigits = "0123456789abcdef"
+
+// canonicalOffsets holds the string position of each of the 16 bytes in
+// the 8-4-4-4-12 layout (dashes sit at 8, 13, 18, and 23).
+var canonicalOffsets = [16]int{0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34}
+
+// encodeCanonical writes the canonical form into buf using a lookup table,
+// avoiding fmt and any heap allocation.
+func (u MicroShardUUID) encodeCanonical(buf *[36]byte) {
+	for i := 0; i < 8; i++ {
+		hb := byte(u.High >> uint(56-8*i))
+		lb := byte(u.Low >> uint(56-8*i))
+
+		o := canonicalOffsets[i]
+		buf[o], buf[o+1] = hexDigits[hb>>4], hexDigits[hb&0xF]
+
+		o = canonicalOffsets[8+i]
+		buf[o], buf[o+1] = hexDigits[lb>>4], hexDigits[lb&0xF]
+	}
+	buf[8], buf[13], buf[18], buf[23] = '-', '-', '-', '-'
+}
+
+// hexDecode maps an ASCII byte to its nibble value (0xFF = invalid).
+var hexDecode = func() [256]byte {
+	var table [256]byte
+	for i := range table {
+		table[i] = 0xFF
+	}
+	for i := 0; i < 16; i++ {
+		table[hexDigits[i]] = byte(i)
+		table["0123456789ABCDEF"[i]] = byte(i)
+	}
+	return table
+}()
+
+// decodeCanonical decodes the exact 8-4-4-4-12 layout (either hex case)
+// without allocating. ok is false for any other input.
+func decodeCanonical(s string) (high, low uint64, ok bool) {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return 0, 0, false
+	}
+
+	for i := 0; i < 16; i++ {
+		o := canonicalOffsets[i]
+		hi, lo := hexDecode[s[o]], hexDecode[s[o+1]]
+		if hi > 0xF || lo > 0xF {
+			return 0, 0, false
+		}
+		b := uint64(hi<<4 | lo)
+		if i < 8 {
+			high = high<<8 | b
+		} else {
+			low = low<<8 | b
+		}
+	}
+	return high, low, true
+}
+
+// decodeDashed decodes 32 hex digits (either case) with dashes allowed
+// anywhere, without allocating. It returns ErrInvalidLength if s does not
+// hold exactly 32 non-dash characters and ErrInvalidHex for other bytes.
+func decodeDashed(s string) (high, low uint64, err error) {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != '-' {
+			n++
+		}
+	}
+	if n != 32 {
+		return 0, 0, ErrInvalidLength
+	}
+
+	n = 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '-' {
+			continue
+		}
+		v := hexDecode[s[i]]
+		if v > 0xF {
+			return 0, 0, ErrInvalidHex
+		}
+		if n < 16 {
+			high = high<<4 | uint64(v)
+		} else {
+			low = low<<4 | uint64(v)
+		}
+		n++
+	}
+	return high, low, nil
+}
@@ -0,0 +1,10 @@
+//go:build go1.24
+
+package microsharduuid
+
+import "encoding"
+
+var (
+	_ encoding.TextAppender   = MicroShardUUID{}
+	_ encoding.BinaryAppender = MicroShardUUID{}
+)
@@ -0,0 +1,46 @@
+package microsharduuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAppendText(t *testing.T) {
+	uid, _ := Generate(99)
+
+	out, err := uid.AppendText([]byte("id="))
+	if err != nil {
+		t.Fatalf("AppendText failed: %v", err)
+	}
+	if string(out) != "id="+uid.String() {
+		t.Errorf("Unexpected AppendText output: %s", out)
+	}
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = uid.AppendText(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("AppendText allocated %.0f times, expected 0", allocs)
+	}
+}
+
+func TestAppendBinary(t *testing.T) {
+	uid, _ := Generate(99)
+
+	out, err := uid.AppendBinary([]byte{0xFF})
+	if err != nil {
+		t.Fatalf("AppendBinary failed: %v", err)
+	}
+	if out[0] != 0xFF || !bytes.Equal(out[1:], uid.Bytes()) {
+		t.Errorf("Unexpected AppendBinary output: %x", out)
+	}
+
+	buf := make([]byte, 0, 16)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = uid.AppendBinary(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("AppendBinary allocated %.0f times, expected 0", allocs)
+	}
+}
@@ -0,0 +1,73 @@
+package microsharduuid
+
+// ==========================================
+// Base32 (ULID-style) Encoding
+// ==========================================
+
+// crockfordAlphabet is the Crockford Base32 alphabet used by ULID.
+// Its characters are in ascending ASCII order, so the lexical order of an
+// encoded string matches the binary order of the value it encodes.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Base32Len is the length of a Base32 encoded MicroShardUUID.
+const Base32Len = 26
+
+// crockfordDecode maps an ASCII byte to its 5-bit value (0xFF = invalid).
+// Lowercase letters and the Crockford aliases (I, L -> 1 and O -> 0) are accepted.
+var crockfordDecode = func() [256]byte {
+	var table [256]byte
+	for i := range table {
+		table[i] = 0xFF
+	}
+	for i := 0; i < len(crockfordAlphabet); i++ {
+		c := crockfordAlphabet[i]
+		table[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			table[c+('a'-'A')] = byte(i)
+		}
+	}
+	table['I'], table['i'] = 1, 1
+	table['L'], table['l'] = 1, 1
+	table['O'], table['o'] = 0, 0
+	return table
+}()
+
+// Base32 returns the 26-character Crockford Base32 representation (ULID-style).
+// The 128 bits are left-padded with 2 zero bits, so the first character is
+// always in the range 0-7. Sorting the strings sorts the IDs chronologically.
+func (u MicroShardUUID) Base32() string {
+	var buf [Base32Len]byte
+	high, low := u.High, u.Low
+	for i := Base32Len - 1; i >= 0; i-- {
+		buf[i] = crockfordAlphabet[low&0x1F]
+		// Shift the 128-bit value right by 5
+		low = (low >> 5) | (high << 59)
+		high >>= 5
+	}
+	return string(buf[:])
+}
+
+// ParseBase32 converts a 26-character Crockford Base32 string into a MicroShardUUID.
+// It validates length, alphabet, overflow, Version (8), and Variant (2).
+func ParseBase32(s string) (MicroShardUUID, error) {
+	if len(s) != Base32Len {
+		return MicroShardUUID{}, newParseError(FormatBase32, len(s), ErrInvalidLength, "invalid Base32 length")
+	}
+
+	var high, low uint64
+	for i := 0; i < Base32Len; i++ {
+		v := crockfordDecode[s[i]]
+		if v == 0xFF {
+			return MicroShardUUID{}, newParseError(FormatBase32, len(s), ErrInvalidEncoding, "invalid Base32 character")
+		}
+		// The first character only carries 3 bits
+		if i == 0 && v > 7 {
+			return MicroShardUUID{}, newParseError(FormatBase32, len(s), ErrInvalidEncoding, "invalid Base32 (overflows 128 bits)")
+		}
+		// Shift the 128-bit value left by 5 and append
+		high = (high << 5) | (low >> 59)
+		low = (low << 5) | uint64(v)
+	}
+
+	return fromHighLow(high, low, FormatBase32, len(s))
+}
@@ -0,0 +1,73 @@
+package microsharduuid
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBase32Roundtrip(t *testing.T) {
+	original, _ := Generate(4242)
+	encoded := original.Base32()
+
+	if len(encoded) != Base32Len {
+		t.Fatalf("Base32 length mismatch. Expected %d, got %d", Base32Len, len(encoded))
+	}
+
+	parsed, err := ParseBase32(encoded)
+	if err != nil {
+		t.Fatalf("Failed to parse valid Base32: %v", err)
+	}
+	if parsed != original {
+		t.Errorf("Roundtrip failed. Original %v != Parsed %v", original, parsed)
+	}
+
+	// Decoding is case-insensitive
+	parsed, err = ParseBase32(strings.ToLower(encoded))
+	if err != nil || parsed != original {
+		t.Errorf("Lowercase Base32 should decode to the same UUID")
+	}
+}
+
+func TestBase32LexicalSorting(t *testing.T) {
+	t1 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	uidOld, _ := FromTime(t1, 4294967295)
+	uidNew, _ := FromTime(t1.Add(time.Microsecond), 0)
+
+	if uidOld.Base32() >= uidNew.Base32() {
+		t.Error("Base32 sorting failed. Old ID string should be lexically smaller.")
+	}
+
+	// Binary order must match string order for arbitrary bit patterns
+	a := MicroShardUUID{High: 0x8000, Low: 0}
+	b := MicroShardUUID{High: 0x8000, Low: 1}
+	if a.Base32() >= b.Base32() {
+		t.Error("Base32 sorting failed on Low bits (same High bits)")
+	}
+}
+
+func TestBase32Errors(t *testing.T) {
+	uid, _ := Generate(1)
+	valid := uid.Base32()
+
+	// Invalid length
+	if _, err := ParseBase32(valid[:25]); err == nil {
+		t.Error("Should have errored on invalid length")
+	}
+
+	// Invalid character ('U' is excluded from Crockford)
+	if _, err := ParseBase32("U" + valid[1:]); err == nil {
+		t.Error("Should have errored on invalid character")
+	}
+
+	// Overflow (first character > 7)
+	if _, err := ParseBase32("8" + valid[1:]); err == nil {
+		t.Error("Should have errored on 128-bit overflow")
+	}
+
+	// Wrong version
+	v4 := MicroShardUUID{High: uid.High&^(0xF<<12) | (4 << 12), Low: uid.Low}
+	if _, err := ParseBase32(v4.Base32()); err == nil {
+		t.Error("Should have errored on invalid version")
+	}
+}
@@ -0,0 +1,72 @@
+package microsharduuid
+
+import "math/bits"
+
+// ==========================================
+// Base58 Encoding
+// ==========================================
+
+// base58Alphabet is the Bitcoin Base58 alphabet (no 0, O, I, l).
+// Its characters are in ascending ASCII order, so fixed-width encodings
+// sort in the same order as the values they encode.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Base58Len is the length of a Base58 encoded MicroShardUUID.
+// 58^22 > 2^128, so every ID fits in 22 characters.
+const Base58Len = 22
+
+// base58Decode maps an ASCII byte to its digit value (0xFF = invalid).
+var base58Decode = func() [256]byte {
+	var table [256]byte
+	for i := range table {
+		table[i] = 0xFF
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		table[base58Alphabet[i]] = byte(i)
+	}
+	return table
+}()
+
+// Base58 returns the 22-character Base58 representation, left-padded with '1' (zero).
+// The fixed width keeps the strings lexically sortable in creation order.
+func (u MicroShardUUID) Base58() string {
+	var buf [Base58Len]byte
+	high, low := u.High, u.Low
+	for i := Base58Len - 1; i >= 0; i-- {
+		// Divide the 128-bit value by 58
+		var rem uint64
+		high, rem = high/58, high%58
+		low, rem = bits.Div64(rem, low, 58)
+		buf[i] = base58Alphabet[rem]
+	}
+	return string(buf[:])
+}
+
+// ParseBase58 converts a 22-character Base58 string into a MicroShardUUID.
+// It validates length, alphabet, overflow, Version (8), and Variant (2).
+func ParseBase58(s string) (MicroShardUUID, error) {
+	if len(s) != Base58Len {
+		return MicroShardUUID{}, newParseError(FormatBase58, len(s), ErrInvalidLength, "invalid Base58 length")
+	}
+
+	var high, low uint64
+	for i := 0; i < Base58Len; i++ {
+		v := base58Decode[s[i]]
+		if v == 0xFF {
+			return MicroShardUUID{}, newParseError(FormatBase58, len(s), ErrInvalidEncoding, "invalid Base58 character")
+		}
+
+		// Multiply the 128-bit value by 58 and add the digit
+		carry, lo := bits.Mul64(low, 58)
+		hiCarry, hi := bits.Mul64(high, 58)
+		hi, c1 := bits.Add64(hi, carry, 0)
+		lo, c2 := bits.Add64(lo, uint64(v), 0)
+		hi, c3 := bits.Add64(hi, c2, 0)
+		if hiCarry != 0 || c1 != 0 || c3 != 0 {
+			return MicroShardUUID{}, newParseError(FormatBase58, len(s), ErrInvalidEncoding, "invalid Base58 (overflows 128 bits)")
+		}
+		high, low = hi, lo
+	}
+
+	return fromHighLow(high, low, FormatBase58, len(s))
+}
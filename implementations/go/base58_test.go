@@ -0,0 +1,52 @@
+package microsharduuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBase58Roundtrip(t *testing.T) {
+	original, _ := Generate(31337)
+	encoded := original.Base58()
+
+	if len(encoded) != Base58Len {
+		t.Fatalf("Base58 length mismatch. Expected %d, got %d", Base58Len, len(encoded))
+	}
+
+	parsed, err := ParseBase58(encoded)
+	if err != nil {
+		t.Fatalf("Failed to parse valid Base58: %v", err)
+	}
+	if parsed != original {
+		t.Errorf("Roundtrip failed. Original %v != Parsed %v", original, parsed)
+	}
+}
+
+func TestBase58LexicalSorting(t *testing.T) {
+	t1 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	uidOld, _ := FromTime(t1, 4294967295)
+	uidNew, _ := FromTime(t1.Add(time.Microsecond), 0)
+
+	if uidOld.Base58() >= uidNew.Base58() {
+		t.Error("Base58 sorting failed. Old ID string should be lexically smaller.")
+	}
+}
+
+func TestBase58Errors(t *testing.T) {
+	uid, _ := Generate(1)
+	valid := uid.Base58()
+
+	if _, err := ParseBase58(valid[1:]); err == nil {
+		t.Error("Should have errored on invalid length")
+	}
+
+	// '0' is excluded from the alphabet
+	if _, err := ParseBase58("0" + valid[1:]); err == nil {
+		t.Error("Should have errored on invalid character")
+	}
+
+	// The largest 22-digit value exceeds 128 bits
+	if _, err := ParseBase58("zzzzzzzzzzzzzzzzzzzzzz"); err == nil {
+		t.Error("Should have errored on 128-bit overflow")
+	}
+}
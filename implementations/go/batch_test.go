@@ -0,0 +1,78 @@
+package microsharduuid
+
+import "testing"
+
+func TestGeneratorNewIDs(t *testing.T) {
+	gen, _ := NewGenerator(808)
+
+	ids, err := gen.NewIDs(1000)
+	if err != nil {
+		t.Fatalf("NewIDs failed: %v", err)
+	}
+	if len(ids) != 1000 {
+		t.Fatalf("Expected 1000 IDs, got %d", len(ids))
+	}
+
+	for i, id := range ids {
+		if id.ShardID() != 808 {
+			t.Fatalf("ID %d has wrong shard %d", i, id.ShardID())
+		}
+		if _, err := Parse(id.String()); err != nil {
+			t.Fatalf("ID %d is invalid: %v", i, err)
+		}
+		if i > 0 && !id.After(ids[i-1]) {
+			t.Fatalf("Batch is not strictly increasing at %d", i)
+		}
+	}
+
+	if ids, err := gen.NewIDs(0); err != nil || len(ids) != 0 {
+		t.Errorf("NewIDs(0) should return an empty batch, got %d (%v)", len(ids), err)
+	}
+}
+
+func TestGenerateBatchDuplicateRandoms(t *testing.T) {
+	// An entropy source returning all-ones forces every random value to collide
+	// at MaxRandom, exercising the increment and carry paths
+	withEntropySource(t, constantReader(0xFF))
+
+	ids, err := GenerateBatch(1, 3)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+	for i := 1; i < len(ids); i++ {
+		if !ids[i].After(ids[i-1]) {
+			t.Fatalf("Batch is not strictly increasing at %d", i)
+		}
+	}
+	if !ids[2].Time().After(ids[0].Time()) {
+		t.Error("Exhausted random space should advance the timestamp")
+	}
+}
+
+func TestNewIDsGlobalMonotonic(t *testing.T) {
+	gen, _ := NewGenerator(5, WithGlobalMonotonic())
+	ids, _ := gen.NewIDs(50)
+
+	for i := 1; i < len(ids); i++ {
+		if !ids[i].Time().After(ids[i-1].Time()) {
+			t.Fatalf("Global monotonic batch must use distinct timestamps (index %d)", i)
+		}
+	}
+}
+
+type constantReader byte
+
+func (r constantReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
+
+func BenchmarkNewIDs1000(b *testing.B) {
+	gen, _ := NewGenerator(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = gen.NewIDs(1000)
+	}
+}
@@ -0,0 +1,120 @@
+package microsharduuid
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStringAllocations(t *testing.T) {
+	uid, _ := Generate(1)
+
+	// The returned string is the only allocation
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = uid.String()
+	})
+	if allocs > 1 {
+		t.Errorf("String allocated %.0f times, expected at most 1", allocs)
+	}
+
+	// Must match the reference fmt implementation for arbitrary bit patterns
+	for _, u := range []MicroShardUUID{uid, {}, {High: ^uint64(0), Low: ^uint64(0)}, {High: 0x0123456789abcdef, Low: 0xfedcba9876543210}} {
+		want := fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", u.High>>32, (u.High>>16)&0xFFFF, u.High&0xFFFF, u.Low>>48, u.Low&0xFFFFFFFFFFFF)
+		if got := u.String(); got != want {
+			t.Errorf("String mismatch. Expected %s, got %s", want, got)
+		}
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	uid, _ := Generate(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = uid.String()
+	}
+}
+
+// BenchmarkStringSprintf is the previous fmt based implementation, kept as a baseline.
+func BenchmarkStringSprintf(b *testing.B) {
+	u, _ := Generate(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", u.High>>32, (u.High>>16)&0xFFFF, u.High&0xFFFF, u.Low>>48, u.Low&0xFFFFFFFFFFFF)
+	}
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	uid, _ := Generate(1)
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = uid.AppendText(buf[:0])
+	}
+}
+
+func TestParseAllocations(t *testing.T) {
+	uid, _ := Generate(1)
+	str := uid.String()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = Parse(str)
+	})
+	if allocs != 0 {
+		t.Errorf("Parse allocated %.0f times on canonical input, expected 0", allocs)
+	}
+
+	// Uppercase canonical input takes the fast path too
+	if parsed, err := Parse(strings.ToUpper(str)); err != nil || parsed != uid {
+		t.Errorf("Uppercase canonical parse failed: %v", err)
+	}
+
+	// Misplaced dashes still go through the lenient path
+	moved := str[:7] + "-" + str[7:8] + str[9:]
+	if parsed, err := Parse(moved); err != nil || parsed != uid {
+		t.Errorf("Lenient fallback failed: %v", err)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	uid, _ := Generate(1)
+	str := uid.String()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = Parse(str)
+	}
+}
+
+func BenchmarkParseLenient(b *testing.B) {
+	uid, _ := Generate(1)
+	str := uid.Braced()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = Parse(str)
+	}
+}
+
+func TestIsValidAllocs(t *testing.T) {
+	uid, _ := Generate(1)
+	inputs := []string{uid.String(), "urn:uuid:" + uid.String(), "{" + strings.ToUpper(uid.String()) + "}", uid.Hex()}
+
+	for _, s := range inputs {
+		if !IsValid(s) {
+			t.Errorf("IsValid rejected %q", s)
+		}
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = IsValid(s)
+		})
+		if allocs != 0 {
+			t.Errorf("IsValid(%q) allocated %.0f times, expected 0", s, allocs)
+		}
+	}
+}
+
+func BenchmarkIsValid(b *testing.B) {
+	uid, _ := Generate(1)
+	str := uid.String()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsValid(str)
+	}
+}
@@ -0,0 +1,145 @@
+package microsharduuid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// Chaos Injection
+// ==========================================
+
+// ErrLeaseExpired reports that the Generator no longer owns its Shard ID,
+// e.g. because the lease of its Allocator lapsed (see WithAllocator). Chaos
+// mode injects it so callers can rehearse losing a shard assignment.
+var ErrLeaseExpired = errors.New("shard lease expired")
+
+// ErrChaosEntropy is the entropy failure injected by chaos mode.
+var ErrChaosEntropy = errors.New("injected entropy failure (chaos)")
+
+// ChaosConfig controls the faults injected by WithChaos. Probabilities are
+// per call to NewID/NewIDs and range from 0 (never) to 1 (always).
+//
+// Chaos mode is meant for tests and staging builds. Never enable it in production.
+type ChaosConfig struct {
+	Seed int64 // Seed for fault decisions; 0 picks a random seed
+
+	ClockStepProbability float64       // Chance of stepping the clock backwards
+	MaxClockStep         time.Duration // Largest backwards step (1s if zero)
+
+	EntropyFailureProbability float64       // Chance of failing with ErrChaosEntropy
+	EntropyDelayProbability   float64       // Chance of a slow entropy read
+	EntropyDelay              time.Duration // Duration of a slow read (10ms if zero)
+
+	LeaseExpiryProbability float64 // Chance of failing with ErrLeaseExpired
+}
+
+// validate checks that every probability is in [0, 1].
+func (cfg ChaosConfig) validate() error {
+	for _, p := range []float64{cfg.ClockStepProbability, cfg.EntropyFailureProbability, cfg.EntropyDelayProbability, cfg.LeaseExpiryProbability} {
+		if p < 0 || p > 1 {
+			return fmt.Errorf("%w: chaos probability %v outside [0, 1]", ErrInvalidOption, p)
+		}
+	}
+	return nil
+}
+
+// WithChaos injects faults into the Generator according to cfg, so consumers
+// can verify their error handling end to end: backwards clock steps (handled
+// by WithRollbackPolicy or WithGlobalMonotonic), slow or failing entropy
+// reads, and shard lease expiry. Injected failures are returned as a *GenerateError wrapping
+// ErrChaosEntropy or ErrLeaseExpired. Disable it with WithoutChaos.
+func WithChaos(cfg ChaosConfig) Option {
+	return func(c *generatorConfig) {
+		c.chaos = newChaos(cfg)
+	}
+}
+
+// WithoutChaos reverts WithChaos (for Reconfigure).
+func WithoutChaos() Option {
+	return func(c *generatorConfig) {
+		c.chaos = nil
+	}
+}
+
+// chaos makes fault decisions for one WithChaos option.
+type chaos struct {
+	cfg ChaosConfig
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func newChaos(cfg ChaosConfig) *chaos {
+	if cfg.MaxClockStep <= 0 {
+		cfg.MaxClockStep = time.Second
+	} else if cfg.MaxClockStep < time.Microsecond {
+		cfg.MaxClockStep = time.Microsecond
+	}
+	if cfg.EntropyDelay <= 0 {
+		cfg.EntropyDelay = 10 * time.Millisecond
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &chaos{cfg: cfg, rng: rand.New(rand.NewSource(seed))}
+}
+
+// roll reports whether a fault with probability p fires.
+func (c *chaos) roll(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rng.Float64() < p
+}
+
+// inject applies clock and lease faults to a generation request and returns
+// the (possibly stepped) timestamp and an entropy source with read faults.
+func (c *chaos) inject(now uint64, shardID uint32, src io.Reader) (uint64, io.Reader, error) {
+	if c.roll(c.cfg.LeaseExpiryProbability) {
+		return 0, nil, &GenerateError{ShardID: shardID, Micros: now, Reason: "shard lease lost", Err: ErrLeaseExpired}
+	}
+
+	if c.roll(c.cfg.ClockStepProbability) {
+		c.mu.Lock()
+		step := uint64(c.rng.Int63n(c.cfg.MaxClockStep.Microseconds()) + 1)
+		c.mu.Unlock()
+		if step > now {
+			step = now
+		}
+		now -= step
+	}
+
+	return now, c.entropy(src), nil
+}
+
+// entropy wraps src (nil = crypto/rand) with read faults.
+func (c *chaos) entropy(src io.Reader) io.Reader {
+	if src == nil {
+		src = entropySource
+	}
+	return &chaosReader{chaos: c, src: src}
+}
+
+// chaosReader delays or fails reads of the wrapped entropy source.
+type chaosReader struct {
+	chaos *chaos
+	src   io.Reader
+}
+
+func (r *chaosReader) Read(p []byte) (int, error) {
+	if r.chaos.roll(r.chaos.cfg.EntropyDelayProbability) {
+		time.Sleep(r.chaos.cfg.EntropyDelay)
+	}
+	if r.chaos.roll(r.chaos.cfg.EntropyFailureProbability) {
+		return 0, ErrChaosEntropy
+	}
+	return r.src.Read(p)
+}
@@ -0,0 +1,89 @@
+package microsharduuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChaosLeaseExpiry(t *testing.T) {
+	gen, _ := NewGenerator(3, WithChaos(ChaosConfig{Seed: 1, LeaseExpiryProbability: 1}))
+
+	_, err := gen.NewID()
+	var genErr *GenerateError
+	if !errors.Is(err, ErrLeaseExpired) || !errors.As(err, &genErr) || genErr.ShardID != 3 {
+		t.Fatalf("Expected GenerateError wrapping ErrLeaseExpired, got %v", err)
+	}
+	if _, err := gen.NewIDs(4); !errors.Is(err, ErrLeaseExpired) {
+		t.Errorf("NewIDs should inject lease expiry too, got %v", err)
+	}
+}
+
+func TestChaosEntropyFailure(t *testing.T) {
+	gen, _ := NewGenerator(1, WithChaos(ChaosConfig{Seed: 1, EntropyFailureProbability: 1}))
+
+	if _, err := gen.NewID(); !errors.Is(err, ErrChaosEntropy) {
+		t.Fatalf("Expected ErrChaosEntropy, got %v", err)
+	}
+	if _, err := gen.NewIDs(4); !errors.Is(err, ErrChaosEntropy) {
+		t.Errorf("NewIDs should inject entropy failures too, got %v", err)
+	}
+}
+
+func TestChaosEntropyDelay(t *testing.T) {
+	gen, _ := NewGenerator(1, WithChaos(ChaosConfig{
+		Seed:                    1,
+		EntropyDelayProbability: 1,
+		EntropyDelay:            5 * time.Millisecond,
+	}))
+
+	start := time.Now()
+	if _, err := gen.NewID(); err != nil {
+		t.Fatalf("Slow entropy should still succeed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("Expected an injected delay, NewID took %v", elapsed)
+	}
+}
+
+func TestChaosClockStep(t *testing.T) {
+	gen, _ := NewGenerator(1, WithChaos(ChaosConfig{
+		Seed:                 1,
+		ClockStepProbability: 1,
+		MaxClockStep:         time.Hour,
+	}))
+
+	before := time.Now()
+	stepped := false
+	for i := 0; i < 10; i++ {
+		id, err := gen.NewID()
+		if err != nil {
+			t.Fatalf("NewID failed: %v", err)
+		}
+		if id.Time().Before(before.Add(-time.Millisecond)) {
+			stepped = true
+		}
+	}
+	if !stepped {
+		t.Error("Expected IDs with timestamps stepped into the past")
+	}
+
+	// Global monotonic mode absorbs backwards steps
+	gen.Reconfigure(WithGlobalMonotonic())
+	prev, _ := gen.NewID()
+	for i := 0; i < 10; i++ {
+		id, _ := gen.NewID()
+		if !id.After(prev) {
+			t.Fatal("Global monotonic IDs must stay ordered under clock steps")
+		}
+		prev = id
+	}
+}
+
+func TestWithoutChaos(t *testing.T) {
+	gen, _ := NewGenerator(1, WithChaos(ChaosConfig{LeaseExpiryProbability: 1}))
+	gen.Reconfigure(WithoutChaos())
+	if _, err := gen.NewID(); err != nil {
+		t.Errorf("WithoutChaos should disable fault injection: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package microsharduuid
+
+import (
+	"context"
+	"time"
+)
+
+// ==========================================
+// Pluggable Clock
+// ==========================================
+
+// Clock is the time source of a Generator. Implement it to control
+// timestamps in tests, simulations, and replay tooling, or to plug in
+// alternative time sources such as hybrid logical clocks or PTP.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts an ordinary function to the Clock interface.
+type ClockFunc func() time.Time
+
+// Now calls f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the default wall clock (time.Now).
+var SystemClock Clock = ClockFunc(time.Now)
+
+// WithClock makes the Generator read timestamps from c instead of the system
+// clock. A nil c restores SystemClock. Times before the Unix epoch are
+// rejected with a *GenerateError.
+func WithClock(c Clock) Option {
+	return func(cfg *generatorConfig) {
+		cfg.clock = c
+	}
+}
+
+// nowMicros reads c (nil = system clock) as Unix microseconds.
+func nowMicros(c Clock, shardID uint32) (uint64, error) {
+	if c == nil {
+		return uint64(time.Now().UnixMicro()), nil
+	}
+	micros := c.Now().UnixMicro()
+	if micros < 0 {
+		return 0, &GenerateError{ShardID: shardID, Reason: "clock is before the Unix epoch"}
+	}
+	return uint64(micros), nil
+}
+
+// sleepContext sleeps for d or until ctx is done, returning ctx.Err() in
+// the latter case.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if ctx.Done() == nil {
+		// Never canceled (context.Background): skip the timer
+		time.Sleep(d)
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,46 @@
+package microsharduuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithClock(t *testing.T) {
+	fixed := time.Date(2030, 5, 17, 12, 0, 0, 123000, time.UTC)
+	gen, _ := NewGenerator(9, WithClock(ClockFunc(func() time.Time { return fixed })))
+
+	id, err := gen.NewID()
+	if err != nil {
+		t.Fatalf("NewID failed: %v", err)
+	}
+	if !id.Time().Equal(fixed) {
+		t.Errorf("Expected timestamp %v from the injected clock, got %v", fixed, id.Time())
+	}
+
+	ids, _ := gen.NewIDs(3)
+	for _, batched := range ids {
+		if !batched.Time().Equal(fixed) {
+			t.Fatalf("NewIDs ignored the injected clock: %v", batched.Time())
+		}
+	}
+
+	gen.Reconfigure(WithClock(nil))
+	if id, _ := gen.NewID(); time.Since(id.Time()) > time.Minute {
+		t.Errorf("WithClock(nil) should restore the system clock, got %v", id.Time())
+	}
+}
+
+func TestClockBeforeEpoch(t *testing.T) {
+	past := ClockFunc(func() time.Time { return time.Unix(-1, 0) })
+	gen, _ := NewGenerator(1, WithClock(past))
+
+	if _, err := gen.NewID(); err == nil {
+		t.Error("Expected error for a clock before the Unix epoch")
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	if d := time.Since(SystemClock.Now()); d < 0 || d > time.Second {
+		t.Errorf("SystemClock drifted from time.Now by %v", d)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/conformance"
+)
+
+func runConformance(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("conformance", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	serve := fs.Bool("serve", false, "Act as an adapter for the Go implementation on stdin/stdout")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *serve {
+		if err := conformance.Serve(stdin, stdout, conformance.Reference()); err != nil {
+			fmt.Fprintf(stderr, "msuuid conformance: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	if fs.NArg() == 0 {
+		fmt.Fprintln(stderr, "msuuid conformance: missing adapter command")
+		return 2
+	}
+	p, err := conformance.Start(fs.Arg(0), fs.Args()[1:]...)
+	if err != nil {
+		fmt.Fprintf(stderr, "msuuid conformance: %v\n", err)
+		return 2
+	}
+	report := conformance.Run(p)
+	p.Close()
+
+	descriptions := make(map[string]string)
+	for _, c := range conformance.Checks() {
+		descriptions[c.Name] = c.Description
+	}
+	for _, res := range report.Results {
+		if res.Err != nil {
+			fmt.Fprintf(stdout, "FAIL  %-16s %v\n", res.Check, res.Err)
+		} else {
+			fmt.Fprintf(stdout, "ok    %-16s %s\n", res.Check, descriptions[res.Check])
+		}
+	}
+
+	if !report.Passed() {
+		return 1
+	}
+	return 0
+}
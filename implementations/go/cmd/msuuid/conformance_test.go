@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestHelperConformanceAdapter is not a real test: TestRunConformance runs
+// the test binary with it as the adapter, serving the Go implementation.
+func TestHelperConformanceAdapter(t *testing.T) {
+	if os.Getenv("MSUUID_CONFORMANCE_ADAPTER") != "1" {
+		t.Skip("only runs as a subprocess of TestRunConformance")
+	}
+	os.Exit(run([]string{"conformance", "--serve"}, os.Stdin, os.Stdout, os.Stderr))
+}
+
+func TestRunConformance(t *testing.T) {
+	t.Setenv("MSUUID_CONFORMANCE_ADAPTER", "1")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"conformance", os.Args[0], "-test.run=^TestHelperConformanceAdapter$"}, strings.NewReader(""), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("Exit code %d:\n%s%s", code, stdout.String(), stderr.String())
+	}
+	if strings.Contains(stdout.String(), "FAIL") || !strings.Contains(stdout.String(), "ok    layout") {
+		t.Errorf("Unexpected output:\n%s", stdout.String())
+	}
+
+	// An adapter that echoes requests fails every check
+	if _, err := exec.LookPath("cat"); err == nil {
+		stdout.Reset()
+		if code := run([]string{"conformance", "cat"}, strings.NewReader(""), &stdout, &stderr); code != 1 {
+			t.Errorf("A broken adapter should exit with 1, got %d:\n%s", code, stdout.String())
+		}
+	}
+
+	if code := run([]string{"conformance"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("A missing adapter should be a usage error, got exit code %d", code)
+	}
+}
+
+func TestRunConformanceServe(t *testing.T) {
+	in := `{"op":"parse","micros":"0","shard":0,"random":0,"input":"18aa66e8-3909-8000-8000-02a123456789"}` + "\n"
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"conformance", "--serve"}, strings.NewReader(in), &stdout, &stderr); code != 0 {
+		t.Fatalf("Exit code %d (%s)", code, stderr.String())
+	}
+	if got := stdout.String(); got != `{"micros":"1735689600123456","shard":42,"random":4886718345}`+"\n" {
+		t.Errorf("Unexpected response: %s", got)
+	}
+}
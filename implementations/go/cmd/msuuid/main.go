@@ -0,0 +1,65 @@
+// Command msuuid is a command line toolbox for MicroShard UUIDs.
+//
+// Usage:
+//
+//	msuuid verify --file ids.txt [--shards 1,2,10-20] [--since T] [--until T] [--max-failures N]
+//	msuuid remap --key-file key.txt [--file legacy.csv]
+//	msuuid stats [--file ids.txt] [--top N]
+//	msuuid order [--file ids.txt] [--per-shard] [--window N] [--max-violations N]
+//	msuuid vectors [--seed N] [--count N]
+//	msuuid conformance <adapter command> [args...] | --serve
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run dispatches a subcommand and returns the process exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	switch args[0] {
+	case "verify":
+		return runVerify(args[1:], stdin, stdout, stderr)
+	case "remap":
+		return runRemap(args[1:], stdin, stdout, stderr)
+	case "stats":
+		return runStats(args[1:], stdin, stdout, stderr)
+	case "order":
+		return runOrder(args[1:], stdin, stdout, stderr)
+	case "vectors":
+		return runVectors(args[1:], stdin, stdout, stderr)
+	case "conformance":
+		return runConformance(args[1:], stdin, stdout, stderr)
+	case "help", "-h", "--help":
+		usage(stdout)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "msuuid: unknown command %q\n\n", args[0])
+		usage(stderr)
+		return 2
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: msuuid <command> [flags]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Commands:")
+	fmt.Fprintln(w, "  verify       Validate a file of IDs (one per line)")
+	fmt.Fprintln(w, "  remap        Deterministically map legacy UUIDs to MicroShard UUIDs")
+	fmt.Fprintln(w, "  stats        Report shard distribution, rates, and skew of a file of IDs")
+	fmt.Fprintln(w, "  order        Check a file of IDs for time order and duplicates")
+	fmt.Fprintln(w, "  vectors      Print the cross-language golden test vectors as JSON")
+	fmt.Fprintln(w, "  conformance  Certify an implementation through its JSON adapter")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Run 'msuuid <command> -h' for command flags.")
+}
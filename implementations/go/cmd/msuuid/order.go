@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/analysis"
+)
+
+func runOrder(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("order", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "-", "File of IDs, one per line ('-' for stdin)")
+	perShard := fs.Bool("per-shard", false, "Check time order within each shard instead of globally")
+	window := fs.Int("window", analysis.DefaultOrderWindow, "Number of recent IDs remembered for duplicate detection")
+	maxViolations := fs.Int("max-violations", 10, "Number of violations to print")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	in := stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintf(stderr, "msuuid order: %v\n", err)
+			return 2
+		}
+		defer f.Close()
+		in = f
+	}
+
+	v := analysis.NewOrderValidator(analysis.OrderConfig{PerShard: *perShard, Window: *window, MaxViolations: *maxViolations})
+	invalid := 0
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+		id, err := microsharduuid.Parse(input)
+		if err != nil {
+			invalid++
+			continue
+		}
+		v.Observe(id)
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "msuuid order: %v\n", err)
+		return 2
+	}
+
+	r := v.Report()
+	fmt.Fprintf(stdout, "ids:          %d\n", r.Count)
+	fmt.Fprintf(stdout, "invalid:      %d\n", invalid)
+	fmt.Fprintf(stdout, "out of order: %d\n", r.OutOfOrder)
+	fmt.Fprintf(stdout, "duplicates:   %d\n", r.Duplicates)
+	fmt.Fprintf(stdout, "max regress:  %s\n", r.MaxRegression)
+	if len(r.Violations) > 0 {
+		fmt.Fprintf(stdout, "\nfirst %d violations:\n", len(r.Violations))
+		for _, vi := range r.Violations {
+			// Positions count valid IDs only, starting at 1
+			fmt.Fprintf(stdout, "  id #%d: %s: %s", vi.Position+1, vi.ID, vi.Kind)
+			if vi.Regression > 0 {
+				fmt.Fprintf(stdout, " (%s behind)", vi.Regression)
+			}
+			fmt.Fprintln(stdout)
+		}
+	}
+
+	if !r.Sorted() {
+		return 1
+	}
+	return 0
+}
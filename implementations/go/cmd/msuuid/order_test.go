@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestRunOrder(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	a, _ := microsharduuid.FromTime(base, 1)
+	b, _ := microsharduuid.FromTime(base.Add(time.Second), 1)
+	c, _ := microsharduuid.FromTime(base.Add(2*time.Second), 1)
+
+	var stdout, stderr bytes.Buffer
+	sorted := strings.NewReader(strings.Join([]string{a.String(), b.String(), c.String()}, "\n"))
+	if code := run([]string{"order"}, sorted, &stdout, &stderr); code != 0 {
+		t.Errorf("Sorted input: exit code %d (%s)", code, stdout.String())
+	}
+
+	stdout.Reset()
+	broken := strings.NewReader(strings.Join([]string{a.String(), c.String(), b.String(), b.String()}, "\n"))
+	if code := run([]string{"order"}, broken, &stdout, &stderr); code != 1 {
+		t.Errorf("Broken input: expected exit code 1, got %d", code)
+	}
+	out := stdout.String()
+	for _, want := range []string{"out of order: 1\n", "duplicates:   1\n", "max regress:  1s\n", "id #3: " + b.String() + ": out of order (1s behind)", "id #4: " + b.String() + ": duplicate\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output lacks %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func runRemap(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("remap", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "-", "CSV of legacy_uuid,created_at,shard lines ('-' for stdin)")
+	keyFile := fs.String("key-file", "", "File holding the secret HMAC key (required)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *keyFile == "" {
+		fmt.Fprintln(stderr, "msuuid remap: --key-file is required")
+		return 2
+	}
+	key, err := os.ReadFile(*keyFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "msuuid remap: %v\n", err)
+		return 2
+	}
+	key = []byte(strings.TrimSpace(string(key)))
+
+	in := stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintf(stderr, "msuuid remap: %v\n", err)
+			return 2
+		}
+		defer f.Close()
+		in = f
+	}
+
+	if err := remap(in, stdout, key); err != nil {
+		fmt.Fprintf(stderr, "msuuid remap: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// remap reads legacy_uuid,created_at,shard lines from r and writes
+// legacy_uuid,new_id lines to w. It stops at the first invalid line, so a
+// backfill never writes a partial mapping silently.
+func remap(r io.Reader, w io.Writer, key []byte) error {
+	out := bufio.NewWriter(w)
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		id, err := remapLine(line, key)
+		if err != nil {
+			out.Flush()
+			return fmt.Errorf("line %d: %v", lineNo, err)
+		}
+		fmt.Fprintf(out, "%s,%s\n", strings.TrimSpace(strings.SplitN(line, ",", 2)[0]), id)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+func remapLine(line string, key []byte) (microsharduuid.MicroShardUUID, error) {
+	fields := strings.Split(line, ",")
+	if len(fields) != 3 {
+		return microsharduuid.Nil, errors.New("expected legacy_uuid,created_at,shard")
+	}
+
+	legacy, err := parseLegacyUUID(strings.TrimSpace(fields[0]))
+	if err != nil {
+		return microsharduuid.Nil, err
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(fields[1]))
+	if err != nil {
+		return microsharduuid.Nil, errors.New("created_at: expected RFC 3339 time, e.g. 2025-01-01T00:00:00Z")
+	}
+	shard, err := strconv.ParseUint(strings.TrimSpace(fields[2]), 10, 32)
+	if err != nil {
+		return microsharduuid.Nil, fmt.Errorf("invalid shard %q", fields[2])
+	}
+	return microsharduuid.RemapLegacy(key, legacy, createdAt, uint32(shard))
+}
+
+// parseLegacyUUID decodes any UUID in the 8-4-4-4-12 or 32-hex form,
+// regardless of version.
+func parseLegacyUUID(s string) ([16]byte, error) {
+	var b [16]byte
+	raw := strings.ReplaceAll(s, "-", "")
+	if len(raw) != 32 {
+		return b, fmt.Errorf("invalid legacy UUID %q", s)
+	}
+	if _, err := hex.Decode(b[:], []byte(raw)); err != nil {
+		return b, fmt.Errorf("invalid legacy UUID %q", s)
+	}
+	return b, nil
+}
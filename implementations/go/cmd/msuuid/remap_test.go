@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestRemap(t *testing.T) {
+	key := []byte("secret")
+	input := "550e8400-e29b-41d4-a716-446655440000,2021-03-04T05:06:07.891011Z,42\n\n" +
+		"6ba7b8109dad11d180b400c04fd430c8, 2022-01-01T00:00:00Z, 7\n"
+
+	var out bytes.Buffer
+	if err := remap(strings.NewReader(input), &out, key); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 output lines, got %q", out.String())
+	}
+
+	fields := strings.Split(lines[0], ",")
+	id, err := microsharduuid.Parse(fields[1])
+	if err != nil || fields[0] != "550e8400-e29b-41d4-a716-446655440000" {
+		t.Fatalf("Unexpected line %q (%v)", lines[0], err)
+	}
+	legacy, _ := parseLegacyUUID(fields[0])
+	want, _ := microsharduuid.RemapLegacy(key, legacy, time.Date(2021, 3, 4, 5, 6, 7, 891011000, time.UTC), 42)
+	if id != want {
+		t.Errorf("Remapped to %s, expected %s", id, want)
+	}
+
+	// Same input, same output
+	var again bytes.Buffer
+	remap(strings.NewReader(input), &again, key)
+	if again.String() != out.String() {
+		t.Error("remap is not deterministic")
+	}
+}
+
+func TestRemapStopsAtInvalidLine(t *testing.T) {
+	input := "550e8400-e29b-41d4-a716-446655440000,2021-03-04T05:06:07Z,1\nnot-a-uuid,2021-03-04T05:06:07Z,1\n"
+	var out bytes.Buffer
+	err := remap(strings.NewReader(input), &out, []byte("k"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected a line 2 error, got %v", err)
+	}
+	if strings.Count(out.String(), "\n") != 1 {
+		t.Errorf("Expected the first line to be written, got %q", out.String())
+	}
+}
+
+func TestRunRemapKeyFile(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"remap"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("Missing --key-file: expected exit code 2, got %d", code)
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	os.WriteFile(keyFile, []byte("secret\n"), 0o600)
+	input := strings.NewReader("550e8400-e29b-41d4-a716-446655440000,2021-03-04T05:06:07Z,1\n")
+	if code := run([]string{"remap", "--key-file", keyFile}, input, &stdout, &stderr); code != 0 {
+		t.Errorf("Expected exit code 0, got %d (%s)", code, stderr.String())
+	}
+}
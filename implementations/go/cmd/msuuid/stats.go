@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/analysis"
+)
+
+func runStats(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "-", "File of IDs, one per line ('-' for stdin)")
+	top := fs.Int("top", 10, "Number of busiest shards to print")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	in := stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintf(stderr, "msuuid stats: %v\n", err)
+			return 2
+		}
+		defer f.Close()
+		in = f
+	}
+
+	stats := analysis.NewStats()
+	invalid := 0
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+		id, err := microsharduuid.Parse(input)
+		if err != nil {
+			invalid++
+			continue
+		}
+		stats.Observe(id)
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "msuuid stats: %v\n", err)
+		return 2
+	}
+
+	printStats(stdout, stats.Report(), invalid, *top)
+	return 0
+}
+
+func printStats(w io.Writer, r analysis.Report, invalid, top int) {
+	fmt.Fprintf(w, "ids:     %d\n", r.Count)
+	fmt.Fprintf(w, "invalid: %d\n", invalid)
+	if r.Count == 0 {
+		return
+	}
+	fmt.Fprintf(w, "first:   %s\n", r.First.Format(time.RFC3339Nano))
+	fmt.Fprintf(w, "last:    %s\n", r.Last.Format(time.RFC3339Nano))
+	fmt.Fprintf(w, "span:    %s\n", r.Span)
+	fmt.Fprintf(w, "rate:    %.2f/s\n", r.Rate)
+	fmt.Fprintf(w, "shards:  %d\n", len(r.Shards))
+	fmt.Fprintf(w, "skew:    %.2f (busiest shard / mean)\n", r.Skew)
+
+	if top > len(r.Shards) {
+		top = len(r.Shards)
+	}
+	if top > 0 {
+		fmt.Fprintf(w, "\nbusiest %d shards:\n", top)
+		for _, s := range r.Shards[:top] {
+			fmt.Fprintf(w, "  shard %-10d %8d ids  %6.2f%%  %.2f/s\n", s.ShardID, s.Count, s.Share*100, s.Rate)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestRunStats(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var lines []string
+	for i := 0; i < 30; i++ {
+		shard := uint32(5)
+		if i%3 == 0 {
+			shard = 9
+		}
+		id, _ := microsharduuid.FromTime(base.Add(time.Duration(i)*time.Second), shard)
+		lines = append(lines, id.String())
+	}
+	lines = append(lines, "", "not-an-id")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"stats", "--top", "1"}, strings.NewReader(strings.Join(lines, "\n")), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("Exit code %d (%s)", code, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"ids:     30\n", "invalid: 1\n", "span:    29s\n", "shards:  2\n", "skew:    1.33", "busiest 1 shards:", "shard 5 "} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "shard 9 ") {
+		t.Errorf("--top 1 printed more than one shard:\n%s", out)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/vectors"
+)
+
+func runVectors(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("vectors", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	seed := fs.Int64("seed", vectors.DefaultSeed, "Seed of the random cases")
+	count := fs.Int("count", vectors.DefaultCount, "Number of random cases after the boundary cases")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *count < 0 {
+		fmt.Fprintln(stderr, "msuuid vectors: --count must not be negative")
+		return 2
+	}
+
+	f, err := vectors.Generate(*seed, *count)
+	if err != nil {
+		fmt.Fprintf(stderr, "msuuid vectors: %v\n", err)
+		return 1
+	}
+	if err := vectors.Write(stdout, f); err != nil {
+		fmt.Fprintf(stderr, "msuuid vectors: %v\n", err)
+		return 1
+	}
+	return 0
+}
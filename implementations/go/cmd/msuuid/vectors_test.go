@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/vectors"
+)
+
+func TestRunVectors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"vectors"}, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Fatalf("Exit code %d (%s)", code, stderr.String())
+	}
+	if !bytes.Equal(stdout.Bytes(), vectors.Golden()) {
+		t.Error("Default output should match the embedded vectors.json")
+	}
+
+	stdout.Reset()
+	if code := run([]string{"vectors", "--count", "0"}, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Fatalf("Exit code %d (%s)", code, stderr.String())
+	}
+	if strings.Contains(stdout.String(), "random-0") {
+		t.Error("--count 0 should only print the boundary cases")
+	}
+
+	if code := run([]string{"vectors", "--count", "-1"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("Negative counts should be usage errors, got exit code %d", code)
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// verifyConfig holds the validation rules for the verify command.
+type verifyConfig struct {
+	shards      []shardRange // Allowed shards (empty = any)
+	since       time.Time    // Inclusive lower bound (zero = none)
+	until       time.Time    // Exclusive upper bound (zero = none)
+	maxFailures int          // Number of failures to print
+}
+
+// shardRange is an inclusive range of Shard IDs.
+type shardRange struct {
+	lo, hi uint32
+}
+
+// verifyFailure records a rejected line.
+type verifyFailure struct {
+	line   int
+	input  string
+	reason string
+}
+
+// verifyReport summarizes a verify run.
+type verifyReport struct {
+	total    int
+	valid    int
+	reasons  map[string]int
+	failures []verifyFailure // First maxFailures failures
+}
+
+func runVerify(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "-", "File of IDs, one per line ('-' for stdin)")
+	shards := fs.String("shards", "", "Allowed shard IDs, e.g. 1,2,10-20 (default: any)")
+	since := fs.String("since", "", "Reject IDs created before this RFC 3339 time")
+	until := fs.String("until", "", "Reject IDs created at or after this RFC 3339 time")
+	maxFailures := fs.Int("max-failures", 10, "Number of failures to print")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg := verifyConfig{maxFailures: *maxFailures}
+	var err error
+	if cfg.shards, err = parseShardRanges(*shards); err != nil {
+		fmt.Fprintf(stderr, "msuuid verify: %v\n", err)
+		return 2
+	}
+	if cfg.since, err = parseOptionalTime(*since); err != nil {
+		fmt.Fprintf(stderr, "msuuid verify: --since: %v\n", err)
+		return 2
+	}
+	if cfg.until, err = parseOptionalTime(*until); err != nil {
+		fmt.Fprintf(stderr, "msuuid verify: --until: %v\n", err)
+		return 2
+	}
+
+	in := stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintf(stderr, "msuuid verify: %v\n", err)
+			return 2
+		}
+		defer f.Close()
+		in = f
+	}
+
+	report, err := verify(in, cfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "msuuid verify: %v\n", err)
+		return 2
+	}
+	report.print(stdout)
+
+	if report.valid != report.total {
+		return 1
+	}
+	return 0
+}
+
+// verify validates every non-empty line of r against cfg.
+func verify(r io.Reader, cfg verifyConfig) (*verifyReport, error) {
+	report := &verifyReport{reasons: make(map[string]int)}
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+		report.total++
+
+		reason := cfg.check(input)
+		if reason == "" {
+			report.valid++
+			continue
+		}
+
+		report.reasons[reason]++
+		if len(report.failures) < cfg.maxFailures {
+			report.failures = append(report.failures, verifyFailure{line: lineNo, input: input, reason: reason})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// check returns the rejection reason for input, or "" if it is valid.
+func (cfg verifyConfig) check(input string) string {
+	uid, err := microsharduuid.Parse(input)
+	if err != nil {
+		return err.Error()
+	}
+
+	if len(cfg.shards) > 0 && !shardAllowed(cfg.shards, uid.ShardID()) {
+		return "shard not allowed"
+	}
+
+	ts := uid.Time()
+	if !cfg.since.IsZero() && ts.Before(cfg.since) {
+		return "time before --since"
+	}
+	if !cfg.until.IsZero() && !ts.Before(cfg.until) {
+		return "time not before --until"
+	}
+	return ""
+}
+
+func (r *verifyReport) print(w io.Writer) {
+	fmt.Fprintf(w, "total:   %d\n", r.total)
+	fmt.Fprintf(w, "valid:   %d\n", r.valid)
+	fmt.Fprintf(w, "invalid: %d\n", r.total-r.valid)
+
+	if len(r.reasons) > 0 {
+		reasons := make([]string, 0, len(r.reasons))
+		for reason := range r.reasons {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+
+		fmt.Fprintln(w, "\nfailures by reason:")
+		for _, reason := range reasons {
+			fmt.Fprintf(w, "  %6d  %s\n", r.reasons[reason], reason)
+		}
+	}
+
+	if len(r.failures) > 0 {
+		fmt.Fprintf(w, "\nfirst %d failures:\n", len(r.failures))
+		for _, f := range r.failures {
+			fmt.Fprintf(w, "  line %d: %q: %s\n", f.line, f.input, f.reason)
+		}
+	}
+}
+
+// parseShardRanges parses a comma separated list of shards and ranges ("1,2,10-20").
+func parseShardRanges(s string) ([]shardRange, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var ranges []shardRange
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		bounds := strings.SplitN(part, "-", 2)
+
+		lo, err := strconv.ParseUint(bounds[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shard %q", part)
+		}
+		hi := lo
+		if len(bounds) == 2 {
+			if hi, err = strconv.ParseUint(bounds[1], 10, 32); err != nil || hi < lo {
+				return nil, fmt.Errorf("invalid shard range %q", part)
+			}
+		}
+		ranges = append(ranges, shardRange{lo: uint32(lo), hi: uint32(hi)})
+	}
+	return ranges, nil
+}
+
+func shardAllowed(ranges []shardRange, shard uint32) bool {
+	for _, r := range ranges {
+		if shard >= r.lo && shard <= r.hi {
+			return true
+		}
+	}
+	return false
+}
+
+func parseOptionalTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	ts, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, errors.New("expected RFC 3339 time, e.g. 2025-01-01T00:00:00Z")
+	}
+	return ts, nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestVerifyCounts(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ok1, _ := microsharduuid.FromTime(ts, 1)
+	ok2, _ := microsharduuid.FromTime(ts, 15)
+	badShard, _ := microsharduuid.FromTime(ts, 99)
+	tooOld, _ := microsharduuid.FromTime(ts.Add(-48*time.Hour), 1)
+
+	input := strings.Join([]string{
+		ok1.String(),
+		"",
+		ok2.String(),
+		badShard.String(),
+		tooOld.String(),
+		"550e8400-e29b-41d4-a716-446655440000",
+		"garbage",
+	}, "\n")
+
+	shards, _ := parseShardRanges("1,10-20")
+	cfg := verifyConfig{shards: shards, since: ts.Add(-time.Hour), maxFailures: 2}
+
+	report, err := verify(strings.NewReader(input), cfg)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+
+	if report.total != 6 || report.valid != 2 {
+		t.Errorf("Unexpected counts. total=%d valid=%d", report.total, report.valid)
+	}
+	if report.reasons["shard not allowed"] != 1 || report.reasons["time before --since"] != 1 {
+		t.Errorf("Unexpected reasons: %v", report.reasons)
+	}
+	if len(report.failures) != 2 || report.failures[0].line != 4 {
+		t.Errorf("Expected first 2 failures starting at line 4, got %+v", report.failures)
+	}
+}
+
+func TestRunVerifyExitCode(t *testing.T) {
+	uid, _ := microsharduuid.Generate(1)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"verify"}, strings.NewReader(uid.String()+"\n"), &stdout, &stderr)
+	if code != 0 {
+		t.Errorf("Expected exit code 0 for valid input, got %d (%s)", code, stderr.String())
+	}
+
+	code = run([]string{"verify"}, strings.NewReader("nope\n"), &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("Expected exit code 1 for invalid input, got %d", code)
+	}
+
+	code = run([]string{"verify", "--shards", "5-1"}, strings.NewReader(""), &stdout, &stderr)
+	if code != 2 {
+		t.Errorf("Expected exit code 2 for bad flags, got %d", code)
+	}
+}
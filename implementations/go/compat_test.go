@@ -0,0 +1,87 @@
+package microsharduuid
+
+import (
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newerStdPackages were added to the standard library after Go 1.17, the
+// version in go.mod. Files that import them must carry a //go:build goX.Y
+// constraint and ship a !goX.Y fallback.
+var newerStdPackages = map[string]string{
+	"cmp":          "go1.21",
+	"crypto/ecdh":  "go1.20",
+	"iter":         "go1.23",
+	"log/slog":     "go1.21",
+	"maps":         "go1.21",
+	"math/rand/v2": "go1.22",
+	"slices":       "go1.21",
+	"unique":       "go1.23",
+	"weak":         "go1.24",
+}
+
+// TestBackportGuard keeps the core module compiling on old toolchains by
+// checking that newer standard packages are only imported behind build tags.
+func TestBackportGuard(t *testing.T) {
+	fset := token.NewFileSet()
+	err := filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// contrib/* are separate modules with their own Go version
+			if path == "contrib" || path == "testdata" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly|parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		var expr constraint.Expr
+		for _, group := range f.Comments {
+			for _, c := range group.List {
+				if constraint.IsGoBuild(c.Text) {
+					expr, _ = constraint.Parse(c.Text)
+				}
+			}
+		}
+
+		for _, imp := range f.Imports {
+			pkg, _ := strconv.Unquote(imp.Path.Value)
+			release, newer := newerStdPackages[pkg]
+			if !newer {
+				continue
+			}
+			// Evaluate as if compiled by the release before the one that added pkg
+			if expr == nil || expr.Eval(func(tag string) bool { return !isGoReleaseTag(tag) || goMinor(tag) < goMinor(release) }) {
+				t.Errorf("%s imports %s without a //go:build %s constraint", path, pkg, release)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func isGoReleaseTag(tag string) bool {
+	return strings.HasPrefix(tag, "go1.")
+}
+
+// goMinor returns 21 for "go1.21".
+func goMinor(tag string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(tag, "go1."))
+	return n
+}
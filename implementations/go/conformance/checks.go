@@ -0,0 +1,300 @@
+package conformance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/vectors"
+)
+
+// GenerateTolerance is how far the time of a generated ID may be from the
+// runner's clock.
+const GenerateTolerance = time.Minute
+
+// Check is one invariant of the format.
+type Check struct {
+	Name        string
+	Description string
+	Run         func(impl Implementation) error
+}
+
+// Result is the outcome of one Check.
+type Result struct {
+	Check string
+	Err   error // Nil if the check passed
+}
+
+// Report is the outcome of a conformance run.
+type Report struct {
+	Results []Result
+}
+
+// Passed reports whether every check passed.
+func (r Report) Passed() bool {
+	for _, res := range r.Results {
+		if res.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Checks returns the full suite, in the order Run executes it.
+func Checks() []Check {
+	return []Check{
+		{"layout", "every input bit lands at its specified position", checkLayout},
+		{"vectors", "golden vectors build to the expected string and bytes", checkVectors},
+		{"parse", "golden vector strings parse back to their inputs", checkParse},
+		{"sort-order", "string and byte order follow time order", checkSortOrder},
+		{"version-variant", "IDs with another version or variant are rejected", checkVersionVariant},
+		{"overflow", "time, shard, and random values beyond their widths are rejected", checkOverflow},
+		{"generate", "generated IDs carry the shard, version, variant, and current time", checkGenerate},
+	}
+}
+
+// Run executes every check against impl. A check stops at its first failure.
+func Run(impl Implementation) Report {
+	var r Report
+	for _, c := range Checks() {
+		r.Results = append(r.Results, Result{Check: c.Name, Err: c.Run(impl)})
+	}
+	return r
+}
+
+// build asks impl to build an ID and decodes its string and bytes, which
+// must agree.
+func build(impl Implementation, micros uint64, shard uint32, random uint64) (high, low uint64, s string, err error) {
+	resp, err := impl.Do(Request{Op: OpBuild, Micros: micros, Shard: uint64(shard), Random: random})
+	if err != nil {
+		return 0, 0, "", err
+	}
+	if resp.Error != "" {
+		return 0, 0, "", fmt.Errorf("build %d/%d/%d rejected: %s", micros, shard, random, resp.Error)
+	}
+	id, err := microsharduuid.ParseStrict(resp.String)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("build %d/%d/%d returned non-canonical string %q: %v", micros, shard, random, resp.String, err)
+	}
+	if resp.Bytes != id.Hex() {
+		return 0, 0, "", fmt.Errorf("build %d/%d/%d returned bytes %s for string %s", micros, shard, random, resp.Bytes, resp.String)
+	}
+	return id.High, id.Low, resp.String, nil
+}
+
+// layoutBit is the expected position of one input bit, counted from the
+// least significant bit of High (64+) or Low (0-63).
+type layoutBit struct {
+	field string
+	bit   uint
+	pos   uint
+}
+
+// specBits lists the position of every input bit from the README layout:
+// High = [time 48][version 4][time 6][shard 6], Low = [variant 2][shard 26][random 36].
+func specBits() []layoutBit {
+	var bits []layoutBit
+	for i := uint(0); i < 54; i++ {
+		pos := 64 + 6 + i // Time low 6 bits sit above the shard high bits
+		if i >= 6 {
+			pos = 64 + 16 + (i - 6) // Time high 48 bits sit above the version
+		}
+		bits = append(bits, layoutBit{"time", i, pos})
+	}
+	for i := uint(0); i < 32; i++ {
+		pos := 36 + i
+		if i >= 26 {
+			pos = 64 + (i - 26)
+		}
+		bits = append(bits, layoutBit{"shard", i, pos})
+	}
+	for i := uint(0); i < 36; i++ {
+		bits = append(bits, layoutBit{"random", i, i})
+	}
+	return bits
+}
+
+func checkLayout(impl Implementation) error {
+	// Version 8 and variant 2 are the only bits of an all-zero ID
+	const fixedHigh, fixedLow = 8 << 12, 2 << 62
+	for _, b := range specBits() {
+		var micros, random uint64
+		var shard uint32
+		switch b.field {
+		case "time":
+			micros = 1 << b.bit
+		case "shard":
+			shard = 1 << b.bit
+		default:
+			random = 1 << b.bit
+		}
+		high, low, s, err := build(impl, micros, shard, random)
+		if err != nil {
+			return err
+		}
+		wantHigh, wantLow := uint64(fixedHigh), uint64(fixedLow)
+		if b.pos >= 64 {
+			wantHigh |= 1 << (b.pos - 64)
+		} else {
+			wantLow |= 1 << b.pos
+		}
+		if high != wantHigh || low != wantLow {
+			return fmt.Errorf("%s bit %d: got %s, expected bit %d of the 128-bit value set", b.field, b.bit, s, b.pos)
+		}
+	}
+	return nil
+}
+
+func loadVectors() ([]vectors.Vector, error) {
+	f, err := vectors.Load()
+	if err != nil {
+		return nil, err
+	}
+	return f.Vectors, nil
+}
+
+func checkVectors(impl Implementation) error {
+	vs, err := loadVectors()
+	if err != nil {
+		return err
+	}
+	for _, v := range vs {
+		resp, err := impl.Do(Request{Op: OpBuild, Micros: v.Micros, Shard: uint64(v.Shard), Random: v.Random})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("vector %s rejected: %s", v.Name, resp.Error)
+		}
+		if resp.String != v.String || resp.Bytes != v.Bytes {
+			return fmt.Errorf("vector %s: got %s / %s, expected %s / %s", v.Name, resp.String, resp.Bytes, v.String, v.Bytes)
+		}
+	}
+	return nil
+}
+
+func checkParse(impl Implementation) error {
+	vs, err := loadVectors()
+	if err != nil {
+		return err
+	}
+	for _, v := range vs {
+		// Parsers must also accept uppercase hex
+		for _, input := range []string{v.String, strings.ToUpper(v.String)} {
+			resp, err := impl.Do(Request{Op: OpParse, Input: input})
+			if err != nil {
+				return err
+			}
+			if resp.Error != "" {
+				return fmt.Errorf("vector %s: parsing %s rejected: %s", v.Name, input, resp.Error)
+			}
+			if resp.Micros != v.Micros || resp.Shard != v.Shard || resp.Random != v.Random {
+				return fmt.Errorf("vector %s: %s parsed to %d/%d/%d, expected %d/%d/%d",
+					v.Name, input, resp.Micros, resp.Shard, resp.Random, v.Micros, v.Shard, v.Random)
+			}
+		}
+	}
+	return nil
+}
+
+func checkSortOrder(impl Implementation) error {
+	type pair struct {
+		earlier, later uint64
+	}
+	// One microsecond apart across every carry, including the ones between
+	// the split time fields, with the later ID on a lower shard and smaller
+	// random value
+	var pairs []pair
+	for bit := uint(0); bit < 54; bit++ {
+		pairs = append(pairs, pair{1<<bit - 1, 1 << bit})
+	}
+	pairs = append(pairs, pair{microsharduuid.MaxTime - 1, microsharduuid.MaxTime})
+
+	for _, p := range pairs {
+		// build checked that the bytes match the strings, and canonical
+		// strings are lowercase hex, so string order is byte order
+		_, _, first, err := build(impl, p.earlier, microsharduuid.MaxShardID, microsharduuid.MaxRandom)
+		if err != nil {
+			return err
+		}
+		_, _, second, err := build(impl, p.later, 0, 0)
+		if err != nil {
+			return err
+		}
+		if first >= second {
+			return fmt.Errorf("%s (time %d) does not sort before %s (time %d)", first, p.earlier, second, p.later)
+		}
+	}
+	return nil
+}
+
+func checkVersionVariant(impl Implementation) error {
+	const valid = "18aa66e8-3909-8000-8000-02a123456789" // Vector "typical"
+
+	var invalid []string
+	for _, d := range "012345679abcdef" { // Every version but 8
+		invalid = append(invalid, valid[:14]+string(d)+valid[15:])
+	}
+	for _, d := range "01234567cdef" { // Variants 0 (NCS), 1 (RFC 4122), and 3 (reserved)
+		invalid = append(invalid, valid[:19]+string(d)+valid[20:])
+	}
+	for _, input := range invalid {
+		resp, err := impl.Do(Request{Op: OpParse, Input: input})
+		if err != nil {
+			return err
+		}
+		if resp.Error == "" {
+			return fmt.Errorf("%s was accepted", input)
+		}
+	}
+	return nil
+}
+
+func checkOverflow(impl Implementation) error {
+	cases := []Request{
+		{Op: OpBuild, Micros: microsharduuid.MaxTime + 1},
+		{Op: OpBuild, Shard: uint64(microsharduuid.MaxShardID) + 1},
+		{Op: OpBuild, Random: microsharduuid.MaxRandom + 1},
+		{Op: OpGenerate, Shard: uint64(microsharduuid.MaxShardID) + 1},
+	}
+	for _, req := range cases {
+		resp, err := impl.Do(req)
+		if err != nil {
+			return err
+		}
+		if resp.Error == "" {
+			return fmt.Errorf("%s of time %d, shard %d, random %d was accepted as %s", req.Op, req.Micros, req.Shard, req.Random, resp.String)
+		}
+	}
+
+	// The limits themselves must still be accepted
+	_, _, _, err := build(impl, microsharduuid.MaxTime, microsharduuid.MaxShardID, microsharduuid.MaxRandom)
+	return err
+}
+
+func checkGenerate(impl Implementation) error {
+	for _, shard := range []uint32{0, 42, 1 << 26, microsharduuid.MaxShardID} {
+		resp, err := impl.Do(Request{Op: OpGenerate, Shard: uint64(shard)})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("generate for shard %d rejected: %s", shard, resp.Error)
+		}
+		id, err := microsharduuid.ParseStrict(resp.String)
+		if err != nil {
+			return fmt.Errorf("generate for shard %d returned %q: %v", shard, resp.String, err)
+		}
+		if id.VariantField() != uint8(microsharduuid.Variant) {
+			return fmt.Errorf("generate for shard %d returned variant %d", shard, id.VariantField())
+		}
+		if id.ShardID() != shard {
+			return fmt.Errorf("generate for shard %d returned shard %d", shard, id.ShardID())
+		}
+		if skew := time.Since(id.Time()); skew > GenerateTolerance || skew < -GenerateTolerance {
+			return fmt.Errorf("generate returned time %s, %v from the runner's clock", id.ISOTime(), skew)
+		}
+	}
+	return nil
+}
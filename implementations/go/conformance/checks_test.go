@@ -0,0 +1,117 @@
+package conformance
+
+import (
+	"strings"
+	"testing"
+)
+
+// broken wraps the reference implementation with a single defect.
+type broken func(req Request, resp Response) Response
+
+func (b broken) Do(req Request) (Response, error) {
+	resp, err := Reference().Do(req)
+	return b(req, resp), err
+}
+
+func failedChecks(r Report) []string {
+	var failed []string
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed = append(failed, res.Check)
+		}
+	}
+	return failed
+}
+
+func TestRunReference(t *testing.T) {
+	report := Run(Reference())
+	if !report.Passed() {
+		for _, res := range report.Results {
+			t.Errorf("Check %s failed: %v", res.Check, res.Err)
+		}
+	}
+	if len(report.Results) != len(Checks()) {
+		t.Errorf("Expected one result per check, got %d", len(report.Results))
+	}
+}
+
+func TestRunDetectsDefects(t *testing.T) {
+	cases := map[string]struct {
+		impl broken
+		want string
+	}{
+		"ignores shard high bits": {
+			impl: func(req Request, resp Response) Response {
+				if req.Op == OpBuild && req.Shard >= 1<<26 && resp.Error == "" {
+					return refDo(Request{Op: OpBuild, Micros: req.Micros, Shard: req.Shard & (1<<26 - 1), Random: req.Random})
+				}
+				return resp
+			},
+			want: "layout",
+		},
+		"uppercase output": {
+			impl: func(req Request, resp Response) Response {
+				resp.String = strings.ToUpper(resp.String)
+				return resp
+			},
+			want: "layout",
+		},
+		"accepts any version": {
+			impl: func(req Request, resp Response) Response {
+				if req.Op == OpParse && len(req.Input) == 36 && req.Input[14] != '8' {
+					return Response{}
+				}
+				return resp
+			},
+			want: "version-variant",
+		},
+		"accepts the reserved variant": {
+			impl: func(req Request, resp Response) Response {
+				if req.Op == OpParse && len(req.Input) == 36 && strings.ContainsRune("cdef", rune(req.Input[19])) {
+					return Response{}
+				}
+				return resp
+			},
+			want: "version-variant",
+		},
+		"no time overflow check": {
+			impl: func(req Request, resp Response) Response {
+				if req.Op == OpBuild && req.Micros > 1<<54-1 {
+					return Response{String: "ffffffff-ffff-8fc0-8000-000000000000"}
+				}
+				return resp
+			},
+			want: "overflow",
+		},
+		"generates for the wrong shard": {
+			impl: func(req Request, resp Response) Response {
+				if req.Op == OpGenerate && req.Shard == 42 {
+					return refDo(Request{Op: OpGenerate, Shard: 43})
+				}
+				return resp
+			},
+			want: "generate",
+		},
+	}
+
+	for name, tc := range cases {
+		failed := failedChecks(Run(tc.impl))
+		if len(failed) == 0 || !contains(failed, tc.want) {
+			t.Errorf("%s: expected check %s to fail, failed: %v", name, tc.want, failed)
+		}
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func refDo(req Request) Response {
+	resp, _ := Reference().Do(req)
+	return resp
+}
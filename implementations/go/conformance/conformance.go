@@ -0,0 +1,195 @@
+// Package conformance certifies MicroShard UUID implementations in any
+// language against the format's invariants: the bit layout, the golden
+// vectors, sort order, the version and variant rules, and the overflow
+// limits.
+//
+// An implementation under test is driven through a small adapter program
+// that speaks JSON over stdin and stdout: the runner writes one Request per
+// line and the adapter answers each with one Response line. Three
+// operations are defined:
+//
+//	{"op":"build","micros":"1735689600123456","shard":42,"random":4886718345,"input":""}
+//	→ {"string":"18aa66e8-3909-8000-8000-02a123456789","bytes":"18aa66e839098000800002a123456789"}
+//
+//	{"op":"parse","micros":"0","shard":0,"random":0,"input":"18aa66e8-3909-8000-8000-02a123456789"}
+//	→ {"micros":"1735689600123456","shard":42,"random":4886718345}
+//
+//	{"op":"generate","micros":"0","shard":42,"random":0,"input":""}
+//	→ {"string":"..."}  (an ID for the current time)
+//
+// Requests always carry every field; fields an operation does not use are
+// zero. Any operation the implementation rejects (an overflowing input, a
+// wrong version) is answered with {"error":"<message>"}. micros is a JSON string
+// because it can exceed 2^53. "build" takes the random bits from the
+// request, so adapters must bypass the implementation's entropy source.
+//
+// Run the suite with the msuuid command:
+//
+//	msuuid conformance python3 adapter.py
+//
+// or in code with Start and Run.
+package conformance
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Operations of the protocol.
+const (
+	OpBuild    = "build"
+	OpParse    = "parse"
+	OpGenerate = "generate"
+)
+
+// Request is one line sent to the implementation.
+type Request struct {
+	Op     string `json:"op"`
+	Micros uint64 `json:"micros,string"` // build
+	Shard  uint64 `json:"shard"`         // build, generate; wider than 32 bits to test overflow
+	Random uint64 `json:"random"`        // build
+	Input  string `json:"input"`         // parse
+}
+
+// Response is one line answered by the implementation.
+type Response struct {
+	String string `json:"string,omitempty"` // build, generate: canonical form
+	Bytes  string `json:"bytes,omitempty"`  // build: 16 bytes, lowercase hex
+
+	Micros uint64 `json:"micros,string,omitempty"` // parse
+	Shard  uint32 `json:"shard,omitempty"`         // parse
+	Random uint64 `json:"random,omitempty"`        // parse
+
+	Error string `json:"error,omitempty"` // Set when the implementation rejects the request
+}
+
+// Implementation executes protocol requests. Do returns an error only if
+// the implementation could not be reached; rejections are reported in
+// Response.Error.
+type Implementation interface {
+	Do(req Request) (Response, error)
+}
+
+// Reference returns this package's view of the Go implementation, the
+// reference every other implementation is compared with.
+func Reference() Implementation {
+	return reference{}
+}
+
+type reference struct{}
+
+func (reference) Do(req Request) (Response, error) {
+	switch req.Op {
+	case OpBuild:
+		if req.Shard > uint64(microsharduuid.MaxShardID) {
+			return Response{Error: microsharduuid.ErrShardOverflow.Error()}, nil
+		}
+		id, err := microsharduuid.FromParts(req.Micros, uint32(req.Shard), req.Random)
+		if err != nil {
+			return Response{Error: err.Error()}, nil
+		}
+		return Response{String: id.String(), Bytes: id.Hex()}, nil
+
+	case OpParse:
+		id, err := microsharduuid.Parse(req.Input)
+		if err != nil {
+			return Response{Error: err.Error()}, nil
+		}
+		return Response{Micros: uint64(id.UnixMicro()), Shard: id.ShardID(), Random: id.Random()}, nil
+
+	case OpGenerate:
+		if req.Shard > uint64(microsharduuid.MaxShardID) {
+			return Response{Error: microsharduuid.ErrShardOverflow.Error()}, nil
+		}
+		id, err := microsharduuid.Generate(uint32(req.Shard))
+		if err != nil {
+			return Response{Error: err.Error()}, nil
+		}
+		return Response{String: id.String()}, nil
+
+	default:
+		return Response{Error: fmt.Sprintf("unknown op %q", req.Op)}, nil
+	}
+}
+
+// Serve answers the requests read from r with impl, writing the responses
+// to w, until r is exhausted. It lets a Go program act as an adapter, e.g.
+// to check a wrapper around this package.
+func Serve(r io.Reader, w io.Writer, impl Implementation) error {
+	dec := json.NewDecoder(r)
+	enc := json.NewEncoder(w) // Encode ends every response with a newline
+	for {
+		var req Request
+		if err := dec.Decode(&req); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("conformance: decoding request: %w", err)
+		}
+		resp, err := impl.Do(req)
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// Process is an Implementation backed by an adapter program.
+type Process struct {
+	cmd *exec.Cmd
+	in  io.WriteCloser
+	out *bufio.Reader
+	enc *json.Encoder
+}
+
+// Start runs the adapter program name with args. Its stderr is passed
+// through, so adapter diagnostics show up next to the runner's output.
+func Start(name string, args ...string) (*Process, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &Process{cmd: cmd, in: in, out: bufio.NewReader(out), enc: json.NewEncoder(in)}, nil
+}
+
+// Do sends req and reads one response line.
+func (p *Process) Do(req Request) (Response, error) {
+	if err := p.enc.Encode(req); err != nil {
+		return Response{}, fmt.Errorf("conformance: writing request: %w", err)
+	}
+	line, err := p.out.ReadBytes('\n')
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return Response{}, fmt.Errorf("conformance: reading response to %s: %w", req.Op, err)
+	}
+	var resp Response
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return Response{}, fmt.Errorf("conformance: invalid response %q: %w", line, err)
+	}
+	return resp, nil
+}
+
+// Close closes the adapter's stdin and waits for it to exit.
+func (p *Process) Close() error {
+	p.in.Close()
+	return p.cmd.Wait()
+}
@@ -0,0 +1,89 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestReference(t *testing.T) {
+	ref := Reference()
+
+	resp, _ := ref.Do(Request{Op: OpBuild, Micros: 1735689600123456, Shard: 42, Random: 0x123456789})
+	if resp.String != "18aa66e8-3909-8000-8000-02a123456789" || resp.Bytes != "18aa66e839098000800002a123456789" {
+		t.Errorf("Unexpected build response: %+v", resp)
+	}
+
+	resp, _ = ref.Do(Request{Op: OpParse, Input: "18aa66e8-3909-8000-8000-02a123456789"})
+	if resp.Micros != 1735689600123456 || resp.Shard != 42 || resp.Random != 0x123456789 || resp.Error != "" {
+		t.Errorf("Unexpected parse response: %+v", resp)
+	}
+
+	for _, req := range []Request{{Op: OpBuild, Shard: 1 << 32}, {Op: OpParse, Input: "nope"}, {Op: "delete"}} {
+		if resp, _ := ref.Do(req); resp.Error == "" {
+			t.Errorf("%+v should be rejected", req)
+		}
+	}
+}
+
+func TestServe(t *testing.T) {
+	in := `{"op":"build","micros":"1735689600123456","shard":42,"random":4886718345,"input":""}
+{"op":"parse","micros":"0","shard":0,"random":0,"input":"not-an-id"}
+`
+	var out bytes.Buffer
+	if err := Serve(strings.NewReader(in), &out, Reference()); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one response per request, got:\n%s", out.String())
+	}
+	var build, parse Response
+	if err := json.Unmarshal([]byte(lines[0]), &build); err != nil || build.String != "18aa66e8-3909-8000-8000-02a123456789" {
+		t.Errorf("Unexpected build response %s (%v)", lines[0], err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &parse); err != nil || parse.Error == "" {
+		t.Errorf("Unexpected parse response %s (%v)", lines[1], err)
+	}
+
+	if err := Serve(strings.NewReader("{"), &out, Reference()); err == nil {
+		t.Error("Malformed requests should fail")
+	}
+}
+
+// TestHelperAdapter is not a real test: TestProcess runs the test binary
+// with it as a stand-in adapter program.
+func TestHelperAdapter(t *testing.T) {
+	if os.Getenv("MSUUID_CONFORMANCE_ADAPTER") != "1" {
+		t.Skip("only runs as a subprocess of TestProcess")
+	}
+	if err := Serve(os.Stdin, os.Stdout, Reference()); err != nil {
+		t.Fatal(err)
+	}
+	os.Exit(0)
+}
+
+func TestProcess(t *testing.T) {
+	t.Setenv("MSUUID_CONFORMANCE_ADAPTER", "1")
+	p, err := Start(os.Args[0], "-test.run=^TestHelperAdapter$")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := Run(p)
+	for _, res := range report.Results {
+		if res.Err != nil {
+			t.Errorf("Check %s failed over the process protocol: %v", res.Check, res.Err)
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Errorf("Adapter did not exit cleanly: %v", err)
+	}
+
+	if _, err := p.Do(Request{Op: OpParse}); err == nil {
+		t.Error("Requests to a closed adapter should fail")
+	}
+}
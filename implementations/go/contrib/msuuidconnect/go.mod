@@ -0,0 +1,11 @@
+module github.com/dilipvamsi/microshard-uuid/implementations/go/contrib/msuuidconnect
+
+go 1.21
+
+require (
+	connectrpc.com/connect v1.16.2
+	github.com/dilipvamsi/microshard-uuid/implementations/go v0.0.0-00010101000000-000000000000
+	google.golang.org/protobuf v1.34.2
+)
+
+replace github.com/dilipvamsi/microshard-uuid/implementations/go => ../..
@@ -0,0 +1,110 @@
+// Package msuuidconnect propagates MicroShardUUID request IDs through
+// connectrpc.com/connect calls, with the same rules as contrib/msuuidgrpc.
+//
+// On handlers, the interceptor reuses a valid incoming X-Request-ID header
+// or generates a new ID, stores it in the context (read it with
+// requestid.FromContext), and returns it in the response header. On clients,
+// it forwards the ID found in the context, or mints one if a Generator is
+// given:
+//
+//	interceptors := connect.WithInterceptors(msuuidconnect.NewInterceptor(gen))
+//	path, handler := ordersv1connect.NewOrderServiceHandler(svc, interceptors)
+//	client := ordersv1connect.NewOrderServiceClient(http.DefaultClient, url, interceptors)
+package msuuidconnect
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/requestid"
+)
+
+// Interceptor implements connect.Interceptor for both clients and handlers.
+type Interceptor struct {
+	gen *microsharduuid.Generator
+}
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+// NewInterceptor returns an Interceptor that generates IDs with gen. On
+// handlers, a nil gen means the default Generator; on clients, it means IDs
+// are only forwarded, never generated.
+func NewInterceptor(gen *microsharduuid.Generator) *Interceptor {
+	return &Interceptor{gen: gen}
+}
+
+// WrapUnary implements connect.Interceptor.
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			ctx, err := i.clientContext(ctx, req.Header().Get(requestid.Header) != "")
+			if err != nil {
+				return nil, err
+			}
+			if id, ok := requestid.FromContext(ctx); ok && req.Header().Get(requestid.Header) == "" {
+				req.Header().Set(requestid.Header, id.String())
+			}
+			return next(ctx, req)
+		}
+
+		id, err := requestid.Resolve(ctx, i.gen, req.Header().Get(requestid.Header))
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		resp, err := next(requestid.NewContext(ctx, id), req)
+		if err != nil {
+			// Failed handlers may return a typed nil response, so the ID
+			// travels in the error metadata instead
+			var connectErr *connect.Error
+			if errors.As(err, &connectErr) {
+				connectErr.Meta().Set(requestid.Header, id.String())
+			}
+			return resp, err
+		}
+		resp.Header().Set(requestid.Header, id.String())
+		return resp, nil
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor.
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		// Stream constructors cannot fail, so a generation error only means
+		// the call goes out without an ID
+		ctx, _ = i.clientContext(ctx, false)
+		conn := next(ctx, spec)
+		if id, ok := requestid.FromContext(ctx); ok && conn.RequestHeader().Get(requestid.Header) == "" {
+			conn.RequestHeader().Set(requestid.Header, id.String())
+		}
+		return conn
+	}
+}
+
+// WrapStreamingHandler implements connect.Interceptor.
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		id, err := requestid.Resolve(ctx, i.gen, conn.RequestHeader().Get(requestid.Header))
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		conn.ResponseHeader().Set(requestid.Header, id.String())
+		return next(requestid.NewContext(ctx, id), conn)
+	}
+}
+
+// clientContext makes sure ctx carries a request ID, generating one if gen
+// is set. hasHeader reports whether the caller already set the header, in
+// which case nothing is generated.
+func (i *Interceptor) clientContext(ctx context.Context, hasHeader bool) (context.Context, error) {
+	if _, ok := requestid.FromContext(ctx); ok || hasHeader || i.gen == nil {
+		return ctx, nil
+	}
+	id, err := i.gen.NewIDContext(ctx)
+	if err != nil {
+		return ctx, err
+	}
+	return requestid.NewContext(ctx, id), nil
+}
@@ -0,0 +1,155 @@
+package msuuidconnect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/requestid"
+)
+
+const (
+	echoProcedure   = "/test.v1.EchoService/Echo"
+	streamProcedure = "/test.v1.EchoService/Stream"
+	failProcedure   = "/test.v1.EchoService/Fail"
+)
+
+type clients struct {
+	echo   *connect.Client[wrapperspb.StringValue, wrapperspb.StringValue]
+	stream *connect.Client[wrapperspb.StringValue, wrapperspb.StringValue]
+	fail   *connect.Client[wrapperspb.StringValue, wrapperspb.StringValue]
+}
+
+// setup serves handlers that reply with the request ID they saw.
+func setup(t *testing.T, serverGen, clientGen *microsharduuid.Generator) clients {
+	t.Helper()
+	seen := func(ctx context.Context) *wrapperspb.StringValue {
+		id, _ := requestid.FromContext(ctx)
+		return wrapperspb.String(id.String())
+	}
+	opts := connect.WithInterceptors(NewInterceptor(serverGen))
+
+	mux := http.NewServeMux()
+	mux.Handle(echoProcedure, connect.NewUnaryHandler(echoProcedure,
+		func(ctx context.Context, _ *connect.Request[wrapperspb.StringValue]) (*connect.Response[wrapperspb.StringValue], error) {
+			return connect.NewResponse(seen(ctx)), nil
+		}, opts))
+	mux.Handle(streamProcedure, connect.NewServerStreamHandler(streamProcedure,
+		func(ctx context.Context, _ *connect.Request[wrapperspb.StringValue], ss *connect.ServerStream[wrapperspb.StringValue]) error {
+			return ss.Send(seen(ctx))
+		}, opts))
+	mux.Handle(failProcedure, connect.NewUnaryHandler(failProcedure,
+		func(context.Context, *connect.Request[wrapperspb.StringValue]) (*connect.Response[wrapperspb.StringValue], error) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("missing"))
+		}, opts))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	copts := connect.WithInterceptors(NewInterceptor(clientGen))
+	return clients{
+		echo:   connect.NewClient[wrapperspb.StringValue, wrapperspb.StringValue](srv.Client(), srv.URL+echoProcedure, copts),
+		stream: connect.NewClient[wrapperspb.StringValue, wrapperspb.StringValue](srv.Client(), srv.URL+streamProcedure, copts),
+		fail:   connect.NewClient[wrapperspb.StringValue, wrapperspb.StringValue](srv.Client(), srv.URL+failProcedure, copts),
+	}
+}
+
+func TestUnaryPropagates(t *testing.T) {
+	serverGen, _ := microsharduuid.NewGenerator(1)
+	c := setup(t, serverGen, nil)
+
+	id, _ := microsharduuid.Generate(99)
+	resp, err := c.echo.CallUnary(requestid.NewContext(context.Background(), id), connect.NewRequest(wrapperspb.String("")))
+	if err != nil {
+		t.Fatalf("CallUnary failed: %v", err)
+	}
+	if resp.Msg.Value != id.String() {
+		t.Errorf("Handler saw %s, expected %s", resp.Msg.Value, id)
+	}
+	if resp.Header().Get(requestid.Header) != id.String() {
+		t.Errorf("Response header %q, expected %s", resp.Header().Get(requestid.Header), id)
+	}
+}
+
+func TestUnaryGenerates(t *testing.T) {
+	serverGen, _ := microsharduuid.NewGenerator(1)
+	clientGen, _ := microsharduuid.NewGenerator(2)
+
+	// Server-side generation when the client sends nothing
+	resp, err := setup(t, serverGen, nil).echo.CallUnary(context.Background(), connect.NewRequest(wrapperspb.String("")))
+	if err != nil {
+		t.Fatalf("CallUnary failed: %v", err)
+	}
+	if id, _ := microsharduuid.Parse(resp.Msg.Value); id.ShardID() != 1 {
+		t.Errorf("Expected a server ID from shard 1, got %s", resp.Msg.Value)
+	}
+
+	// Client-side generation
+	resp, err = setup(t, serverGen, clientGen).echo.CallUnary(context.Background(), connect.NewRequest(wrapperspb.String("")))
+	if err != nil {
+		t.Fatalf("CallUnary failed: %v", err)
+	}
+	if id, _ := microsharduuid.Parse(resp.Msg.Value); id.ShardID() != 2 {
+		t.Errorf("Expected a client ID from shard 2, got %s", resp.Msg.Value)
+	}
+}
+
+func TestServerStream(t *testing.T) {
+	serverGen, _ := microsharduuid.NewGenerator(1)
+	clientGen, _ := microsharduuid.NewGenerator(2)
+	c := setup(t, serverGen, clientGen)
+
+	stream, err := c.stream.CallServerStream(context.Background(), connect.NewRequest(wrapperspb.String("")))
+	if err != nil {
+		t.Fatalf("CallServerStream failed: %v", err)
+	}
+	defer stream.Close()
+	if !stream.Receive() {
+		t.Fatalf("Receive failed: %v", stream.Err())
+	}
+	id, err := microsharduuid.Parse(stream.Msg().Value)
+	if err != nil || id.ShardID() != 2 {
+		t.Errorf("Expected the client's ID from shard 2, got %q", stream.Msg().Value)
+	}
+	if stream.ResponseHeader().Get(requestid.Header) != id.String() {
+		t.Errorf("Stream response header %q, expected %s", stream.ResponseHeader().Get(requestid.Header), id)
+	}
+}
+
+func TestErrorCarriesID(t *testing.T) {
+	serverGen, _ := microsharduuid.NewGenerator(1)
+	c := setup(t, serverGen, nil)
+
+	id, _ := microsharduuid.Generate(99)
+	_, err := c.fail.CallUnary(requestid.NewContext(context.Background(), id), connect.NewRequest(wrapperspb.String("")))
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeNotFound {
+		t.Fatalf("Expected a NotFound error, got %v", err)
+	}
+	if connectErr.Meta().Get(requestid.Header) != id.String() {
+		t.Errorf("Error metadata %q, expected %s", connectErr.Meta().Get(requestid.Header), id)
+	}
+}
+
+func TestExplicitHeaderWins(t *testing.T) {
+	serverGen, _ := microsharduuid.NewGenerator(1)
+	clientGen, _ := microsharduuid.NewGenerator(2)
+	c := setup(t, serverGen, clientGen)
+
+	id, _ := microsharduuid.Generate(50)
+	req := connect.NewRequest(wrapperspb.String(""))
+	req.Header().Set(requestid.Header, id.String())
+	resp, err := c.echo.CallUnary(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CallUnary failed: %v", err)
+	}
+	if resp.Msg.Value != id.String() {
+		t.Errorf("Explicit header should be sent unchanged, handler saw %s", resp.Msg.Value)
+	}
+}
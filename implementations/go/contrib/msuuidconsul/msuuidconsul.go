@@ -0,0 +1,282 @@
+// Package msuuidconsul assigns Shard IDs through Consul sessions and KV
+// locks, for fleets standardized on Consul rather than etcd.
+//
+// Each instance creates a session with a TTL and acquires the lock of one
+// key below a prefix (e.g. "microshard/shards/0000000042"), renewing the
+// session in the background. When the instance stops or loses contact
+// with Consul, the session is invalidated, the key is deleted, and the
+// shard becomes free for the next instance:
+//
+//	alloc, err := msuuidconsul.New(msuuidconsul.Config{Client: client, First: 0, Last: 1023})
+//	gen, err := microsharduuid.NewGenerator(0, microsharduuid.WithAllocator(alloc))
+//	defer alloc.Release(context.Background())
+//
+// Once no renewal has succeeded for half the TTL, the Generator stops
+// issuing IDs with microsharduuid.ErrLeaseExpired, before the session can
+// be invalidated and another instance take the shard over;
+// Generator.Reacquire claims a new one.
+package msuuidconsul
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Defaults applied by New.
+const (
+	DefaultPrefix = "microshard/shards/"
+	DefaultTTL    = 15 * time.Second
+)
+
+// maxAttempts bounds the rescans when other instances claim the shards
+// this one picked.
+const maxAttempts = 5
+
+// Config configures an Allocator.
+type Config struct {
+	Client *api.Client
+	Prefix string        // KV prefix (DefaultPrefix if empty)
+	First  uint32        // First Shard ID of the range
+	Last   uint32        // Last Shard ID of the range (inclusive)
+	TTL    time.Duration // Session TTL, 10s to 24h (DefaultTTL if zero)
+	Owner  string        // Value stored under the key, for operators (host:pid if empty)
+
+	// LockDelay keeps the shard of an invalidated session locked for a
+	// while, as extra margin for an instance that lost contact to stop
+	// before another takes the shard over. Consul's default (15s) applies
+	// if zero.
+	LockDelay time.Duration
+}
+
+// Allocator implements microsharduuid.Allocator with Consul sessions. It
+// holds at most one shard at a time and is safe for concurrent use.
+type Allocator struct {
+	cfg Config
+
+	mu    sync.Mutex
+	claim *claim // nil when no shard is held
+}
+
+// claim is one held shard.
+type claim struct {
+	shard   uint32
+	session string
+	cancel  context.CancelFunc // Stops the renewals
+	lost    chan struct{}      // Closed when the renewals end
+}
+
+// New validates cfg and creates an Allocator. It fails with
+// microsharduuid.ErrInvalidOption for a nil client, a reversed range, or a
+// TTL outside Consul's limits.
+func New(cfg Config) (*Allocator, error) {
+	if cfg.Client == nil {
+		return nil, fmt.Errorf("%w: consul allocator needs a client", microsharduuid.ErrInvalidOption)
+	}
+	if cfg.First > cfg.Last {
+		return nil, fmt.Errorf("%w: shard range %d-%d is reversed", microsharduuid.ErrInvalidOption, cfg.First, cfg.Last)
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = DefaultTTL
+	}
+	if cfg.TTL < 10*time.Second || cfg.TTL > 24*time.Hour {
+		return nil, fmt.Errorf("%w: consul session TTL %v outside 10s-24h", microsharduuid.ErrInvalidOption, cfg.TTL)
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = DefaultPrefix
+	}
+	if cfg.Owner == "" {
+		host, _ := os.Hostname()
+		cfg.Owner = host + ":" + strconv.Itoa(os.Getpid())
+	}
+	return &Allocator{cfg: cfg}, nil
+}
+
+// Key returns the KV key locked by the owner of shardID.
+func (a *Allocator) Key(shardID uint32) string {
+	// Zero-padded, so keys list in shard order
+	return fmt.Sprintf("%s%010d", a.cfg.Prefix, shardID)
+}
+
+// Acquire creates a session, locks the key of a free shard of the range
+// with it, and renews the session until Release. A shard already held is
+// released first. It fails with an error wrapping
+// microsharduuid.ErrNoShardAvailable when every shard of the range is taken.
+func (a *Allocator) Acquire(ctx context.Context) (uint32, <-chan struct{}, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.release(ctx); err != nil {
+		return 0, nil, err
+	}
+
+	created := time.Now()
+	q := (&api.WriteOptions{}).WithContext(ctx)
+	session, _, err := a.cfg.Client.Session().Create(&api.SessionEntry{
+		Name:      "microshard " + a.cfg.Owner,
+		TTL:       a.cfg.TTL.String(),
+		Behavior:  api.SessionBehaviorDelete,
+		LockDelay: a.cfg.LockDelay,
+	}, q)
+	if err != nil {
+		return 0, nil, fmt.Errorf("msuuidconsul: creating session: %w", err)
+	}
+
+	shard, err := a.claimFree(ctx, session)
+	if err != nil {
+		a.destroy(session)
+		return 0, nil, err
+	}
+
+	// The renewals outlive ctx, which only bounds Acquire itself
+	renewCtx, cancel := context.WithCancel(context.Background())
+	c := &claim{shard: shard, session: session, cancel: cancel, lost: make(chan struct{})}
+	go func() {
+		a.renew(renewCtx, c, created)
+		close(c.lost)
+	}()
+	a.claim = c
+	return shard, c.lost, nil
+}
+
+// renew renews the session of c every sixth of the TTL until ctx is
+// canceled, and returns once the session is gone or no renewal has
+// succeeded for half the TTL.
+//
+// Session().RenewPeriodic counts the TTL from the last response and waits
+// on a request that can hang for as long as a partition lasts, so the
+// session could be invalidated and the shard taken over while this
+// instance still issues IDs. As with the etcd allocator, each renewal is
+// bounded by the deadline and counted from when it was sent.
+func (a *Allocator) renew(ctx context.Context, c *claim, created time.Time) {
+	ticker := time.NewTicker(a.cfg.TTL / 6)
+	defer ticker.Stop()
+
+	deadline := created.Add(a.cfg.TTL / 2)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		sent := time.Now()
+		rctx, cancel := context.WithDeadline(ctx, deadline)
+		entry, _, err := a.cfg.Client.Session().Renew(c.session, (&api.WriteOptions{}).WithContext(rctx))
+		cancel()
+		if err == nil {
+			if entry == nil {
+				return // Invalidated or destroyed
+			}
+			deadline = sent.Add(a.cfg.TTL / 2)
+		}
+		if !time.Now().Before(deadline) {
+			return
+		}
+	}
+}
+
+// claimFree locks the key of a free shard with session, starting from a
+// random shard so concurrent instances rarely race for the same key.
+func (a *Allocator) claimFree(ctx context.Context, session string) (uint32, error) {
+	size := uint64(a.cfg.Last) - uint64(a.cfg.First) + 1
+	kv := a.cfg.Client.KV()
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		taken, err := a.taken(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if uint64(len(taken)) >= size {
+			return 0, fmt.Errorf("%w: all %d shards of %s are taken", microsharduuid.ErrNoShardAvailable, size, a.cfg.Prefix)
+		}
+
+		start := uint64(rand.Int63n(int64(size)))
+		for i := uint64(0); i < size; i++ {
+			shard := a.cfg.First + uint32((start+i)%size)
+			if taken[shard] {
+				continue
+			}
+			pair := &api.KVPair{Key: a.Key(shard), Value: []byte(a.cfg.Owner), Session: session}
+			ok, _, err := kv.Acquire(pair, (&api.WriteOptions{}).WithContext(ctx))
+			if err != nil {
+				return 0, fmt.Errorf("msuuidconsul: claiming shard %d: %w", shard, err)
+			}
+			if ok {
+				return shard, nil
+			}
+			// Locked by another instance since the scan: rescan
+			break
+		}
+	}
+	return 0, fmt.Errorf("%w: lost %d races for a shard of %s", microsharduuid.ErrNoShardAvailable, maxAttempts, a.cfg.Prefix)
+}
+
+// taken lists the locked shards of the range.
+func (a *Allocator) taken(ctx context.Context) (map[uint32]bool, error) {
+	pairs, _, err := a.cfg.Client.KV().List(a.cfg.Prefix, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("msuuidconsul: listing shards: %w", err)
+	}
+	taken := make(map[uint32]bool, len(pairs))
+	for _, p := range pairs {
+		if p.Session == "" {
+			continue // Left behind unlocked, free to acquire
+		}
+		shard, err := strconv.ParseUint(strings.TrimPrefix(p.Key, a.cfg.Prefix), 10, 32)
+		if err == nil && uint32(shard) >= a.cfg.First && uint32(shard) <= a.cfg.Last {
+			taken[uint32(shard)] = true
+		}
+	}
+	return taken, nil
+}
+
+// Release destroys the session, which deletes the shard key, and closes
+// the channel returned by Acquire. It does nothing if no shard is held.
+func (a *Allocator) Release(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.release(ctx)
+}
+
+func (a *Allocator) release(ctx context.Context) error {
+	c := a.claim
+	if c == nil {
+		return nil
+	}
+	a.claim = nil
+	c.cancel()
+	<-c.lost
+
+	// Destroying a session that was already invalidated succeeds
+	if _, err := a.cfg.Client.Session().Destroy(c.session, (&api.WriteOptions{}).WithContext(ctx)); err != nil {
+		return fmt.Errorf("msuuidconsul: destroying session of shard %d: %w", c.shard, err)
+	}
+	return nil
+}
+
+// destroy drops a session that never became a claim.
+func (a *Allocator) destroy(session string) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.TTL)
+	defer cancel()
+	a.cfg.Client.Session().Destroy(session, (&api.WriteOptions{}).WithContext(ctx)) // Best effort: it expires after the TTL anyway
+}
+
+// Shard returns the held Shard ID, or false if none is held.
+func (a *Allocator) Shard() (uint32, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.claim == nil {
+		return 0, false
+	}
+	return a.claim.shard, true
+}
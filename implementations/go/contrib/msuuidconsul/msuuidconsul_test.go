@@ -0,0 +1,314 @@
+package msuuidconsul
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// fakeConsul implements the session and KV endpoints used by the
+// allocator, with delete-behavior sessions.
+type fakeConsul struct {
+	mu       sync.Mutex
+	nextID   int
+	sessions map[string]bool
+	kv       map[string]*api.KVPair
+
+	// stalled is non-nil while renewals hang, as during a partition, and
+	// closed to resume them
+	stalled chan struct{}
+}
+
+func (f *fakeConsul) stall() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stalled = make(chan struct{})
+}
+
+func (f *fakeConsul) resume() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	close(f.stalled)
+	f.stalled = nil
+}
+
+func newFakeConsul(t *testing.T) (*fakeConsul, *api.Client) {
+	t.Helper()
+	f := &fakeConsul{sessions: make(map[string]bool), kv: make(map[string]*api.KVPair)}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	client, err := api.NewClient(&api.Config{Address: strings.TrimPrefix(srv.URL, "http://")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, client
+}
+
+// invalidate drops a session as if its TTL had run out.
+func (f *fakeConsul) invalidate(session string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.invalidateLocked(session)
+}
+
+func (f *fakeConsul) invalidateLocked(session string) {
+	delete(f.sessions, session)
+	for key, pair := range f.kv {
+		if pair.Session == session {
+			delete(f.kv, key)
+		}
+	}
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	if strings.HasPrefix(path, "/v1/session/renew/") {
+		f.mu.Lock()
+		stalled := f.stalled
+		f.mu.Unlock()
+		if stalled != nil {
+			select {
+			case <-stalled:
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case path == "/v1/session/create":
+		f.nextID++
+		id := fmt.Sprintf("session-%d", f.nextID)
+		f.sessions[id] = true
+		json.NewEncoder(w).Encode(map[string]string{"ID": id})
+
+	case strings.HasPrefix(path, "/v1/session/renew/"):
+		id := strings.TrimPrefix(path, "/v1/session/renew/")
+		if !f.sessions[id] {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode([]api.SessionEntry{{ID: id, TTL: "10s"}})
+
+	case strings.HasPrefix(path, "/v1/session/destroy/"):
+		f.invalidateLocked(strings.TrimPrefix(path, "/v1/session/destroy/"))
+		io.WriteString(w, "true")
+
+	case strings.HasPrefix(path, "/v1/kv/") && r.Method == http.MethodGet:
+		prefix := strings.TrimPrefix(path, "/v1/kv/")
+		var pairs []*api.KVPair
+		for key, pair := range f.kv {
+			if strings.HasPrefix(key, prefix) {
+				pairs = append(pairs, pair)
+			}
+		}
+		if len(pairs) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+		json.NewEncoder(w).Encode(pairs)
+
+	case strings.HasPrefix(path, "/v1/kv/") && r.Method == http.MethodPut:
+		key := strings.TrimPrefix(path, "/v1/kv/")
+		session := r.URL.Query().Get("acquire")
+		if !f.sessions[session] {
+			http.Error(w, "invalid session", http.StatusInternalServerError)
+			return
+		}
+		if held, ok := f.kv[key]; ok && held.Session != "" && held.Session != session {
+			io.WriteString(w, "false")
+			return
+		}
+		value, _ := io.ReadAll(r.Body)
+		f.kv[key] = &api.KVPair{Key: key, Value: value, Session: session}
+		io.WriteString(w, "true")
+
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+path, http.StatusBadRequest)
+	}
+}
+
+func TestNewValidation(t *testing.T) {
+	_, client := newFakeConsul(t)
+	for name, cfg := range map[string]Config{
+		"no client":      {},
+		"reversed range": {Client: client, First: 5, Last: 4},
+		"short TTL":      {Client: client, TTL: time.Second},
+	} {
+		if _, err := New(cfg); !errors.Is(err, microsharduuid.ErrInvalidOption) {
+			t.Errorf("%s: expected ErrInvalidOption, got %v", name, err)
+		}
+	}
+}
+
+func TestAcquireUniqueShards(t *testing.T) {
+	fake, client := newFakeConsul(t)
+	ctx := context.Background()
+
+	var allocs []*Allocator
+	seen := make(map[uint32]bool)
+	for i := 0; i < 3; i++ {
+		a, err := New(Config{Client: client, First: 10, Last: 12, Owner: fmt.Sprintf("instance-%d", i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		shard, _, err := a.Acquire(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shard < 10 || shard > 12 || seen[shard] {
+			t.Fatalf("Shard %d is outside the range or already taken (%v)", shard, seen)
+		}
+		seen[shard] = true
+		allocs = append(allocs, a)
+	}
+
+	fake.mu.Lock()
+	owner := string(fake.kv[allocs[0].Key(mustShard(t, allocs[0]))].Value)
+	fake.mu.Unlock()
+	if owner != "instance-0" {
+		t.Errorf("Shard key should name its owner, got %q", owner)
+	}
+
+	extra, _ := New(Config{Client: client, First: 10, Last: 12})
+	if _, _, err := extra.Acquire(ctx); !errors.Is(err, microsharduuid.ErrNoShardAvailable) {
+		t.Fatalf("A full range should fail with ErrNoShardAvailable, got %v", err)
+	}
+
+	// Releasing frees the shard for the next instance right away
+	freed := mustShard(t, allocs[1])
+	if err := allocs[1].Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := allocs[1].Shard(); ok {
+		t.Error("Shard should report nothing after Release")
+	}
+	shard, _, err := extra.Acquire(ctx)
+	if err != nil || shard != freed {
+		t.Errorf("Expected the released shard %d, got %d (%v)", freed, shard, err)
+	}
+}
+
+func TestGeneratorWithAllocator(t *testing.T) {
+	fake, client := newFakeConsul(t)
+	ctx := context.Background()
+
+	a, err := New(Config{Client: client, Prefix: "test/", First: 100, Last: 101})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := microsharduuid.NewGenerator(0, microsharduuid.WithAllocator(a))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := gen.NewID()
+	if err != nil || id.ShardID() != mustShard(t, a) {
+		t.Fatalf("Unexpected ID %v (%v)", id, err)
+	}
+
+	// Invalidating the session (TTL expiry on the server) ends the claim
+	a.mu.Lock()
+	session := a.claim.session
+	a.mu.Unlock()
+	fake.invalidate(session)
+
+	deadline := time.Now().Add(15 * time.Second)
+	for {
+		if _, err := gen.NewID(); errors.Is(err, microsharduuid.ErrLeaseExpired) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Generation should stop once the session is invalidated")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if err := gen.Reacquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gen.NewID(); err != nil {
+		t.Errorf("Reacquire should restore generation: %v", err)
+	}
+	if err := a.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.kv) != 0 || len(fake.sessions) != 0 {
+		t.Errorf("Release should destroy the session and its key: %v %v", fake.kv, fake.sessions)
+	}
+}
+
+func TestStalledRenewals(t *testing.T) {
+	fake, client := newFakeConsul(t)
+	ctx := context.Background()
+
+	a, err := New(Config{Client: client, First: 0, Last: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ttl := 600 * time.Millisecond
+	a.cfg.TTL = ttl // Below Consul's minimum, which the fake does not enforce
+	_, lost, err := a.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Renewals keep the claim past the TTL
+	select {
+	case <-lost:
+		t.Fatal("A renewed claim should not be lost")
+	case <-time.After(2 * ttl):
+	}
+
+	// Once renewals hang, the claim must end before the server can
+	// invalidate the session, a TTL after the last renewal that got through
+	fake.stall()
+	stalled := time.Now()
+	select {
+	case <-lost:
+		if d := time.Since(stalled); d >= ttl {
+			t.Errorf("The claim should end before the TTL, took %v", d)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stalled renewals should close the lost channel")
+	}
+
+	// Release does not wait for the hung renewal
+	releaseCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := a.Release(releaseCtx); err != nil {
+		t.Errorf("Release after stalled renewals should succeed, got %v", err)
+	}
+	fake.resume()
+	if _, _, err := a.Acquire(ctx); err != nil {
+		t.Errorf("The shard should be free again: %v", err)
+	}
+	a.Release(ctx)
+}
+
+func mustShard(t *testing.T, a *Allocator) uint32 {
+	t.Helper()
+	shard, ok := a.Shard()
+	if !ok {
+		t.Fatal("No shard held")
+	}
+	return shard
+}
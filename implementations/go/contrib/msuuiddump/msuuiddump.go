@@ -0,0 +1,350 @@
+// Package msuuiddump defines a chunked, zstd-compressed container for large
+// dumps of MicroShardUUIDs, with an index that allows pulling a time slice
+// without decompressing the whole file.
+//
+// File layout:
+//
+//	[Header]  "MSUD" [Format Version 1]
+//	[Chunk]*  zstd( streamcodec stream of up to ChunkSize IDs )
+//	[Index]   per chunk: [Offset 8] [Length 8] [Count 4] [Min ID 16] [Max ID 16]
+//	[Footer]  [Index Offset 8] [Chunk Count 4] "MSUI"
+//
+// All integers are Big Endian. The index sits at the end so the dump can be
+// written in a single streaming pass.
+package msuuiddump
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/streamcodec"
+)
+
+// DefaultChunkSize is the number of IDs per chunk used by NewWriter.
+const DefaultChunkSize = 64 * 1024
+
+const (
+	headerMagic   = "MSUD"
+	footerMagic   = "MSUI"
+	formatVersion = 1
+
+	headerLen     = 5
+	indexEntryLen = 8 + 8 + 4 + 16 + 16
+	footerLen     = 8 + 4 + 4
+
+	// minStreamIDLen is the smallest encoding of one ID in a streamcodec
+	// stream: a 1-byte time delta, a 1-byte shard reference, 5 random bytes.
+	minStreamIDLen = 7
+
+	// maxZstdRatio bounds how much a zstd body can expand: a 128 KiB block
+	// takes at least 4 bytes (a 3-byte block header and one RLE byte).
+	maxZstdRatio = 128 << 10 / 4
+)
+
+// ErrInvalidDump is returned when the input is not a valid dump file.
+var ErrInvalidDump = errors.New("msuuiddump: invalid dump")
+
+// ChunkInfo describes one compressed chunk of a dump.
+type ChunkInfo struct {
+	Offset int64 // Offset of the compressed body
+	Length int64 // Length of the compressed body
+	Count  int   // Number of IDs
+	Min    microsharduuid.MicroShardUUID
+	Max    microsharduuid.MicroShardUUID
+}
+
+// ==========================================
+// Writer
+// ==========================================
+
+// Writer writes a dump in a single streaming pass.
+// Close must be called to write the index.
+type Writer struct {
+	w         io.Writer
+	enc       *zstd.Encoder
+	chunkSize int
+	offset    int64
+	pending   []microsharduuid.MicroShardUUID
+	index     []ChunkInfo
+	closed    bool
+}
+
+// NewWriter returns a Writer using DefaultChunkSize.
+func NewWriter(w io.Writer) (*Writer, error) {
+	return NewWriterSize(w, DefaultChunkSize)
+}
+
+// NewWriterSize returns a Writer that stores up to chunkSize IDs per chunk.
+// Smaller chunks make range reads more selective at the cost of compression.
+func NewWriterSize(w io.Writer, chunkSize int) (*Writer, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("msuuiddump: chunk size must be positive")
+	}
+
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	hdr := append([]byte(headerMagic), formatVersion)
+	if _, err := w.Write(hdr); err != nil {
+		return nil, err
+	}
+
+	return &Writer{w: w, enc: enc, chunkSize: chunkSize, offset: headerLen}, nil
+}
+
+// Write appends one ID. IDs should be written in sorted order so that
+// chunk ranges don't overlap, but any order is accepted.
+func (d *Writer) Write(id microsharduuid.MicroShardUUID) error {
+	if d.closed {
+		return errors.New("msuuiddump: write after close")
+	}
+	d.pending = append(d.pending, id)
+	if len(d.pending) >= d.chunkSize {
+		return d.flushChunk()
+	}
+	return nil
+}
+
+// Close writes the last chunk and the index. It does not close the underlying io.Writer.
+func (d *Writer) Close() error {
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	defer d.enc.Close()
+
+	if err := d.flushChunk(); err != nil {
+		return err
+	}
+
+	indexOffset := d.offset
+	buf := make([]byte, 0, len(d.index)*indexEntryLen+footerLen)
+	for _, c := range d.index {
+		buf = appendUint64(buf, uint64(c.Offset))
+		buf = appendUint64(buf, uint64(c.Length))
+		buf = appendUint32(buf, uint32(c.Count))
+		buf = append(buf, c.Min.Bytes()...)
+		buf = append(buf, c.Max.Bytes()...)
+	}
+	buf = appendUint64(buf, uint64(indexOffset))
+	buf = appendUint32(buf, uint32(len(d.index)))
+	buf = append(buf, footerMagic...)
+
+	_, err := d.w.Write(buf)
+	return err
+}
+
+func (d *Writer) flushChunk() error {
+	if len(d.pending) == 0 {
+		return nil
+	}
+
+	info := ChunkInfo{Offset: d.offset, Count: len(d.pending), Min: d.pending[0], Max: d.pending[0]}
+
+	var raw bytes.Buffer
+	sw := streamcodec.NewWriter(&raw)
+	for _, id := range d.pending {
+		if err := sw.Write(id); err != nil {
+			return err
+		}
+		if id.Before(info.Min) {
+			info.Min = id
+		}
+		if id.After(info.Max) {
+			info.Max = id
+		}
+	}
+	if err := sw.Close(); err != nil {
+		return err
+	}
+
+	body := d.enc.EncodeAll(raw.Bytes(), nil)
+	if _, err := d.w.Write(body); err != nil {
+		return err
+	}
+
+	info.Length = int64(len(body))
+	d.offset += info.Length
+	d.index = append(d.index, info)
+	d.pending = d.pending[:0]
+	return nil
+}
+
+// ==========================================
+// Reader
+// ==========================================
+
+// Reader provides indexed, random access to a dump.
+type Reader struct {
+	r           io.ReaderAt
+	dec         *zstd.Decoder
+	index       []ChunkInfo
+	indexOffset int64
+}
+
+// NewReader opens a dump of the given size and loads its index. It fails
+// with ErrInvalidDump if the header, footer, or any index entry is
+// inconsistent with the file size.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < headerLen+footerLen {
+		return nil, ErrInvalidDump
+	}
+
+	hdr := make([]byte, headerLen)
+	if _, err := r.ReadAt(hdr, 0); err != nil {
+		return nil, err
+	}
+	if string(hdr[:4]) != headerMagic || hdr[4] != formatVersion {
+		return nil, ErrInvalidDump
+	}
+
+	footer := make([]byte, footerLen)
+	if _, err := r.ReadAt(footer, size-footerLen); err != nil {
+		return nil, err
+	}
+	if string(footer[12:]) != footerMagic {
+		return nil, ErrInvalidDump
+	}
+	indexOffset := int64(binary.BigEndian.Uint64(footer[0:8]))
+	count := int64(binary.BigEndian.Uint32(footer[8:12]))
+	if indexOffset < headerLen || indexOffset > size-footerLen || indexOffset+count*indexEntryLen != size-footerLen {
+		return nil, ErrInvalidDump
+	}
+
+	raw := make([]byte, count*indexEntryLen)
+	if _, err := r.ReadAt(raw, indexOffset); err != nil {
+		return nil, err
+	}
+
+	index := make([]ChunkInfo, count)
+	for i := range index {
+		e := raw[i*indexEntryLen:]
+		min, errMin := microsharduuid.FromBytes(e[20:36])
+		max, errMax := microsharduuid.FromBytes(e[36:52])
+		if errMin != nil || errMax != nil {
+			return nil, ErrInvalidDump
+		}
+		index[i] = ChunkInfo{
+			Offset: int64(binary.BigEndian.Uint64(e[0:8])),
+			Length: int64(binary.BigEndian.Uint64(e[8:16])),
+			Count:  int(binary.BigEndian.Uint32(e[16:20])),
+			Min:    min,
+			Max:    max,
+		}
+		if !validChunk(index[i], indexOffset) {
+			return nil, ErrInvalidDump
+		}
+	}
+
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{r: r, dec: dec, index: index, indexOffset: indexOffset}, nil
+}
+
+// validChunk reports whether c lies between the header and the index, and
+// its Count could fit in its Length.
+func validChunk(c ChunkInfo, indexOffset int64) bool {
+	if c.Offset < headerLen || c.Length < 0 || c.Offset > indexOffset || c.Length > indexOffset-c.Offset {
+		return false
+	}
+	minLength := (uint64(c.Count)*minStreamIDLen + maxZstdRatio - 1) / maxZstdRatio
+	return c.Count >= 0 && uint64(c.Length) >= minLength
+}
+
+// Close releases decoder resources.
+func (d *Reader) Close() {
+	d.dec.Close()
+}
+
+// Chunks returns the chunk index.
+func (d *Reader) Chunks() []ChunkInfo {
+	return d.index
+}
+
+// Range calls fn for every ID created in [from, to), stopping early if fn returns false.
+// Only chunks whose [Min, Max] time range overlaps the window are decompressed.
+// IDs are visited in file order.
+func (d *Reader) Range(from, to time.Time, fn func(microsharduuid.MicroShardUUID) bool) error {
+	for _, c := range d.index {
+		if c.Max.Time().Before(from) || !c.Min.Time().Before(to) {
+			continue
+		}
+
+		ids, err := d.ReadChunk(c)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			ts := id.Time()
+			if ts.Before(from) || !ts.Before(to) {
+				continue
+			}
+			if !fn(id) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// ReadChunk decompresses a single chunk. It fails with ErrInvalidDump for a
+// chunk outside the dump or whose content does not match its index entry.
+func (d *Reader) ReadChunk(c ChunkInfo) ([]microsharduuid.MicroShardUUID, error) {
+	if !validChunk(c, d.indexOffset) {
+		return nil, ErrInvalidDump
+	}
+	body := make([]byte, c.Length)
+	if _, err := d.r.ReadAt(body, c.Offset); err != nil {
+		return nil, err
+	}
+
+	raw, err := d.dec.DecodeAll(body, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count was only bounded loosely, so size the slice from the data
+	n := c.Count
+	if limit := len(raw) / minStreamIDLen; n > limit {
+		n = limit
+	}
+	ids := make([]microsharduuid.MicroShardUUID, 0, n)
+	sr := streamcodec.NewReader(bytes.NewReader(raw))
+	for {
+		id, err := sr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != c.Count {
+		return nil, ErrInvalidDump
+	}
+	return ids, nil
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var tmp [8]byte
+	binary.BigEndian.PutUint64(tmp[:], v)
+	return append(b, tmp[:]...)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var tmp [4]byte
+	binary.BigEndian.PutUint32(tmp[:], v)
+	return append(b, tmp[:]...)
+}
@@ -0,0 +1,131 @@
+package msuuiddump
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func writeDump(t *testing.T, ids []microsharduuid.MicroShardUUID, chunkSize int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriterSize(&buf, chunkSize)
+	if err != nil {
+		t.Fatalf("NewWriterSize failed: %v", err)
+	}
+	for _, id := range ids {
+		if err := w.Write(id); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestRangeReadsOnlyOverlappingChunks(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var ids []microsharduuid.MicroShardUUID
+	for i := 0; i < 10000; i++ {
+		ids = append(ids, microsharduuid.MustFromTime(base.Add(time.Duration(i)*time.Second), uint32(i%8)))
+	}
+
+	data := writeDump(t, ids, 1000)
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+	defer r.Close()
+
+	if len(r.Chunks()) != 10 {
+		t.Fatalf("Expected 10 chunks, got %d", len(r.Chunks()))
+	}
+
+	// Seconds [2500, 3500) span chunks 2 and 3 only
+	from := base.Add(2500 * time.Second)
+	to := base.Add(3500 * time.Second)
+
+	var got []microsharduuid.MicroShardUUID
+	if err := r.Range(from, to, func(id microsharduuid.MicroShardUUID) bool {
+		got = append(got, id)
+		return true
+	}); err != nil {
+		t.Fatalf("Range failed: %v", err)
+	}
+
+	if len(got) != 1000 || got[0] != ids[2500] || got[999] != ids[3499] {
+		t.Errorf("Unexpected range result: %d IDs", len(got))
+	}
+
+	overlapping := 0
+	for _, c := range r.Chunks() {
+		if !c.Max.Time().Before(from) && c.Min.Time().Before(to) {
+			overlapping++
+		}
+	}
+	if overlapping != 2 {
+		t.Errorf("Expected 2 overlapping chunks, got %d", overlapping)
+	}
+}
+
+func TestEmptyAndCorruptDumps(t *testing.T) {
+	data := writeDump(t, nil, 10)
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Empty dump should open: %v", err)
+	}
+	if len(r.Chunks()) != 0 {
+		t.Errorf("Empty dump should have no chunks")
+	}
+	r.Close()
+
+	data = writeDump(t, []microsharduuid.MicroShardUUID{microsharduuid.MustGenerate(1)}, 10)
+	truncated := data[:len(data)-1]
+	if _, err := NewReader(bytes.NewReader(truncated), int64(len(truncated))); err == nil {
+		t.Error("Truncated dump should be rejected")
+	}
+}
+
+func TestCorruptIndex(t *testing.T) {
+	ids := []microsharduuid.MicroShardUUID{microsharduuid.MustGenerate(1), microsharduuid.MustGenerate(2)}
+	data := writeDump(t, ids, 10)
+	indexOffset := int64(binary.BigEndian.Uint64(data[len(data)-footerLen:]))
+
+	cases := map[string]func(e []byte){
+		"offset in header":   func(e []byte) { binary.BigEndian.PutUint64(e[0:8], 2) },
+		"offset past index":  func(e []byte) { binary.BigEndian.PutUint64(e[0:8], uint64(indexOffset)+1) },
+		"negative offset":    func(e []byte) { binary.BigEndian.PutUint64(e[0:8], 1<<63) },
+		"length past index":  func(e []byte) { binary.BigEndian.PutUint64(e[8:16], uint64(indexOffset)) },
+		"huge length":        func(e []byte) { binary.BigEndian.PutUint64(e[8:16], 1<<62) },
+		"count beyond bytes": func(e []byte) { binary.BigEndian.PutUint32(e[16:20], ^uint32(0)) },
+	}
+	for name, corrupt := range cases {
+		bad := append([]byte(nil), data...)
+		corrupt(bad[indexOffset:])
+		if _, err := NewReader(bytes.NewReader(bad), int64(len(bad))); !errors.Is(err, ErrInvalidDump) {
+			t.Errorf("%s: expected ErrInvalidDump, got %v", name, err)
+		}
+	}
+
+	// Entries passed to ReadChunk directly are checked too
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	c := r.Chunks()[0]
+	c.Length = 1 << 40
+	if _, err := r.ReadChunk(c); !errors.Is(err, ErrInvalidDump) {
+		t.Errorf("Out-of-range chunk: expected ErrInvalidDump, got %v", err)
+	}
+	c = r.Chunks()[0]
+	c.Count = 3
+	if _, err := r.ReadChunk(c); !errors.Is(err, ErrInvalidDump) {
+		t.Errorf("Wrong count: expected ErrInvalidDump, got %v", err)
+	}
+}
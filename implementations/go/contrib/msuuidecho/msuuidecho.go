@@ -0,0 +1,102 @@
+// Package msuuidecho binds MicroShardUUIDs in Echo routes and assigns
+// request IDs.
+//
+// Path and query parameters parse in any encoding accepted by
+// microsharduuid.ParseAny. Invalid input yields an *echo.HTTPError with
+// status 400, which Echo's error handler renders as
+// {"message": "invalid id: ..."}.
+//
+//	e.GET("/orders/:id", func(c echo.Context) error {
+//		id, err := msuuidecho.Param(c, "id")
+//		if err != nil {
+//			return err // 400
+//		}
+//		return c.JSON(http.StatusOK, map[string]uint32{"shard": id.ShardID()})
+//	})
+//
+// echo.Context.Bind also works on struct fields of type MicroShardUUID,
+// since it implements Echo's BindUnmarshaler:
+//
+//	var req struct {
+//		ID microsharduuid.MicroShardUUID `param:"id"`
+//	}
+//	if err := c.Bind(&req); err != nil {
+//		return err
+//	}
+package msuuidecho
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/requestid"
+)
+
+// contextKeyPrefix namespaces the IDs stored by Params in the echo.Context.
+const contextKeyPrefix = "msuuid.param."
+
+// Param parses the path parameter name, returning a 400 *echo.HTTPError if
+// it is invalid.
+func Param(c echo.Context, name string) (microsharduuid.MicroShardUUID, error) {
+	return parse(name, c.Param(name))
+}
+
+// Query parses the query parameter name, with the same error handling as
+// Param. A missing parameter is invalid as well.
+func Query(c echo.Context, name string) (microsharduuid.MicroShardUUID, error) {
+	return parse(name, c.QueryParam(name))
+}
+
+func parse(name, value string) (microsharduuid.MicroShardUUID, error) {
+	id, err := microsharduuid.ParseAny(value)
+	if err != nil {
+		return microsharduuid.MicroShardUUID{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid %s: %v", name, err)).SetInternal(err)
+	}
+	return id, nil
+}
+
+// Params returns middleware that validates the path parameters names before
+// the handler runs, so routes can rely on Get instead of checking errors.
+func Params(names ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			for _, name := range names {
+				id, err := Param(c, name)
+				if err != nil {
+					return err
+				}
+				c.Set(contextKeyPrefix+name, id)
+			}
+			return next(c)
+		}
+	}
+}
+
+// Get returns the path parameter name parsed by Params.
+func Get(c echo.Context, name string) (microsharduuid.MicroShardUUID, bool) {
+	id, ok := c.Get(contextKeyPrefix + name).(microsharduuid.MicroShardUUID)
+	return id, ok
+}
+
+// RequestID returns middleware that assigns a request ID like
+// requestid.Middleware: it reuses a valid incoming X-Request-ID header or
+// generates one from gen (the default Generator if nil), stores it in the
+// request context (see requestid.FromContext), and sets the response header.
+func RequestID(gen *microsharduuid.Generator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			id, err := requestid.Resolve(req.Context(), gen, req.Header.Get(requestid.Header))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+			}
+
+			c.SetRequest(req.WithContext(requestid.NewContext(req.Context(), id)))
+			c.Response().Header().Set(requestid.Header, id.String())
+			return next(c)
+		}
+	}
+}
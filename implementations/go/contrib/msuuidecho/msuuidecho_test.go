@@ -0,0 +1,143 @@
+package msuuidecho
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/requestid"
+)
+
+func do(e *echo.Echo, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+	return rec
+}
+
+func errorMessage(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid error body %q: %v", rec.Body.String(), err)
+	}
+	return body["message"]
+}
+
+func TestParam(t *testing.T) {
+	id, _ := microsharduuid.Generate(12)
+
+	e := echo.New()
+	e.GET("/orders/:id", func(c echo.Context) error {
+		got, err := Param(c, "id")
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, got.String())
+	})
+
+	if rec := do(e, "/orders/"+id.String()); rec.Code != http.StatusOK || rec.Body.String() != id.String() {
+		t.Errorf("Valid ID: got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "/orders/"+id.Base32()); rec.Body.String() != id.String() {
+		t.Errorf("Base32 ID: got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec := do(e, "/orders/not-an-id")
+	if rec.Code != http.StatusBadRequest || !strings.HasPrefix(errorMessage(t, rec), "invalid id:") {
+		t.Errorf("Invalid ID: got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestQuery(t *testing.T) {
+	id, _ := microsharduuid.Generate(12)
+
+	e := echo.New()
+	e.GET("/search", func(c echo.Context) error {
+		got, err := Query(c, "after")
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, got.String())
+	})
+
+	if rec := do(e, "/search?after="+id.String()); rec.Body.String() != id.String() {
+		t.Errorf("Valid query: got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "/search"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Missing query: got %d", rec.Code)
+	}
+}
+
+func TestBind(t *testing.T) {
+	id, _ := microsharduuid.Generate(12)
+
+	e := echo.New()
+	e.GET("/orders/:id", func(c echo.Context) error {
+		var req struct {
+			ID microsharduuid.MicroShardUUID `param:"id"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, req.ID.String())
+	})
+
+	if rec := do(e, "/orders/"+id.String()); rec.Body.String() != id.String() {
+		t.Errorf("Bound ID: got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "/orders/garbage"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Invalid bound ID: got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestParams(t *testing.T) {
+	tenant, _ := microsharduuid.Generate(1)
+	order, _ := microsharduuid.Generate(2)
+
+	e := echo.New()
+	e.GET("/tenants/:tenant/orders/:order", func(c echo.Context) error {
+		tid, _ := Get(c, "tenant")
+		oid, _ := Get(c, "order")
+		return c.String(http.StatusOK, tid.String()+" "+oid.String())
+	}, Params("tenant", "order"))
+
+	rec := do(e, "/tenants/"+tenant.String()+"/orders/"+order.String())
+	if rec.Body.String() != tenant.String()+" "+order.String() {
+		t.Errorf("Unexpected body %q", rec.Body.String())
+	}
+	rec = do(e, "/tenants/bad/orders/"+order.String())
+	if rec.Code != http.StatusBadRequest || !strings.HasPrefix(errorMessage(t, rec), "invalid tenant:") {
+		t.Errorf("Invalid first param: got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	gen, _ := microsharduuid.NewGenerator(40)
+	incoming, _ := microsharduuid.Generate(7)
+
+	e := echo.New()
+	e.Use(RequestID(gen))
+	e.GET("/", func(c echo.Context) error {
+		id, _ := requestid.FromContext(c.Request().Context())
+		return c.String(http.StatusOK, id.String())
+	})
+
+	rec := do(e, "/")
+	id, err := microsharduuid.Parse(rec.Body.String())
+	if err != nil || id.ShardID() != 40 || rec.Header().Get(requestid.Header) != id.String() {
+		t.Errorf("Unexpected request ID %q (header %q)", rec.Body.String(), rec.Header().Get(requestid.Header))
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(requestid.Header, incoming.String())
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Body.String() != incoming.String() {
+		t.Errorf("Expected the incoming ID %s, got %q", incoming, rec.Body.String())
+	}
+}
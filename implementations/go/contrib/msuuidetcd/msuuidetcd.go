@@ -0,0 +1,270 @@
+// Package msuuidetcd assigns Shard IDs through etcd leases, so every
+// instance of a dynamic fleet gets a unique shard without manual
+// configuration.
+//
+// Each instance claims one key below a prefix (e.g. "/microshard/shards/0000000042")
+// attached to a lease it renews. When the instance stops or loses contact
+// with etcd, the lease expires, the key disappears, and the shard becomes
+// free for the next instance:
+//
+//	alloc, err := msuuidetcd.New(msuuidetcd.Config{Client: cli, First: 0, Last: 1023})
+//	gen, err := microsharduuid.NewGenerator(0, microsharduuid.WithAllocator(alloc))
+//	defer alloc.Release(context.Background())
+//
+// Once no renewal has succeeded for half the TTL, the Generator stops
+// issuing IDs with microsharduuid.ErrLeaseExpired, before the lease can
+// expire and another instance take the shard over; Generator.Reacquire
+// claims a new one.
+package msuuidetcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Defaults applied by New.
+const (
+	DefaultPrefix = "/microshard/shards/"
+	DefaultTTL    = 10 * time.Second
+)
+
+// maxAttempts bounds the rescans when other instances claim the shards
+// this one picked.
+const maxAttempts = 5
+
+// Config configures an Allocator.
+type Config struct {
+	Client *clientv3.Client
+	Prefix string        // Key prefix (DefaultPrefix if empty)
+	First  uint32        // First Shard ID of the range
+	Last   uint32        // Last Shard ID of the range (inclusive)
+	TTL    time.Duration // Lease TTL, rounded up to whole seconds (DefaultTTL if zero)
+	Owner  string        // Value stored under the key, for operators (host:pid if empty)
+}
+
+// Allocator implements microsharduuid.Allocator with etcd leases. It holds
+// at most one shard at a time and is safe for concurrent use.
+type Allocator struct {
+	cfg   Config
+	lease clientv3.Lease // cfg.Client, replaced in tests
+
+	mu    sync.Mutex
+	claim *claim // nil when no shard is held
+}
+
+// claim is one held shard.
+type claim struct {
+	shard  uint32
+	lease  clientv3.LeaseID
+	cancel context.CancelFunc // Stops the renewals
+	lost   chan struct{}      // Closed when the renewals end
+}
+
+// New validates cfg and creates an Allocator. It fails with
+// microsharduuid.ErrInvalidOption for a nil client or a reversed range.
+func New(cfg Config) (*Allocator, error) {
+	if cfg.Client == nil {
+		return nil, fmt.Errorf("%w: etcd allocator needs a client", microsharduuid.ErrInvalidOption)
+	}
+	if cfg.First > cfg.Last {
+		return nil, fmt.Errorf("%w: shard range %d-%d is reversed", microsharduuid.ErrInvalidOption, cfg.First, cfg.Last)
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = DefaultPrefix
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultTTL
+	}
+	if cfg.Owner == "" {
+		host, _ := os.Hostname()
+		cfg.Owner = host + ":" + strconv.Itoa(os.Getpid())
+	}
+	return &Allocator{cfg: cfg, lease: cfg.Client}, nil
+}
+
+// Key returns the etcd key that records the owner of shardID.
+func (a *Allocator) Key(shardID uint32) string {
+	// Zero-padded, so keys list in shard order
+	return fmt.Sprintf("%s%010d", a.cfg.Prefix, shardID)
+}
+
+// Acquire claims a free shard of the range under a new lease and renews the
+// lease until Release. A shard already held is released first. It
+// fails with an error wrapping microsharduuid.ErrNoShardAvailable when
+// every shard of the range is taken.
+func (a *Allocator) Acquire(ctx context.Context) (uint32, <-chan struct{}, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.release(ctx); err != nil {
+		return 0, nil, err
+	}
+
+	granted := time.Now()
+	lease, err := a.lease.Grant(ctx, int64((a.cfg.TTL+time.Second-1)/time.Second))
+	if err != nil {
+		return 0, nil, fmt.Errorf("msuuidetcd: granting lease: %w", err)
+	}
+
+	shard, err := a.claimFree(ctx, lease.ID)
+	if err != nil {
+		a.revoke(lease.ID)
+		return 0, nil, err
+	}
+
+	// The renewals outlive ctx, which only bounds Acquire itself
+	kaCtx, cancel := context.WithCancel(context.Background())
+	c := &claim{shard: shard, lease: lease.ID, cancel: cancel, lost: make(chan struct{})}
+	go func() {
+		// The server may have raised the TTL to its minimum
+		a.keepAlive(kaCtx, c, time.Duration(lease.TTL)*time.Second, granted)
+		close(c.lost)
+	}()
+	a.claim = c
+	return shard, c.lost, nil
+}
+
+// keepAlive renews the lease of c every sixth of the TTL until ctx is
+// canceled, and returns once no renewal has succeeded for half the TTL.
+//
+// The server counts the TTL from when it received the last renewal, so
+// waiting for the client's own deadline, which counts from the response,
+// would leave a window in which the lease has expired and another instance
+// owns the shard while this one still issues IDs. Counting from when the
+// last successful renewal was sent, half the TTL leaves the other half as
+// margin for slow responses and clock drift.
+func (a *Allocator) keepAlive(ctx context.Context, c *claim, ttl time.Duration, granted time.Time) {
+	ticker := time.NewTicker(ttl / 6)
+	defer ticker.Stop()
+
+	deadline := granted.Add(ttl / 2)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		sent := time.Now()
+		rctx, cancel := context.WithDeadline(ctx, deadline)
+		resp, err := a.lease.KeepAliveOnce(rctx, c.lease)
+		cancel()
+		if errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			return // Expired or revoked
+		}
+		if err == nil && resp.TTL > 0 {
+			deadline = sent.Add(ttl / 2)
+		}
+		if !time.Now().Before(deadline) {
+			return
+		}
+	}
+}
+
+// claimFree creates the key of a free shard under lease, starting from a
+// random shard so concurrent instances rarely race for the same key.
+func (a *Allocator) claimFree(ctx context.Context, lease clientv3.LeaseID) (uint32, error) {
+	size := uint64(a.cfg.Last) - uint64(a.cfg.First) + 1
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		taken, err := a.taken(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if uint64(len(taken)) >= size {
+			return 0, fmt.Errorf("%w: all %d shards of %s are taken", microsharduuid.ErrNoShardAvailable, size, a.cfg.Prefix)
+		}
+
+		start := uint64(rand.Int63n(int64(size)))
+		for i := uint64(0); i < size; i++ {
+			shard := a.cfg.First + uint32((start+i)%size)
+			if taken[shard] {
+				continue
+			}
+			key := a.Key(shard)
+			resp, err := a.cfg.Client.Txn(ctx).
+				If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+				Then(clientv3.OpPut(key, a.cfg.Owner, clientv3.WithLease(lease))).
+				Commit()
+			if err != nil {
+				return 0, fmt.Errorf("msuuidetcd: claiming shard %d: %w", shard, err)
+			}
+			if resp.Succeeded {
+				return shard, nil
+			}
+			// Claimed by another instance since the scan: rescan
+			break
+		}
+	}
+	return 0, fmt.Errorf("%w: lost %d races for a shard of %s", microsharduuid.ErrNoShardAvailable, maxAttempts, a.cfg.Prefix)
+}
+
+// taken lists the claimed shards of the range.
+func (a *Allocator) taken(ctx context.Context) (map[uint32]bool, error) {
+	resp, err := a.cfg.Client.Get(ctx, a.cfg.Prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, fmt.Errorf("msuuidetcd: listing shards: %w", err)
+	}
+	taken := make(map[uint32]bool, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		shard, err := strconv.ParseUint(strings.TrimPrefix(string(kv.Key), a.cfg.Prefix), 10, 32)
+		if err == nil && uint32(shard) >= a.cfg.First && uint32(shard) <= a.cfg.Last {
+			taken[uint32(shard)] = true
+		}
+	}
+	return taken, nil
+}
+
+// Release revokes the lease, which deletes the shard key right away, and
+// closes the channel returned by Acquire. It does nothing if no shard is
+// held.
+func (a *Allocator) Release(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.release(ctx)
+}
+
+func (a *Allocator) release(ctx context.Context) error {
+	c := a.claim
+	if c == nil {
+		return nil
+	}
+	a.claim = nil
+	c.cancel()
+	<-c.lost
+
+	// A lease that already expired has nothing left to revoke
+	if _, err := a.lease.Revoke(ctx, c.lease); err != nil && !errors.Is(err, rpctypes.ErrLeaseNotFound) {
+		return fmt.Errorf("msuuidetcd: revoking lease of shard %d: %w", c.shard, err)
+	}
+	return nil
+}
+
+// revoke drops a lease that never became a claim.
+func (a *Allocator) revoke(lease clientv3.LeaseID) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.TTL)
+	defer cancel()
+	a.lease.Revoke(ctx, lease) // Best effort: it expires after the TTL anyway
+}
+
+// Shard returns the held Shard ID, or false if none is held.
+func (a *Allocator) Shard() (uint32, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.claim == nil {
+		return 0, false
+	}
+	return a.claim.shard, true
+}
@@ -0,0 +1,236 @@
+package msuuidetcd
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// startEtcd runs a single-node embedded etcd and returns a client for it.
+func startEtcd(t *testing.T) *clientv3.Client {
+	t.Helper()
+	cfg := embed.NewConfig()
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	clientURL, _ := url.Parse("http://127.0.0.1:0")
+	peerURL, _ := url.Parse("http://127.0.0.1:0")
+	cfg.ListenClientUrls, cfg.AdvertiseClientUrls = []url.URL{*clientURL}, []url.URL{*clientURL}
+	cfg.ListenPeerUrls, cfg.AdvertisePeerUrls = []url.URL{*peerURL}, []url.URL{*peerURL}
+	cfg.InitialCluster = cfg.Name + "=" + peerURL.String()
+
+	e, err := embed.StartEtcd(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(e.Close)
+	select {
+	case <-e.Server.ReadyNotify():
+	case <-time.After(30 * time.Second):
+		t.Fatal("etcd did not start")
+	}
+
+	cli, err := clientv3.New(clientv3.Config{Endpoints: []string{e.Clients[0].Addr().String()}, DialTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cli.Close() })
+	return cli
+}
+
+// stallingLease hangs renewals while stalled is set, as a partition from
+// etcd would.
+type stallingLease struct {
+	clientv3.Lease
+	stalled atomic.Bool
+}
+
+func (l *stallingLease) KeepAliveOnce(ctx context.Context, id clientv3.LeaseID) (*clientv3.LeaseKeepAliveResponse, error) {
+	if l.stalled.Load() {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return l.Lease.KeepAliveOnce(ctx, id)
+}
+
+func TestNewValidation(t *testing.T) {
+	if _, err := New(Config{}); !errors.Is(err, microsharduuid.ErrInvalidOption) {
+		t.Errorf("A missing client should fail with ErrInvalidOption, got %v", err)
+	}
+	if _, err := New(Config{Client: &clientv3.Client{}, First: 5, Last: 4}); !errors.Is(err, microsharduuid.ErrInvalidOption) {
+		t.Errorf("A reversed range should fail with ErrInvalidOption, got %v", err)
+	}
+}
+
+func TestAcquireUniqueShards(t *testing.T) {
+	cli := startEtcd(t)
+	ctx := context.Background()
+
+	var allocs []*Allocator
+	seen := make(map[uint32]bool)
+	for i := 0; i < 3; i++ {
+		a, err := New(Config{Client: cli, First: 10, Last: 12, TTL: 2 * time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		shard, lost, err := a.Acquire(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shard < 10 || shard > 12 || seen[shard] {
+			t.Fatalf("Shard %d is outside the range or already taken (%v)", shard, seen)
+		}
+		select {
+		case <-lost:
+			t.Fatal("A fresh claim should not be lost")
+		default:
+		}
+		seen[shard] = true
+		allocs = append(allocs, a)
+	}
+
+	extra, _ := New(Config{Client: cli, First: 10, Last: 12})
+	if _, _, err := extra.Acquire(ctx); !errors.Is(err, microsharduuid.ErrNoShardAvailable) {
+		t.Fatalf("A full range should fail with ErrNoShardAvailable, got %v", err)
+	}
+
+	// Releasing frees the shard for the next instance right away
+	freed, _ := allocs[1].Shard()
+	if err := allocs[1].Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := allocs[1].Shard(); ok {
+		t.Error("Shard should report nothing after Release")
+	}
+	shard, _, err := extra.Acquire(ctx)
+	if err != nil || shard != freed {
+		t.Errorf("Expected the released shard %d, got %d (%v)", freed, shard, err)
+	}
+}
+
+func TestGeneratorWithAllocator(t *testing.T) {
+	cli := startEtcd(t)
+	ctx := context.Background()
+
+	a, err := New(Config{Client: cli, Prefix: "/test/", First: 100, Last: 100, TTL: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := microsharduuid.NewGenerator(0, microsharduuid.WithAllocator(a))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := gen.NewID()
+	if err != nil || id.ShardID() != 100 {
+		t.Fatalf("Unexpected ID %v (%v)", id, err)
+	}
+
+	resp, err := cli.Get(ctx, a.Key(100))
+	if err != nil || len(resp.Kvs) != 1 || resp.Kvs[0].Lease == 0 {
+		t.Fatalf("Shard key should exist under a lease: %v (%v)", resp, err)
+	}
+
+	// The keep-alive must outlive the TTL
+	time.Sleep(2500 * time.Millisecond)
+	if _, err := gen.NewID(); err != nil {
+		t.Fatalf("The lease should be kept alive: %v", err)
+	}
+
+	if err := a.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gen.NewID(); !errors.Is(err, microsharduuid.ErrLeaseExpired) {
+		t.Errorf("Generation after Release should fail with ErrLeaseExpired, got %v", err)
+	}
+	if resp, _ := cli.Get(ctx, a.Key(100)); len(resp.Kvs) != 0 {
+		t.Error("Release should delete the shard key")
+	}
+
+	if err := gen.Reacquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gen.NewID(); err != nil {
+		t.Errorf("Reacquire should restore generation: %v", err)
+	}
+}
+
+func TestLostLease(t *testing.T) {
+	cli := startEtcd(t)
+	ctx := context.Background()
+
+	a, _ := New(Config{Client: cli, First: 0, Last: 0, TTL: time.Second})
+	_, lost, err := a.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Revoking the lease behind the allocator's back ends the claim
+	a.mu.Lock()
+	lease := a.claim.lease
+	a.mu.Unlock()
+	if _, err := cli.Revoke(ctx, lease); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-lost:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Losing the lease should close the lost channel")
+	}
+
+	// Release tolerates the missing lease, and the shard can be claimed again
+	if err := a.Release(ctx); err != nil {
+		t.Errorf("Release after a lost lease should succeed, got %v", err)
+	}
+	if _, _, err := a.Acquire(ctx); err != nil {
+		t.Errorf("The shard should be free again: %v", err)
+	}
+}
+
+func TestStalledKeepAlive(t *testing.T) {
+	cli := startEtcd(t)
+	ctx := context.Background()
+
+	a, _ := New(Config{Client: cli, First: 0, Last: 0, TTL: 3 * time.Second})
+	lease := &stallingLease{Lease: cli.Lease}
+	a.lease = lease
+	_, lost, err := a.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Renewals keep the claim past the TTL
+	time.Sleep(4 * time.Second)
+	select {
+	case <-lost:
+		t.Fatal("A renewed claim should not be lost")
+	default:
+	}
+
+	// Without renewals, the claim must end while the server still holds the
+	// lease, so no other instance can own the shard yet
+	lease.stalled.Store(true)
+	select {
+	case <-lost:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Stalled renewals should close the lost channel")
+	}
+	a.mu.Lock()
+	id := a.claim.lease
+	a.mu.Unlock()
+	ttl, err := cli.TimeToLive(ctx, id)
+	if err != nil || ttl.TTL <= 0 {
+		t.Errorf("The claim should be lost before the lease expires, remaining TTL %v (%v)", ttl, err)
+	}
+
+	lease.stalled.Store(false)
+	if err := a.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/dilipvamsi/microshard-uuid/implementations/go/contrib/msuuidfranz
+
+go 1.21
+
+require github.com/dilipvamsi/microshard-uuid/implementations/go v0.0.0-00010101000000-000000000000
+
+require (
+	github.com/klauspost/compress v1.17.8 // indirect
+	github.com/pierrec/lz4/v4 v4.1.21 // indirect
+	github.com/twmb/franz-go v1.17.1
+	github.com/twmb/franz-go/pkg/kmsg v1.8.0 // indirect
+)
+
+replace github.com/dilipvamsi/microshard-uuid/implementations/go => ../..
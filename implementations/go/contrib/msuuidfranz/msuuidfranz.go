@@ -0,0 +1,55 @@
+// Package msuuidfranz provides a franz-go (github.com/twmb/franz-go)
+// partitioner that sends every record of a shard to the same partition, so
+// per-shard events stay in order.
+//
+// Records are keyed with id.ShardKey() or the ID itself (raw bytes or
+// string); the partition is microsharduuid.ShardPartition of the key's
+// shard, i.e. jump consistent hash, so adding partitions moves few shards.
+// Records with any other key fall back to kgo.StickyKeyPartitioner:
+//
+//	client, err := kgo.NewClient(
+//		kgo.SeedBrokers("localhost:9092"),
+//		kgo.RecordPartitioner(msuuidfranz.Partitioner()),
+//	)
+//	client.Produce(ctx, &kgo.Record{Topic: "orders", Key: id.ShardKey(), Value: payload}, nil)
+package msuuidfranz
+
+import (
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Partitioner returns a kgo.Partitioner that partitions records by the
+// shard of their key (see the package documentation).
+func Partitioner() kgo.Partitioner {
+	return partitioner{fallback: kgo.StickyKeyPartitioner(nil)}
+}
+
+type partitioner struct {
+	fallback kgo.Partitioner
+}
+
+func (p partitioner) ForTopic(topic string) kgo.TopicPartitioner {
+	return topicPartitioner{fallback: p.fallback.ForTopic(topic)}
+}
+
+type topicPartitioner struct {
+	fallback kgo.TopicPartitioner
+}
+
+// RequiresConsistency is true for shard keys: a record must wait for its
+// shard's partition rather than being moved to another one.
+func (p topicPartitioner) RequiresConsistency(r *kgo.Record) bool {
+	if _, ok := microsharduuid.ShardFromKey(r.Key); ok {
+		return true
+	}
+	return p.fallback.RequiresConsistency(r)
+}
+
+func (p topicPartitioner) Partition(r *kgo.Record, n int) int {
+	if shard, ok := microsharduuid.ShardFromKey(r.Key); ok {
+		return microsharduuid.ShardPartition(shard, n)
+	}
+	return p.fallback.Partition(r, n)
+}
@@ -0,0 +1,37 @@
+package msuuidfranz
+
+import (
+	"testing"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestPartitionByShard(t *testing.T) {
+	tp := Partitioner().ForTopic("orders")
+
+	a, _ := microsharduuid.Generate(4242)
+	b, _ := microsharduuid.Generate(4242)
+	want := a.Partition(12)
+
+	for _, key := range [][]byte{a.ShardKey(), a.Bytes(), []byte(b.String())} {
+		r := &kgo.Record{Topic: "orders", Key: key}
+		if !tp.RequiresConsistency(r) {
+			t.Errorf("Key %q should require consistency", key)
+		}
+		if got := tp.Partition(r, 12); got != want {
+			t.Errorf("Key %q went to partition %d, expected %d", key, got, want)
+		}
+	}
+}
+
+func TestFallbackForOtherKeys(t *testing.T) {
+	tp := Partitioner().ForTopic("orders")
+	r := &kgo.Record{Topic: "orders", Key: []byte("customer-7")}
+
+	p := tp.Partition(r, 12)
+	if p < 0 || p >= 12 || tp.Partition(r, 12) != p {
+		t.Errorf("Fallback partition %d is not stable", p)
+	}
+}
@@ -0,0 +1,117 @@
+// Package msuuidgin binds MicroShardUUIDs in Gin routes and assigns request
+// IDs.
+//
+// Path and query parameters parse in any encoding accepted by
+// microsharduuid.ParseAny; invalid input aborts the request with
+// 400 Bad Request and a JSON body of the form {"error": "invalid id: ..."}.
+//
+//	r.GET("/orders/:id", func(c *gin.Context) {
+//		id, ok := msuuidgin.Param(c, "id")
+//		if !ok {
+//			return // 400 already sent
+//		}
+//		c.JSON(200, gin.H{"shard": id.ShardID()})
+//	})
+//
+// Struct binding works too, since MicroShardUUID implements Gin's
+// BindUnmarshaler:
+//
+//	var req struct {
+//		ID microsharduuid.MicroShardUUID `uri:"id" binding:"required"`
+//	}
+//	if !msuuidgin.BindURI(c, &req) {
+//		return
+//	}
+package msuuidgin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/requestid"
+)
+
+// contextKeyPrefix namespaces the IDs stored by Params in the gin.Context.
+const contextKeyPrefix = "msuuid.param."
+
+// Param parses the path parameter name. On failure it aborts the request
+// with 400 Bad Request and returns false.
+func Param(c *gin.Context, name string) (microsharduuid.MicroShardUUID, bool) {
+	return parse(c, name, c.Param(name))
+}
+
+// Query parses the query parameter name, with the same error handling as
+// Param. A missing parameter is invalid as well.
+func Query(c *gin.Context, name string) (microsharduuid.MicroShardUUID, bool) {
+	return parse(c, name, c.Query(name))
+}
+
+func parse(c *gin.Context, name, value string) (microsharduuid.MicroShardUUID, bool) {
+	id, err := microsharduuid.ParseAny(value)
+	if err != nil {
+		abort(c, fmt.Errorf("invalid %s: %w", name, err))
+		return microsharduuid.MicroShardUUID{}, false
+	}
+	return id, true
+}
+
+// BindURI binds path parameters into obj (see gin.Context.ShouldBindUri).
+// On failure it aborts the request with 400 Bad Request and returns false.
+func BindURI(c *gin.Context, obj interface{}) bool {
+	if err := c.ShouldBindUri(obj); err != nil {
+		abort(c, err)
+		return false
+	}
+	return true
+}
+
+// Params returns middleware that validates the path parameters names before
+// the handler runs, so routes can rely on Get instead of checking errors.
+func Params(names ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, name := range names {
+			id, ok := Param(c, name)
+			if !ok {
+				return
+			}
+			c.Set(contextKeyPrefix+name, id)
+		}
+		c.Next()
+	}
+}
+
+// Get returns the path parameter name parsed by Params.
+func Get(c *gin.Context, name string) (microsharduuid.MicroShardUUID, bool) {
+	v, ok := c.Get(contextKeyPrefix + name)
+	if !ok {
+		return microsharduuid.MicroShardUUID{}, false
+	}
+	id, ok := v.(microsharduuid.MicroShardUUID)
+	return id, ok
+}
+
+// RequestID returns middleware that assigns a request ID like
+// requestid.Middleware: it reuses a valid incoming X-Request-ID header or
+// generates one from gen (the default Generator if nil), stores it in the
+// request context (see requestid.FromContext), and sets the response header.
+func RequestID(gen *microsharduuid.Generator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		id, err := requestid.Resolve(ctx, gen, c.GetHeader(requestid.Header))
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.Request = c.Request.WithContext(requestid.NewContext(ctx, id))
+		c.Header(requestid.Header, id.String())
+		c.Next()
+	}
+}
+
+func abort(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
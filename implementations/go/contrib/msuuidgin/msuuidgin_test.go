@@ -0,0 +1,136 @@
+package msuuidgin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/requestid"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func do(r *gin.Engine, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+	return rec
+}
+
+func errorBody(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid error body %q: %v", rec.Body.String(), err)
+	}
+	return body["error"]
+}
+
+func TestParam(t *testing.T) {
+	id, _ := microsharduuid.Generate(12)
+
+	r := gin.New()
+	r.GET("/orders/:id", func(c *gin.Context) {
+		got, ok := Param(c, "id")
+		if !ok {
+			return
+		}
+		c.String(http.StatusOK, got.String())
+	})
+
+	if rec := do(r, "/orders/"+id.String()); rec.Code != http.StatusOK || rec.Body.String() != id.String() {
+		t.Errorf("Valid ID: got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := do(r, "/orders/"+id.Base58()); rec.Code != http.StatusOK || rec.Body.String() != id.String() {
+		t.Errorf("Base58 ID: got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec := do(r, "/orders/not-an-id")
+	if rec.Code != http.StatusBadRequest || !strings.HasPrefix(errorBody(t, rec), "invalid id:") {
+		t.Errorf("Invalid ID: got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestQuery(t *testing.T) {
+	id, _ := microsharduuid.Generate(12)
+
+	r := gin.New()
+	r.GET("/search", func(c *gin.Context) {
+		if got, ok := Query(c, "after"); ok {
+			c.String(http.StatusOK, got.String())
+		}
+	})
+
+	if rec := do(r, "/search?after="+id.String()); rec.Body.String() != id.String() {
+		t.Errorf("Valid query: got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := do(r, "/search"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Missing query: got %d", rec.Code)
+	}
+}
+
+func TestBindURI(t *testing.T) {
+	id, _ := microsharduuid.Generate(12)
+
+	r := gin.New()
+	r.GET("/orders/:id", func(c *gin.Context) {
+		var req struct {
+			ID microsharduuid.MicroShardUUID `uri:"id" binding:"required"`
+		}
+		if !BindURI(c, &req) {
+			return
+		}
+		c.String(http.StatusOK, req.ID.String())
+	})
+
+	if rec := do(r, "/orders/"+id.String()); rec.Body.String() != id.String() {
+		t.Errorf("Bound ID: got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := do(r, "/orders/garbage"); rec.Code != http.StatusBadRequest || errorBody(t, rec) == "" {
+		t.Errorf("Invalid bound ID: got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestParams(t *testing.T) {
+	tenant, _ := microsharduuid.Generate(1)
+	order, _ := microsharduuid.Generate(2)
+
+	r := gin.New()
+	r.GET("/tenants/:tenant/orders/:order", Params("tenant", "order"), func(c *gin.Context) {
+		tid, _ := Get(c, "tenant")
+		oid, _ := Get(c, "order")
+		c.String(http.StatusOK, tid.String()+" "+oid.String())
+	})
+
+	rec := do(r, "/tenants/"+tenant.String()+"/orders/"+order.String())
+	if rec.Body.String() != tenant.String()+" "+order.String() {
+		t.Errorf("Unexpected body %q", rec.Body.String())
+	}
+	rec = do(r, "/tenants/"+tenant.String()+"/orders/bad")
+	if rec.Code != http.StatusBadRequest || !strings.HasPrefix(errorBody(t, rec), "invalid order:") {
+		t.Errorf("Invalid second param: got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	gen, _ := microsharduuid.NewGenerator(40)
+
+	r := gin.New()
+	r.Use(RequestID(gen))
+	r.GET("/", func(c *gin.Context) {
+		id, _ := requestid.FromContext(c.Request.Context())
+		c.String(http.StatusOK, id.String())
+	})
+
+	rec := do(r, "/")
+	id, err := microsharduuid.Parse(rec.Body.String())
+	if err != nil || id.ShardID() != 40 || rec.Header().Get(requestid.Header) != id.String() {
+		t.Errorf("Unexpected request ID %q (header %q)", rec.Body.String(), rec.Header().Get(requestid.Header))
+	}
+}
@@ -0,0 +1,9 @@
+module github.com/dilipvamsi/microshard-uuid/implementations/go/contrib/msuuidgofrs
+
+go 1.21
+
+require github.com/dilipvamsi/microshard-uuid/implementations/go v0.0.0-00010101000000-000000000000
+
+require github.com/gofrs/uuid v4.4.0+incompatible
+
+replace github.com/dilipvamsi/microshard-uuid/implementations/go => ../..
@@ -0,0 +1,35 @@
+// Package msuuidgofrs converts between MicroShardUUID and
+// github.com/gofrs/uuid.UUID, for legacy services whose models and
+// database layers are built on the gofrs package.
+//
+// Both types hold the same 16 Big Endian bytes; the conversion into
+// MicroShardUUID also checks that the value really is the version 8
+// MicroShard layout, so a random v4 or a v7 is rejected:
+//
+//	u := msuuidgofrs.ToGofrsUUID(id)
+//	back, err := msuuidgofrs.FromGofrsUUID(u) // err wraps ErrInvalidVersion for non-v8 input
+package msuuidgofrs
+
+import (
+	"encoding/binary"
+
+	"github.com/gofrs/uuid"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// ToGofrsUUID returns id as a uuid.UUID. It never fails: every
+// MicroShardUUID is a valid RFC 9562 version 8 UUID.
+func ToGofrsUUID(id microsharduuid.MicroShardUUID) uuid.UUID {
+	var u uuid.UUID
+	binary.BigEndian.PutUint64(u[0:8], id.High)
+	binary.BigEndian.PutUint64(u[8:16], id.Low)
+	return u
+}
+
+// FromGofrsUUID converts u with microsharduuid.FromArray, returning a
+// *microsharduuid.ParseError wrapping ErrInvalidVersion or
+// ErrInvalidVariant if u is not a MicroShardUUID.
+func FromGofrsUUID(u uuid.UUID) (microsharduuid.MicroShardUUID, error) {
+	return microsharduuid.FromArray(u)
+}
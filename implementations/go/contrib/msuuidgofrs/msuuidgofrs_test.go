@@ -0,0 +1,43 @@
+package msuuidgofrs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestRoundTrip(t *testing.T) {
+	id, _ := microsharduuid.Generate(42)
+
+	u := ToGofrsUUID(id)
+	if u.String() != id.String() {
+		t.Errorf("uuid.UUID %s, expected %s", u, id)
+	}
+	if u.Version() != 8 || u.Variant() != uuid.VariantRFC4122 {
+		t.Errorf("Unexpected version %d / variant %d", u.Version(), u.Variant())
+	}
+
+	back, err := FromGofrsUUID(u)
+	if err != nil || back != id {
+		t.Errorf("FromGofrsUUID = %s, %v", back, err)
+	}
+}
+
+func TestFromGofrsUUIDRejectsOtherVersions(t *testing.T) {
+	if _, err := FromGofrsUUID(uuid.Must(uuid.NewV4())); !errors.Is(err, microsharduuid.ErrInvalidVersion) {
+		t.Errorf("v4: expected ErrInvalidVersion, got %v", err)
+	}
+	if _, err := FromGofrsUUID(uuid.Must(uuid.NewV7())); !errors.Is(err, microsharduuid.ErrInvalidVersion) {
+		t.Errorf("v7: expected ErrInvalidVersion, got %v", err)
+	}
+
+	// Version 8 from another scheme, with the NCS variant
+	other := uuid.Must(uuid.FromString("01234567-89ab-8def-0123-456789abcdef"))
+	var pe *microsharduuid.ParseError
+	if _, err := FromGofrsUUID(other); !errors.As(err, &pe) || !errors.Is(err, microsharduuid.ErrInvalidVariant) {
+		t.Errorf("Foreign v8: expected ErrInvalidVariant, got %v", err)
+	}
+}
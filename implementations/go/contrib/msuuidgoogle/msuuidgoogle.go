@@ -0,0 +1,35 @@
+// Package msuuidgoogle converts between MicroShardUUID and
+// github.com/google/uuid.UUID, for codebases that already pass uuid.UUID
+// around (database drivers, generated clients, shared models).
+//
+// Both types hold the same 16 Big Endian bytes; the conversion into
+// MicroShardUUID also checks that the value really is the version 8
+// MicroShard layout, so a random v4 or a v7 is rejected:
+//
+//	u := msuuidgoogle.ToGoogleUUID(id)
+//	back, err := msuuidgoogle.FromGoogleUUID(u) // err wraps ErrInvalidVersion for non-v8 input
+package msuuidgoogle
+
+import (
+	"encoding/binary"
+
+	"github.com/google/uuid"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// ToGoogleUUID returns id as a uuid.UUID. It never fails: every
+// MicroShardUUID is a valid RFC 9562 version 8 UUID.
+func ToGoogleUUID(id microsharduuid.MicroShardUUID) uuid.UUID {
+	var u uuid.UUID
+	binary.BigEndian.PutUint64(u[0:8], id.High)
+	binary.BigEndian.PutUint64(u[8:16], id.Low)
+	return u
+}
+
+// FromGoogleUUID converts u with microsharduuid.FromArray, returning a
+// *microsharduuid.ParseError wrapping ErrInvalidVersion or
+// ErrInvalidVariant if u is not a MicroShardUUID.
+func FromGoogleUUID(u uuid.UUID) (microsharduuid.MicroShardUUID, error) {
+	return microsharduuid.FromArray(u)
+}
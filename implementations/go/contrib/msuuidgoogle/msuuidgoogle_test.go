@@ -0,0 +1,44 @@
+package msuuidgoogle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestRoundTrip(t *testing.T) {
+	id, _ := microsharduuid.Generate(42)
+
+	u := ToGoogleUUID(id)
+	if u.String() != id.String() {
+		t.Errorf("uuid.UUID %s, expected %s", u, id)
+	}
+	if u.Version() != 8 || u.Variant() != uuid.RFC4122 {
+		t.Errorf("Unexpected version %d / variant %s", u.Version(), u.Variant())
+	}
+
+	back, err := FromGoogleUUID(u)
+	if err != nil || back != id {
+		t.Errorf("FromGoogleUUID = %s, %v", back, err)
+	}
+}
+
+func TestFromGoogleUUIDRejectsOtherVersions(t *testing.T) {
+	if _, err := FromGoogleUUID(uuid.New()); !errors.Is(err, microsharduuid.ErrInvalidVersion) {
+		t.Errorf("v4: expected ErrInvalidVersion, got %v", err)
+	}
+	v7, _ := uuid.NewV7()
+	if _, err := FromGoogleUUID(v7); !errors.Is(err, microsharduuid.ErrInvalidVersion) {
+		t.Errorf("v7: expected ErrInvalidVersion, got %v", err)
+	}
+
+	// Version 8 from another scheme, with the NCS variant
+	other := uuid.MustParse("01234567-89ab-8def-0123-456789abcdef")
+	var pe *microsharduuid.ParseError
+	if _, err := FromGoogleUUID(other); !errors.As(err, &pe) || !errors.Is(err, microsharduuid.ErrInvalidVariant) {
+		t.Errorf("Foreign v8: expected ErrInvalidVariant, got %v", err)
+	}
+}
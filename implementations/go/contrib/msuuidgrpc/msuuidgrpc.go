@@ -0,0 +1,134 @@
+// Package msuuidgrpc propagates MicroShardUUID request IDs through gRPC
+// metadata, for log correlation across a fleet.
+//
+// Server interceptors reuse a valid incoming "x-request-id" or generate a
+// new ID, store it in the context (read it with requestid.FromContext), and
+// return it in the response header metadata. Client interceptors forward the
+// ID found in the outgoing context, or mint one if a Generator is given:
+//
+//	srv := grpc.NewServer(
+//		grpc.UnaryInterceptor(msuuidgrpc.UnaryServerInterceptor(gen)),
+//		grpc.StreamInterceptor(msuuidgrpc.StreamServerInterceptor(gen)),
+//	)
+//
+//	conn, err := grpc.NewClient(target,
+//		grpc.WithUnaryInterceptor(msuuidgrpc.UnaryClientInterceptor(gen)),
+//		grpc.WithStreamInterceptor(msuuidgrpc.StreamClientInterceptor(gen)),
+//	)
+package msuuidgrpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/requestid"
+)
+
+// MetadataKey is the metadata key carrying the request ID (gRPC metadata
+// keys are lowercase).
+var MetadataKey = strings.ToLower(requestid.Header)
+
+// UnaryServerInterceptor assigns a request ID to every unary call, using
+// gen (the default Generator if nil) when the client sent none.
+func UnaryServerInterceptor(gen *microsharduuid.Generator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := serverContext(ctx, gen)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor assigns a request ID to every stream, like
+// UnaryServerInterceptor.
+func StreamServerInterceptor(gen *microsharduuid.Generator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := serverContext(ss.Context(), gen)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// UnaryClientInterceptor sends the request ID of the outgoing context. If
+// there is none and gen is not nil, it generates one and stores it in the
+// context passed on to later interceptors.
+func UnaryClientInterceptor(gen *microsharduuid.Generator) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := clientContext(ctx, gen)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor sends the request ID of the outgoing context, like
+// UnaryClientInterceptor.
+func StreamClientInterceptor(gen *microsharduuid.Generator) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, err := clientContext(ctx, gen)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// serverContext resolves the request ID of an incoming call and echoes it
+// in the response header.
+func serverContext(ctx context.Context, gen *microsharduuid.Generator) (context.Context, error) {
+	var incoming string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(MetadataKey); len(vals) > 0 {
+			incoming = vals[0]
+		}
+	}
+
+	id, err := requestid.Resolve(ctx, gen, incoming)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "request ID: %v", err)
+	}
+	// Best effort: fails only if headers were already sent
+	_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataKey, id.String()))
+	return requestid.NewContext(ctx, id), nil
+}
+
+// clientContext adds the request ID to the outgoing metadata, unless the
+// caller already set one there.
+func clientContext(ctx context.Context, gen *microsharduuid.Generator) (context.Context, error) {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MetadataKey)) > 0 {
+		return ctx, nil
+	}
+
+	id, ok := requestid.FromContext(ctx)
+	if !ok {
+		if gen == nil {
+			return ctx, nil
+		}
+		var err error
+		if id, err = gen.NewIDContext(ctx); err != nil {
+			return nil, err
+		}
+		ctx = requestid.NewContext(ctx, id)
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, id.String()), nil
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,139 @@
+package msuuidgrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+	"github.com/dilipvamsi/microshard-uuid/implementations/go/requestid"
+)
+
+// healthServer records the request ID seen by each handler.
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	seen chan microsharduuid.MicroShardUUID
+}
+
+func (h *healthServer) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	id, _ := requestid.FromContext(ctx)
+	h.seen <- id
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (h *healthServer) Watch(_ *grpc_health_v1.HealthCheckRequest, ss grpc_health_v1.Health_WatchServer) error {
+	id, _ := requestid.FromContext(ss.Context())
+	h.seen <- id
+	return ss.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING})
+}
+
+func setup(t *testing.T, serverGen, clientGen *microsharduuid.Generator) (grpc_health_v1.HealthClient, *healthServer) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(serverGen)),
+		grpc.StreamInterceptor(StreamServerInterceptor(serverGen)),
+	)
+	hs := &healthServer{seen: make(chan microsharduuid.MicroShardUUID, 1)}
+	grpc_health_v1.RegisterHealthServer(srv, hs)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(clientGen)),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(clientGen)),
+	)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return grpc_health_v1.NewHealthClient(conn), hs
+}
+
+func TestUnaryPropagates(t *testing.T) {
+	serverGen, _ := microsharduuid.NewGenerator(1)
+	client, hs := setup(t, serverGen, nil)
+
+	id, _ := microsharduuid.Generate(99)
+	var header metadata.MD
+	ctx := requestid.NewContext(context.Background(), id)
+	if _, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if seen := <-hs.seen; seen != id {
+		t.Errorf("Server saw %s, expected the client's %s", seen, id)
+	}
+	if got := header.Get(MetadataKey); len(got) != 1 || got[0] != id.String() {
+		t.Errorf("Response header carries %v, expected %s", got, id)
+	}
+}
+
+func TestUnaryServerGenerates(t *testing.T) {
+	serverGen, _ := microsharduuid.NewGenerator(1)
+	client, hs := setup(t, serverGen, nil)
+
+	if _, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if seen := <-hs.seen; seen.ShardID() != 1 {
+		t.Errorf("Server should generate an ID on shard 1, got %s", seen)
+	}
+}
+
+func TestClientGenerates(t *testing.T) {
+	serverGen, _ := microsharduuid.NewGenerator(1)
+	clientGen, _ := microsharduuid.NewGenerator(2)
+	client, hs := setup(t, serverGen, clientGen)
+
+	if _, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if seen := <-hs.seen; seen.ShardID() != 2 {
+		t.Errorf("Server should see the client's ID from shard 2, got %s", seen)
+	}
+}
+
+func TestStreamPropagates(t *testing.T) {
+	serverGen, _ := microsharduuid.NewGenerator(1)
+	client, hs := setup(t, serverGen, nil)
+
+	id, _ := microsharduuid.Generate(99)
+	stream, err := client.Watch(requestid.NewContext(context.Background(), id), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+
+	if seen := <-hs.seen; seen != id {
+		t.Errorf("Stream handler saw %s, expected %s", seen, id)
+	}
+	if header, _ := stream.Header(); len(header.Get(MetadataKey)) != 1 {
+		t.Errorf("Stream header lacks the request ID: %v", header)
+	}
+}
+
+func TestExplicitMetadataWins(t *testing.T) {
+	serverGen, _ := microsharduuid.NewGenerator(1)
+	clientGen, _ := microsharduuid.NewGenerator(2)
+	client, hs := setup(t, serverGen, clientGen)
+
+	id, _ := microsharduuid.Generate(50)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), MetadataKey, id.String())
+	if _, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if seen := <-hs.seen; seen != id {
+		t.Errorf("Explicit metadata should be sent unchanged, server saw %s", seen)
+	}
+}
@@ -0,0 +1,90 @@
+// Package msuuidopenapi registers a "msuuid" string format with OpenAPI
+// validators, so request validation rejects malformed IDs before they reach
+// handlers.
+//
+// Declare the format in the spec, optionally with the matching pattern for
+// tools that ignore unknown formats:
+//
+//	OrderID:
+//	  type: string
+//	  format: msuuid
+//	  pattern: '^[0-9a-f]{8}-[0-9a-f]{4}-8[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$'
+//
+// Then register the validator once at startup, before specs are loaded:
+//
+//	msuuidopenapi.RegisterKin()                   // getkin/kin-openapi
+//	msuuidopenapi.RegisterStrfmt(strfmt.Default)  // go-swagger / go-openapi
+package msuuidopenapi
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-openapi/strfmt"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// FormatName is the OpenAPI string format registered by this package.
+const FormatName = "msuuid"
+
+// Pattern is the JSON Schema pattern for the canonical form accepted by
+// microsharduuid.ParseStrict: lowercase hex, Version 8, and Variant 2.
+const Pattern = `^[0-9a-f]{8}-[0-9a-f]{4}-8[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`
+
+// Validate reports whether s is a canonical MicroShardUUID, returning the
+// *microsharduuid.ParseError otherwise.
+func Validate(s string) error {
+	_, err := microsharduuid.ParseStrict(s)
+	return err
+}
+
+// Schema returns a kin-openapi string schema with the msuuid format and
+// Pattern, for specs generated from code.
+func Schema() *openapi3.Schema {
+	s := openapi3.NewStringSchema()
+	s.Format = FormatName
+	s.Pattern = Pattern
+	return s
+}
+
+// RegisterKin defines the msuuid format in kin-openapi. kin-openapi keeps
+// formats in an unguarded global map, so call it during initialization.
+func RegisterKin() {
+	openapi3.DefineStringFormatValidator(FormatName, openapi3.NewCallbackValidator(Validate))
+}
+
+// RegisterStrfmt adds the msuuid format to a go-openapi registry, usually
+// strfmt.Default, which go-swagger generated servers validate against. It
+// reports whether the format was newly added.
+func RegisterStrfmt(reg strfmt.Registry) bool {
+	id := ID{}
+	return reg.Add(FormatName, &id, func(s string) bool { return Validate(s) == nil })
+}
+
+// ID is the strfmt.Format type go-swagger generated models use for msuuid
+// fields. It marshals as the canonical string.
+type ID microsharduuid.MicroShardUUID
+
+// UUID returns the underlying MicroShardUUID.
+func (id ID) UUID() microsharduuid.MicroShardUUID {
+	return microsharduuid.MicroShardUUID(id)
+}
+
+// String returns the canonical form.
+func (id ID) String() string {
+	return id.UUID().String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ID) MarshalText() ([]byte, error) {
+	return id.UUID().AppendText(nil)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler with ParseStrict.
+func (id *ID) UnmarshalText(text []byte) error {
+	u, err := microsharduuid.ParseStrict(string(text))
+	if err != nil {
+		return err
+	}
+	*id = ID(u)
+	return nil
+}
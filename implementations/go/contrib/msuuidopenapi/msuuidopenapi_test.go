@@ -0,0 +1,81 @@
+package msuuidopenapi
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-openapi/strfmt"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestPatternMatchesValidate(t *testing.T) {
+	re := regexp.MustCompile(Pattern)
+	id, _ := microsharduuid.Generate(5)
+	dry, _ := microsharduuid.NewGenerator(5, microsharduuid.WithDryRun())
+	dryID, _ := dry.NewID()
+	v4 := "0190a6f8-1c2d-4e5f-8a9b-0c1d2e3f4a5b"
+
+	cases := []string{id.String(), dryID.String(), strings.ToUpper(id.String()), id.Hex(), v4, ""}
+	for _, s := range cases {
+		if re.MatchString(s) != (Validate(s) == nil) {
+			t.Errorf("Pattern and Validate disagree on %q", s)
+		}
+	}
+	if Validate(id.String()) != nil || Validate(v4) == nil {
+		t.Error("Validate should accept generated IDs and reject v4 UUIDs")
+	}
+}
+
+func TestRegisterKin(t *testing.T) {
+	RegisterKin()
+	schema := Schema()
+	id, _ := microsharduuid.Generate(5)
+
+	if err := schema.VisitJSON(id.String()); err != nil {
+		t.Errorf("Valid ID rejected: %v", err)
+	}
+	// Bypass the pattern, so the rejection comes from the format validator
+	schema.Pattern = ""
+	if err := schema.VisitJSON("not-an-id", openapi3.EnableFormatValidation()); err == nil {
+		t.Error("Malformed ID should fail format validation")
+	}
+}
+
+func TestRegisterStrfmt(t *testing.T) {
+	reg := strfmt.NewFormats()
+	if !RegisterStrfmt(reg) {
+		t.Fatal("Format should be newly added")
+	}
+	id, _ := microsharduuid.Generate(5)
+
+	if !reg.Validates(FormatName, id.String()) || reg.Validates(FormatName, "not-an-id") {
+		t.Error("Registry validation does not match Validate")
+	}
+
+	parsed, err := reg.Parse(FormatName, id.String())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := parsed.(*ID).UUID(); got != id {
+		t.Errorf("Parsed %s, expected %s", got, id)
+	}
+}
+
+func TestIDText(t *testing.T) {
+	id, _ := microsharduuid.Generate(5)
+
+	text, err := ID(id).MarshalText()
+	if err != nil || string(text) != id.String() {
+		t.Fatalf("MarshalText = %q, %v", text, err)
+	}
+	var got ID
+	if err := got.UnmarshalText(text); err != nil || got.UUID() != id {
+		t.Errorf("UnmarshalText = %s, %v", got, err)
+	}
+	if err := got.UnmarshalText([]byte(strings.ToUpper(id.String()))); err == nil {
+		t.Error("Non-canonical text should be rejected")
+	}
+}
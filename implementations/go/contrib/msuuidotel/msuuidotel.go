@@ -0,0 +1,175 @@
+// Package msuuidotel correlates OpenTelemetry traces with the MicroShard
+// UUIDs they minted.
+//
+// Annotate records an "msuuid.generated" event carrying the ID, Shard ID,
+// and embedded timestamp on the span in ctx; SetAttributes attaches the same
+// data as span attributes. Generator wraps a *microsharduuid.Generator and
+// does this for every ID it issues:
+//
+//	gen := msuuidotel.New(base)
+//	id, err := gen.NewID(ctx) // event on the current span
+//
+// With WithSpans, every generation call gets its own child span instead,
+// which makes slow paths (RollbackWait, a blocking entropy source) visible
+// in traces.
+package msuuidotel
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// instrumentationName identifies this package as the tracer name.
+const instrumentationName = "github.com/dilipvamsi/microshard-uuid/implementations/go/contrib/msuuidotel"
+
+// Attribute keys.
+const (
+	KeyID      = attribute.Key("msuuid.id")
+	KeyShardID = attribute.Key("msuuid.shard_id")
+	KeyTime    = attribute.Key("msuuid.time")
+	KeyCount   = attribute.Key("msuuid.count")
+)
+
+// EventGenerated is the name of the span event recorded by Annotate.
+const EventGenerated = "msuuid.generated"
+
+// Attributes returns the ID, Shard ID, and RFC 3339 timestamp of id.
+func Attributes(id microsharduuid.MicroShardUUID) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		KeyID.String(id.String()),
+		KeyShardID.Int64(int64(id.ShardID())),
+		KeyTime.String(id.Time().Format(time.RFC3339Nano)),
+	}
+}
+
+// Annotate records an EventGenerated event for id on the span in ctx. It
+// does nothing if ctx carries no recording span.
+func Annotate(ctx context.Context, id microsharduuid.MicroShardUUID) {
+	span := trace.SpanFromContext(ctx)
+	if span.IsRecording() {
+		span.AddEvent(EventGenerated, trace.WithAttributes(Attributes(id)...))
+	}
+}
+
+// SetAttributes attaches the Attributes of id to the span in ctx, e.g. on
+// the span of a request that created a record with that primary key.
+func SetAttributes(ctx context.Context, id microsharduuid.MicroShardUUID) {
+	span := trace.SpanFromContext(ctx)
+	if span.IsRecording() {
+		span.SetAttributes(Attributes(id)...)
+	}
+}
+
+// Option configures a Generator.
+type Option func(*Generator)
+
+// WithTracerProvider sets the provider of the tracer used by WithSpans
+// (otel.GetTracerProvider() by default).
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(g *Generator) {
+		g.tracer = tp.Tracer(instrumentationName)
+	}
+}
+
+// WithSpans makes every generation call start a child span ("msuuid.NewID"
+// or "msuuid.NewIDs") instead of only adding an event to the current span.
+func WithSpans() Option {
+	return func(g *Generator) {
+		g.spans = true
+	}
+}
+
+// Generator wraps a *microsharduuid.Generator with tracing.
+type Generator struct {
+	gen    *microsharduuid.Generator
+	tracer trace.Tracer
+	spans  bool
+}
+
+// New wraps gen.
+func New(gen *microsharduuid.Generator, opts ...Option) *Generator {
+	g := &Generator{gen: gen}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.tracer == nil {
+		g.tracer = otel.GetTracerProvider().Tracer(instrumentationName)
+	}
+	return g
+}
+
+// Unwrap returns the wrapped Generator.
+func (g *Generator) Unwrap() *microsharduuid.Generator {
+	return g.gen
+}
+
+// NewID generates an ID with gen.NewIDContext and records it on the span in
+// ctx (or on a child span with WithSpans). Errors are recorded as span
+// events; child spans are also marked with an error status.
+func (g *Generator) NewID(ctx context.Context) (microsharduuid.MicroShardUUID, error) {
+	ctx, span := g.start(ctx, "msuuid.NewID")
+	if g.spans {
+		defer span.End()
+	}
+
+	id, err := g.gen.NewIDContext(ctx)
+	if err != nil {
+		g.recordError(span, err)
+		return id, err
+	}
+	if g.spans {
+		span.SetAttributes(Attributes(id)...)
+	} else {
+		Annotate(ctx, id)
+	}
+	return id, nil
+}
+
+// NewIDs generates a batch with gen.NewIDsContext. To keep spans small, only
+// the batch size and the first and last ID are recorded.
+func (g *Generator) NewIDs(ctx context.Context, n int) ([]microsharduuid.MicroShardUUID, error) {
+	ctx, span := g.start(ctx, "msuuid.NewIDs")
+	if g.spans {
+		defer span.End()
+	}
+
+	ids, err := g.gen.NewIDsContext(ctx, n)
+	if err != nil {
+		g.recordError(span, err)
+		return ids, err
+	}
+	if len(ids) > 0 && span.IsRecording() {
+		span.AddEvent(EventGenerated, trace.WithAttributes(
+			KeyCount.Int(len(ids)),
+			KeyShardID.Int64(int64(ids[0].ShardID())),
+			attribute.String("msuuid.first_id", ids[0].String()),
+			attribute.String("msuuid.last_id", ids[len(ids)-1].String()),
+		))
+	}
+	return ids, nil
+}
+
+// start returns the span to record on: a new child span with WithSpans,
+// otherwise the current one.
+func (g *Generator) start(ctx context.Context, name string) (context.Context, trace.Span) {
+	if !g.spans {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return g.tracer.Start(ctx, name, trace.WithAttributes(KeyShardID.Int64(int64(g.gen.ShardID()))))
+}
+
+// recordError records err on span. The status is only set on spans started
+// by g; the caller's own span keeps the status the caller decides on.
+func (g *Generator) recordError(span trace.Span, err error) {
+	span.RecordError(err)
+	if g.spans {
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,109 @@
+package msuuidotel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func newProvider() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	rec := tracetest.NewSpanRecorder()
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)), rec
+}
+
+func attr(kvs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range kvs {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestNewIDAnnotatesCurrentSpan(t *testing.T) {
+	tp, rec := newProvider()
+	base, _ := microsharduuid.NewGenerator(77)
+	gen := New(base)
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+	id, err := gen.NewID(ctx)
+	span.End()
+	if err != nil {
+		t.Fatalf("NewID failed: %v", err)
+	}
+
+	spans := rec.Ended()
+	if len(spans) != 1 || len(spans[0].Events()) != 1 {
+		t.Fatalf("Expected one span with one event, got %d spans", len(spans))
+	}
+	ev := spans[0].Events()[0]
+	if ev.Name != EventGenerated {
+		t.Errorf("Unexpected event %q", ev.Name)
+	}
+	if v, _ := attr(ev.Attributes, KeyID); v.AsString() != id.String() {
+		t.Errorf("Event carries ID %q, expected %s", v.AsString(), id)
+	}
+	if v, _ := attr(ev.Attributes, KeyShardID); v.AsInt64() != 77 {
+		t.Errorf("Event carries shard %d, expected 77", v.AsInt64())
+	}
+}
+
+func TestWithSpans(t *testing.T) {
+	tp, rec := newProvider()
+	base, _ := microsharduuid.NewGenerator(77)
+	gen := New(base, WithTracerProvider(tp), WithSpans())
+
+	id, _ := gen.NewID(context.Background())
+	gen.NewIDs(context.Background(), 3)
+
+	spans := rec.Ended()
+	if len(spans) != 2 || spans[0].Name() != "msuuid.NewID" || spans[1].Name() != "msuuid.NewIDs" {
+		t.Fatalf("Expected NewID and NewIDs spans, got %d", len(spans))
+	}
+	if v, _ := attr(spans[0].Attributes(), KeyID); v.AsString() != id.String() {
+		t.Errorf("Span carries ID %q, expected %s", v.AsString(), id)
+	}
+	if v, _ := attr(spans[1].Events()[0].Attributes, KeyCount); v.AsInt64() != 3 {
+		t.Errorf("Batch event should carry the count, got %d", v.AsInt64())
+	}
+}
+
+func TestErrorsRecorded(t *testing.T) {
+	tp, rec := newProvider()
+	base, _ := microsharduuid.NewGenerator(77)
+	gen := New(base, WithTracerProvider(tp), WithSpans())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := gen.NewID(ctx); err == nil {
+		t.Fatal("Expected an error for a canceled context")
+	}
+
+	span := rec.Ended()[0]
+	if span.Status().Code != codes.Error || len(span.Events()) != 1 || span.Events()[0].Name != "exception" {
+		t.Errorf("Expected an error status and exception event, got %v", span.Status())
+	}
+}
+
+func TestSetAttributes(t *testing.T) {
+	tp, rec := newProvider()
+	id, _ := microsharduuid.Generate(5)
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "insert")
+	SetAttributes(ctx, id)
+	span.End()
+
+	if v, ok := attr(rec.Ended()[0].Attributes(), KeyShardID); !ok || v.AsInt64() != 5 {
+		t.Errorf("Expected shard attribute 5, got %v", v)
+	}
+
+	// No span in the context: a no-op
+	SetAttributes(context.Background(), id)
+	Annotate(context.Background(), id)
+}
@@ -0,0 +1,186 @@
+// Package msuuidprom exports MicroShard UUID instrumentation to Prometheus.
+//
+// Register installs a collector as the process-wide microsharduuid.Metrics
+// sink, so every Generator and parser in the process is instrumented:
+//
+//	if _, err := msuuidprom.Register(prometheus.DefaultRegisterer, msuuidprom.Config{}); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Exported series (with the default "msuuid" namespace):
+//
+//	msuuid_ids_generated_total{shard}       IDs issued per shard
+//	msuuid_generate_errors_total{shard}     Failed generation calls per shard
+//	msuuid_parse_errors_total{format,kind}  Rejected inputs by format and error kind
+//	msuuid_entropy_read_seconds             Entropy source read latency
+//	msuuid_entropy_errors_total             Failed entropy reads
+//	msuuid_clock_rollbacks_total{shard}     Backwards clock steps seen by generators
+//	msuuid_clock_rollback_seconds           Size of backwards clock steps
+//
+// The shard label has one value per Shard ID. Deployments with many
+// tenants should bucket it with Config.ShardLabel to bound cardinality.
+package msuuidprom
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Config customizes the exported metrics.
+type Config struct {
+	Namespace string // Metric name prefix ("msuuid" if empty)
+
+	// ShardLabel maps a Shard ID to its label value (decimal if nil), e.g.
+	// to group tenants into buckets.
+	ShardLabel func(shardID uint32) string
+}
+
+// Metrics implements microsharduuid.Metrics and prometheus.Collector.
+type Metrics struct {
+	shardLabel func(uint32) string
+
+	generated     *prometheus.CounterVec
+	generateErrs  *prometheus.CounterVec
+	parseErrs     *prometheus.CounterVec
+	entropyRead   prometheus.Histogram
+	entropyErrs   prometheus.Counter
+	rollbacks     *prometheus.CounterVec
+	rollbackDrift prometheus.Histogram
+
+	// Per-shard counters, cached so the hot path skips label hashing
+	generatedByShard sync.Map // uint32 -> prometheus.Counter
+}
+
+var _ microsharduuid.Metrics = (*Metrics)(nil)
+
+// New creates the collectors without registering them.
+func New(cfg Config) *Metrics {
+	ns := cfg.Namespace
+	if ns == "" {
+		ns = "msuuid"
+	}
+	shardLabel := cfg.ShardLabel
+	if shardLabel == nil {
+		shardLabel = func(shardID uint32) string {
+			return strconv.FormatUint(uint64(shardID), 10)
+		}
+	}
+
+	return &Metrics{
+		shardLabel: shardLabel,
+		generated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "ids_generated_total", Help: "MicroShard UUIDs generated, by shard.",
+		}, []string{"shard"}),
+		generateErrs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "generate_errors_total", Help: "Failed MicroShard UUID generation calls, by shard.",
+		}, []string{"shard"}),
+		parseErrs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "parse_errors_total", Help: "Rejected MicroShard UUID inputs, by format and error kind.",
+		}, []string{"format", "kind"}),
+		entropyRead: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns, Name: "entropy_read_seconds", Help: "Latency of entropy source reads.",
+			Buckets: prometheus.ExponentialBuckets(1e-6, 4, 10), // 1µs to ~262ms
+		}),
+		entropyErrs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: ns, Name: "entropy_errors_total", Help: "Failed entropy source reads.",
+		}),
+		rollbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "clock_rollbacks_total", Help: "Backwards clock steps seen by generators, by shard.",
+		}, []string{"shard"}),
+		rollbackDrift: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns, Name: "clock_rollback_seconds", Help: "Size of backwards clock steps.",
+			Buckets: prometheus.ExponentialBuckets(1e-6, 10, 8), // 1µs to 10s
+		}),
+	}
+}
+
+// Register creates the collectors, registers them with reg, and installs
+// them with microsharduuid.SetMetrics.
+func Register(reg prometheus.Registerer, cfg Config) (*Metrics, error) {
+	m := New(cfg)
+	if err := reg.Register(m); err != nil {
+		return nil, err
+	}
+	microsharduuid.SetMetrics(m)
+	return m, nil
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.generated, m.generateErrs, m.parseErrs, m.entropyRead, m.entropyErrs, m.rollbacks, m.rollbackDrift}
+}
+
+// IDsGenerated implements microsharduuid.Metrics.
+func (m *Metrics) IDsGenerated(shardID uint32, n int) {
+	c, ok := m.generatedByShard.Load(shardID)
+	if !ok {
+		c, _ = m.generatedByShard.LoadOrStore(shardID, m.generated.WithLabelValues(m.shardLabel(shardID)))
+	}
+	c.(prometheus.Counter).Add(float64(n))
+}
+
+// GenerateFailed implements microsharduuid.Metrics.
+func (m *Metrics) GenerateFailed(shardID uint32, err error) {
+	m.generateErrs.WithLabelValues(m.shardLabel(shardID)).Inc()
+}
+
+// ParseFailed implements microsharduuid.Metrics.
+func (m *Metrics) ParseFailed(err *microsharduuid.ParseError) {
+	m.parseErrs.WithLabelValues(err.Format, Kind(err)).Inc()
+}
+
+// EntropyRead implements microsharduuid.Metrics.
+func (m *Metrics) EntropyRead(d time.Duration, err error) {
+	m.entropyRead.Observe(d.Seconds())
+	if err != nil {
+		m.entropyErrs.Inc()
+	}
+}
+
+// ClockRollback implements microsharduuid.Metrics.
+func (m *Metrics) ClockRollback(shardID uint32, drift time.Duration) {
+	m.rollbacks.WithLabelValues(m.shardLabel(shardID)).Inc()
+	m.rollbackDrift.Observe(drift.Seconds())
+}
+
+// parseKinds maps sentinel parse errors to their "kind" label.
+var parseKinds = []struct {
+	err  error
+	kind string
+}{
+	{microsharduuid.ErrInvalidLength, "length"},
+	{microsharduuid.ErrInvalidHex, "hex"},
+	{microsharduuid.ErrInvalidEncoding, "encoding"},
+	{microsharduuid.ErrInvalidVersion, "version"},
+	{microsharduuid.ErrInvalidVariant, "variant"},
+}
+
+// Kind returns the "kind" label of a parse error: length, hex, encoding,
+// version, variant, or other.
+func Kind(err error) string {
+	for _, k := range parseKinds {
+		if errors.Is(err, k.err) {
+			return k.kind
+		}
+	}
+	return "other"
+}
@@ -0,0 +1,98 @@
+package msuuidprom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func register(t *testing.T, cfg Config) *Metrics {
+	t.Helper()
+	m, err := Register(prometheus.NewRegistry(), cfg)
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	t.Cleanup(func() { microsharduuid.SetMetrics(nil) })
+	return m
+}
+
+func TestGeneratedPerShard(t *testing.T) {
+	m := register(t, Config{})
+
+	gen, _ := microsharduuid.NewGenerator(42)
+	gen.NewID()
+	gen.NewIDs(9)
+	microsharduuid.Generate(7)
+
+	if got := testutil.ToFloat64(m.generated.WithLabelValues("42")); got != 10 {
+		t.Errorf("Expected 10 IDs for shard 42, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.generated.WithLabelValues("7")); got != 1 {
+		t.Errorf("Expected 1 ID for shard 7, got %v", got)
+	}
+	if testutil.CollectAndCount(m.entropyRead) != 1 {
+		t.Error("Entropy histogram should be exported")
+	}
+}
+
+func TestShardLabel(t *testing.T) {
+	m := register(t, Config{ShardLabel: func(uint32) string { return "all" }})
+
+	microsharduuid.Generate(1)
+	microsharduuid.Generate(2)
+
+	if got := testutil.ToFloat64(m.generated.WithLabelValues("all")); got != 2 {
+		t.Errorf("Expected both shards under one label, got %v", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	m := register(t, Config{})
+
+	microsharduuid.Parse("short")
+	microsharduuid.Parse("zzzzzzzz-zzzz-zzzz-zzzz-zzzzzzzzzzzz")
+	microsharduuid.ParseBase32("short")
+
+	if got := testutil.ToFloat64(m.parseErrs.WithLabelValues(microsharduuid.FormatCanonical, "length")); got != 1 {
+		t.Errorf("Expected 1 canonical length error, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.parseErrs.WithLabelValues(microsharduuid.FormatCanonical, "hex")); got != 1 {
+		t.Errorf("Expected 1 canonical hex error, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.parseErrs.WithLabelValues(microsharduuid.FormatBase32, "length")); got != 1 {
+		t.Errorf("Expected 1 Base32 length error, got %v", got)
+	}
+}
+
+func TestClockRollbacks(t *testing.T) {
+	m := register(t, Config{})
+
+	now := time.Now()
+	gen, _ := microsharduuid.NewGenerator(5,
+		microsharduuid.WithRollbackPolicy(microsharduuid.RollbackError),
+		microsharduuid.WithClock(microsharduuid.ClockFunc(func() time.Time { return now })))
+	gen.NewID()
+	now = now.Add(-time.Millisecond)
+	gen.NewID()
+
+	if got := testutil.ToFloat64(m.rollbacks.WithLabelValues("5")); got != 1 {
+		t.Errorf("Expected 1 rollback, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.generateErrs.WithLabelValues("5")); got != 1 {
+		t.Errorf("Expected the rejected ID to count as an error, got %v", got)
+	}
+}
+
+func TestKind(t *testing.T) {
+	_, err := microsharduuid.Parse("short")
+	if Kind(err) != "length" {
+		t.Errorf("Expected kind length, got %q", Kind(err))
+	}
+	if Kind(nil) != "other" {
+		t.Errorf("Expected kind other, got %q", Kind(nil))
+	}
+}
@@ -0,0 +1,70 @@
+// Package msuuidsarama provides a Sarama (github.com/IBM/sarama) partitioner
+// that sends every message of a shard to the same partition, so per-shard
+// events stay in order.
+//
+// Messages are keyed with id.ShardKey() or the ID itself (raw bytes or
+// string); the partition is microsharduuid.ShardPartition of the key's
+// shard, i.e. jump consistent hash, so adding partitions moves few shards.
+// Messages with any other key fall back to sarama.NewHashPartitioner:
+//
+//	cfg := sarama.NewConfig()
+//	cfg.Producer.Partitioner = msuuidsarama.NewPartitioner
+//	producer.SendMessage(&sarama.ProducerMessage{
+//		Topic: "orders",
+//		Key:   sarama.ByteEncoder(id.ShardKey()),
+//		Value: sarama.ByteEncoder(payload),
+//	})
+package msuuidsarama
+
+import (
+	"github.com/IBM/sarama"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// NewPartitioner is a sarama.PartitionerConstructor for a partitioner that
+// partitions messages by the shard of their key (see the package
+// documentation).
+func NewPartitioner(topic string) sarama.Partitioner {
+	return &partitioner{fallback: sarama.NewHashPartitioner(topic)}
+}
+
+type partitioner struct {
+	fallback sarama.Partitioner
+}
+
+func (p *partitioner) Partition(msg *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	shard, ok, err := shardOf(msg)
+	if err != nil {
+		return -1, err
+	}
+	if ok {
+		return int32(microsharduuid.ShardPartition(shard, int(numPartitions))), nil
+	}
+	return p.fallback.Partition(msg, numPartitions)
+}
+
+// RequiresConsistency is true: messages must wait for their partition
+// rather than being moved to another one.
+func (p *partitioner) RequiresConsistency() bool {
+	return true
+}
+
+// MessageRequiresConsistency implements sarama.DynamicConsistencyPartitioner,
+// so messages without a key may still go to any available partition.
+func (p *partitioner) MessageRequiresConsistency(msg *sarama.ProducerMessage) bool {
+	return msg.Key != nil
+}
+
+// shardOf decodes the Shard ID from the message key, if it holds one.
+func shardOf(msg *sarama.ProducerMessage) (uint32, bool, error) {
+	if msg.Key == nil {
+		return 0, false, nil
+	}
+	key, err := msg.Key.Encode()
+	if err != nil {
+		return 0, false, err
+	}
+	shard, ok := microsharduuid.ShardFromKey(key)
+	return shard, ok, nil
+}
@@ -0,0 +1,48 @@
+package msuuidsarama
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestPartitionByShard(t *testing.T) {
+	p := NewPartitioner("orders")
+
+	a, _ := microsharduuid.Generate(4242)
+	b, _ := microsharduuid.Generate(4242)
+	want := int32(a.Partition(12))
+
+	keys := []sarama.Encoder{
+		sarama.ByteEncoder(a.ShardKey()),
+		sarama.ByteEncoder(a.Bytes()),
+		sarama.StringEncoder(b.String()),
+	}
+	for _, key := range keys {
+		got, err := p.Partition(&sarama.ProducerMessage{Topic: "orders", Key: key}, 12)
+		if err != nil || got != want {
+			t.Errorf("Key %v went to partition %d (%v), expected %d", key, got, err, want)
+		}
+	}
+	if !p.RequiresConsistency() {
+		t.Error("Partitioner should require consistency")
+	}
+}
+
+func TestFallbackForOtherKeys(t *testing.T) {
+	p := NewPartitioner("orders")
+	msg := &sarama.ProducerMessage{Topic: "orders", Key: sarama.StringEncoder("customer-7")}
+
+	got, err := p.Partition(msg, 12)
+	again, _ := p.Partition(msg, 12)
+	if err != nil || got < 0 || got >= 12 || got != again {
+		t.Errorf("Fallback partition %d (%v) is not stable", got, err)
+	}
+
+	dyn := p.(sarama.DynamicConsistencyPartitioner)
+	if dyn.MessageRequiresConsistency(&sarama.ProducerMessage{Topic: "orders"}) {
+		t.Error("Messages without a key should not require consistency")
+	}
+}
@@ -0,0 +1,90 @@
+// Package msuuidvalidator adds MicroShardUUID rules to go-playground/validator
+// struct tags:
+//
+//	type Order struct {
+//		ID     string                        `validate:"msuuid"`
+//		Parent microsharduuid.MicroShardUUID `validate:"msuuid,msuuid_shard=3 7"`
+//	}
+//
+//	v := validator.New()
+//	if err := msuuidvalidator.Register(v); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// The rules apply to string and MicroShardUUID fields (and pointers to
+// them). For Gin, register on its engine:
+//
+//	v, _ := binding.Validator.Engine().(*validator.Validate)
+//	msuuidvalidator.Register(v)
+package msuuidvalidator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Tag names registered by Register.
+const (
+	Tag      = "msuuid"       // Field is a valid MicroShardUUID
+	ShardTag = "msuuid_shard" // Field is a valid MicroShardUUID from one of the space-separated shards
+)
+
+// Register adds the msuuid and msuuid_shard rules to v.
+func Register(v *validator.Validate) error {
+	if err := v.RegisterValidation(Tag, ValidateID); err != nil {
+		return err
+	}
+	return v.RegisterValidation(ShardTag, ValidateShard)
+}
+
+// ValidateID is the validator.Func behind the msuuid tag. Strings are
+// checked with microsharduuid.Parse; MicroShardUUID values must carry
+// Version 8 and a known variant, so the zero value fails.
+func ValidateID(fl validator.FieldLevel) bool {
+	_, ok := fieldID(fl)
+	return ok
+}
+
+// ValidateShard is the validator.Func behind the msuuid_shard tag. Like the
+// built-in rules, it panics on a malformed parameter, since that is a bug in
+// the struct tag rather than in the input.
+func ValidateShard(fl validator.FieldLevel) bool {
+	id, ok := fieldID(fl)
+	if !ok {
+		return false
+	}
+	for _, p := range strings.Fields(fl.Param()) {
+		shard, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			panic(fmt.Sprintf("msuuidvalidator: bad %s parameter %q on field %s", ShardTag, fl.Param(), fl.StructFieldName()))
+		}
+		if id.ShardID() == uint32(shard) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldID decodes the field under validation.
+func fieldID(fl validator.FieldLevel) (microsharduuid.MicroShardUUID, bool) {
+	field := fl.Field()
+	if !field.CanInterface() {
+		return microsharduuid.MicroShardUUID{}, false
+	}
+	switch v := field.Interface().(type) {
+	case string:
+		id, err := microsharduuid.Parse(v)
+		return id, err == nil
+	case microsharduuid.MicroShardUUID:
+		ok := uint64(v.VersionField()) == microsharduuid.Version &&
+			uint64(v.VariantField()) == microsharduuid.Variant
+		return v, ok
+	default:
+		return microsharduuid.MicroShardUUID{}, false
+	}
+}
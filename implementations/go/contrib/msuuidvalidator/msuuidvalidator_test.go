@@ -0,0 +1,82 @@
+package msuuidvalidator
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func newValidate(t *testing.T) *validator.Validate {
+	t.Helper()
+	v := validator.New()
+	if err := Register(v); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	return v
+}
+
+func TestIDRule(t *testing.T) {
+	v := newValidate(t)
+	id, _ := microsharduuid.Generate(3)
+
+	type request struct {
+		ID     string                         `validate:"msuuid"`
+		Parent microsharduuid.MicroShardUUID  `validate:"msuuid"`
+		Ref    *microsharduuid.MicroShardUUID `validate:"omitempty,msuuid"`
+	}
+
+	if err := v.Struct(request{ID: id.String(), Parent: id, Ref: &id}); err != nil {
+		t.Errorf("Valid IDs rejected: %v", err)
+	}
+	if err := v.Struct(request{ID: id.Base58(), Parent: id}); err == nil {
+		t.Error("Base58 is not a form accepted by Parse")
+	}
+	if err := v.Struct(request{ID: "0190a6f8-1c2d-4e5f-8a9b-0c1d2e3f4a5b", Parent: id}); err == nil {
+		t.Error("UUIDv4 string should fail")
+	}
+	if err := v.Struct(request{ID: id.String()}); err == nil {
+		t.Error("Zero MicroShardUUID should fail")
+	}
+	if err := v.Var(42, Tag); err == nil {
+		t.Error("Unsupported field types should fail")
+	}
+}
+
+func TestShardRule(t *testing.T) {
+	v := newValidate(t)
+	in3, _ := microsharduuid.Generate(3)
+	in7, _ := microsharduuid.Generate(7)
+	in9, _ := microsharduuid.Generate(9)
+
+	type request struct {
+		ID microsharduuid.MicroShardUUID `validate:"msuuid_shard=3 7"`
+	}
+	for _, id := range []microsharduuid.MicroShardUUID{in3, in7} {
+		if err := v.Struct(request{ID: id}); err != nil {
+			t.Errorf("Shard %d rejected: %v", id.ShardID(), err)
+		}
+	}
+	if err := v.Struct(request{ID: in9}); err == nil {
+		t.Error("Shard 9 should fail")
+	}
+	if err := v.Var(in3.String(), "msuuid_shard=3"); err != nil {
+		t.Errorf("String field rejected: %v", err)
+	}
+	if err := v.Var("garbage", "msuuid_shard=3"); err == nil {
+		t.Error("Malformed string should fail")
+	}
+}
+
+func TestShardRuleBadParam(t *testing.T) {
+	v := newValidate(t)
+	id, _ := microsharduuid.Generate(3)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a malformed parameter")
+		}
+	}()
+	_ = v.Var(id.String(), "msuuid_shard=abc")
+}
@@ -0,0 +1,12 @@
+module github.com/dilipvamsi/microshard-uuid/implementations/go/contrib/msuuidwatch
+
+go 1.21
+
+require (
+	github.com/dilipvamsi/microshard-uuid/implementations/go v0.0.0-00010101000000-000000000000
+	github.com/fsnotify/fsnotify v1.7.0
+)
+
+require golang.org/x/sys v0.4.0 // indirect
+
+replace github.com/dilipvamsi/microshard-uuid/implementations/go => ../..
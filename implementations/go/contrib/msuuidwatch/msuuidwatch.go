@@ -0,0 +1,139 @@
+// Package msuuidwatch hot-reloads Generator configuration from a file using fsnotify.
+//
+// The watcher observes the file's directory rather than the file itself, so it
+// keeps working when editors or Kubernetes ConfigMaps replace the file via
+// rename or symlink swap.
+package msuuidwatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Loader converts the contents of a config file into Generator options.
+type Loader func(data []byte) ([]microsharduuid.Option, error)
+
+// Config is the JSON document understood by JSONLoader. Omitted fields keep
+// their current value.
+//
+//	{"shard_id": 12, "global_monotonic": true}
+type Config struct {
+	ShardID         *uint32 `json:"shard_id,omitempty"`
+	GlobalMonotonic *bool   `json:"global_monotonic,omitempty"`
+}
+
+// Options converts the config into Generator options.
+func (c Config) Options() []microsharduuid.Option {
+	var opts []microsharduuid.Option
+	if c.ShardID != nil {
+		opts = append(opts, microsharduuid.WithShardID(*c.ShardID))
+	}
+	if c.GlobalMonotonic != nil {
+		if *c.GlobalMonotonic {
+			opts = append(opts, microsharduuid.WithGlobalMonotonic())
+		} else {
+			opts = append(opts, microsharduuid.WithoutGlobalMonotonic())
+		}
+	}
+	return opts
+}
+
+// JSONLoader decodes a Config document.
+func JSONLoader(data []byte) ([]microsharduuid.Option, error) {
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("msuuidwatch: %w", err)
+	}
+	return c.Options(), nil
+}
+
+// Watcher reloads a config file into a Generator whenever it changes.
+type Watcher struct {
+	Path      string                    // Config file
+	Generator *microsharduuid.Generator // Generator to reconfigure
+	Loader    Loader                    // JSONLoader if nil
+	OnReload  func()                    // Called after each successful reload (optional)
+	OnError   func(err error)           // Called for unreadable or invalid configs (optional)
+}
+
+// Load reads the file once and applies it. Call it at startup to fail fast.
+func (w *Watcher) Load() error {
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		return err
+	}
+
+	loader := w.Loader
+	if loader == nil {
+		loader = JSONLoader
+	}
+	opts, err := loader(data)
+	if err != nil {
+		return err
+	}
+
+	// All options land in a single atomic swap
+	if err := w.Generator.Reconfigure(opts...); err != nil {
+		return err
+	}
+	if w.OnReload != nil {
+		w.OnReload()
+	}
+	return nil
+}
+
+// Run watches the file until ctx is canceled. Invalid configs are reported
+// through OnError and leave the current configuration untouched.
+func (w *Watcher) Run(ctx context.Context) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fw.Close()
+
+	if err := fw.Add(filepath.Dir(w.Path)); err != nil {
+		return err
+	}
+	target := filepath.Clean(w.Path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case ev, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(ev.Name) != target && !isConfigMapSwap(ev.Name) {
+				continue
+			}
+			if !ev.Has(fsnotify.Write) && !ev.Has(fsnotify.Create) && !ev.Has(fsnotify.Rename) {
+				continue
+			}
+			if err := w.Load(); err != nil && w.OnError != nil {
+				w.OnError(err)
+			}
+
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+			if w.OnError != nil {
+				w.OnError(err)
+			}
+		}
+	}
+}
+
+// isConfigMapSwap reports the "..data" symlink Kubernetes swaps on ConfigMap updates.
+func isConfigMapSwap(name string) bool {
+	return filepath.Base(name) == "..data"
+}
@@ -0,0 +1,74 @@
+package msuuidwatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestWatcherReloadsOnWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "msuuid.json")
+	if err := os.WriteFile(path, []byte(`{"shard_id": 1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	gen, _ := microsharduuid.NewGenerator(0)
+	reloads := make(chan struct{}, 10)
+	errs := make(chan error, 10)
+	w := &Watcher{
+		Path:      path,
+		Generator: gen,
+		OnReload:  func() { reloads <- struct{}{} },
+		OnError:   func(err error) { errs <- err },
+	}
+
+	if err := w.Load(); err != nil {
+		t.Fatalf("Initial load failed: %v", err)
+	}
+	<-reloads
+	if gen.ShardID() != 1 {
+		t.Fatalf("Expected shard 1, got %d", gen.ShardID())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+	time.Sleep(50 * time.Millisecond) // Let the watch register
+
+	os.WriteFile(path, []byte(`{"shard_id": 9}`), 0o644)
+	select {
+	case <-reloads:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watcher did not reload after the file changed")
+	}
+	if gen.ShardID() != 9 {
+		t.Errorf("Expected shard 9 after reload, got %d", gen.ShardID())
+	}
+
+	// Broken configs are reported and ignored
+	os.WriteFile(path, []byte(`{"shard_id":`), 0o644)
+	select {
+	case <-errs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watcher did not report the invalid config")
+	}
+	if gen.ShardID() != 9 {
+		t.Errorf("Invalid config changed the shard to %d", gen.ShardID())
+	}
+}
+
+func TestJSONLoader(t *testing.T) {
+	opts, err := JSONLoader([]byte(`{"shard_id": 4, "global_monotonic": true}`))
+	if err != nil || len(opts) != 2 {
+		t.Fatalf("Expected 2 options, got %d (%v)", len(opts), err)
+	}
+
+	opts, err = JSONLoader([]byte(`{}`))
+	if err != nil || len(opts) != 0 {
+		t.Errorf("Empty config should produce no options, got %d (%v)", len(opts), err)
+	}
+}
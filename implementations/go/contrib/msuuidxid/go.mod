@@ -0,0 +1,9 @@
+module github.com/dilipvamsi/microshard-uuid/implementations/go/contrib/msuuidxid
+
+go 1.21
+
+require github.com/dilipvamsi/microshard-uuid/implementations/go v0.0.0-00010101000000-000000000000
+
+require github.com/rs/xid v1.6.0
+
+replace github.com/dilipvamsi/microshard-uuid/implementations/go => ../..
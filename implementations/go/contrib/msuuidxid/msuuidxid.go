@@ -0,0 +1,55 @@
+// Package msuuidxid converts between MicroShardUUID and github.com/rs/xid.ID,
+// so log pipelines keyed by xid can be joined against MicroShardUUID-keyed
+// data on time.
+//
+// An xid has 96 bits: a 32-bit Unix timestamp in seconds followed by 64
+// bits of machine ID, process ID, and counter. ToXID fills them as
+//
+//	[seconds 32] [sub-second micros 20] [Shard ID 32] [low 12 random bits]
+//
+// so xids keep the time order of the IDs they came from. Converting back
+// keeps the time to the microsecond, but only 12 of the 36 random bits
+// survive; FromXID draws the rest from the xid's counter bits:
+//
+//	x, err := msuuidxid.ToXID(id)
+//	back, err := msuuidxid.FromXID(x, id.ShardID()) // same time and shard as id
+package msuuidxid
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/rs/xid"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// ToXID converts id into an xid.ID (see the package documentation for the
+// layout). It fails with microsharduuid.ErrUnrepresentable after
+// 2106-02-07, when the 32-bit seconds field overflows.
+func ToXID(id microsharduuid.MicroShardUUID) (xid.ID, error) {
+	var x xid.ID
+	micros := uint64(id.UnixMicro())
+	secs := micros / 1e6
+	if secs > 0xFFFFFFFF {
+		return x, fmt.Errorf("%w: %s is outside the xid time range", microsharduuid.ErrUnrepresentable, id.ISOTime())
+	}
+
+	binary.BigEndian.PutUint32(x[0:4], uint32(secs))
+	binary.BigEndian.PutUint64(x[4:12], (micros%1e6)<<44|uint64(id.ShardID())<<12|id.Random()&0xFFF)
+	return x, nil
+}
+
+// FromXID converts x into a MicroShardUUID for shardID. The seconds are
+// kept; the next 20 bits are read as sub-second micros (as written by ToXID,
+// clamped to 999999 for other xids, so time order holds); and the low 36
+// bits (the counter and part of the process ID) become the random bits.
+func FromXID(x xid.ID, shardID uint32) (microsharduuid.MicroShardUUID, error) {
+	secs := uint64(binary.BigEndian.Uint32(x[0:4]))
+	rest := binary.BigEndian.Uint64(x[4:12])
+	sub := rest >> 44
+	if sub > 999999 {
+		sub = 999999
+	}
+	return microsharduuid.FromParts(secs*1e6+sub, shardID, rest&microsharduuid.MaxRandom)
+}
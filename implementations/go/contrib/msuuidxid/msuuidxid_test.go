@@ -0,0 +1,55 @@
+package msuuidxid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestRoundTrip(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 999999000, time.UTC)
+	id, _ := microsharduuid.FromTime(ts, microsharduuid.MaxShardID)
+
+	x, err := ToXID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !x.Time().Equal(ts.Truncate(time.Second)) {
+		t.Errorf("xid time %s, expected %s", x.Time(), ts.Truncate(time.Second))
+	}
+
+	back, err := FromXID(x, id.ShardID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.UnixMicro() != id.UnixMicro() || back.ShardID() != id.ShardID() || back.Random()&0xFFF != id.Random()&0xFFF {
+		t.Errorf("FromXID = %+v, expected the time and shard of %+v", back.Decompose(), id.Decompose())
+	}
+}
+
+func TestKeepsOrder(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var prev xid.ID
+	for i := 0; i < 2000; i++ {
+		id, _ := microsharduuid.FromTime(ts.Add(time.Duration(i)*time.Millisecond), uint32(2000-i))
+		x, _ := ToXID(id)
+		if x.Compare(prev) <= 0 {
+			t.Fatalf("xid %d out of order", i)
+		}
+		prev = x
+	}
+}
+
+func TestFromForeignXID(t *testing.T) {
+	x := xid.New()
+	id, err := FromXID(x, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !id.Time().Truncate(time.Second).Equal(x.Time()) || id.ShardID() != 5 {
+		t.Errorf("FromXID(%s) = %+v", x, id.Decompose())
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/dilipvamsi/microshard-uuid/implementations/go/contrib/msuuidzap
+
+go 1.21
+
+require (
+	github.com/dilipvamsi/microshard-uuid/implementations/go v0.0.0-00010101000000-000000000000
+	go.uber.org/zap v1.27.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
+
+replace github.com/dilipvamsi/microshard-uuid/implementations/go => ../..
@@ -0,0 +1,74 @@
+// Package msuuidzap logs MicroShardUUIDs with go.uber.org/zap.
+//
+// ID renders an ID as its canonical string; Object and Field render it as an
+// object that also carries the Shard ID and timestamp, so log pipelines can
+// filter by shard without parsing IDs:
+//
+//	logger.Info("order created", msuuidzap.Field("order_id", id))
+//	// {"msg":"order created","order_id":{"id":"...","shard_id":500,"time":...}}
+//
+// Both encode the canonical string into a pooled buffer instead of calling
+// String, so logging an ID costs no string allocation.
+package msuuidzap
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// canonicalPool holds buffers for the canonical string. zap encoders copy
+// byte strings immediately, so buffers can be reused as soon as Add* returns.
+var canonicalPool = sync.Pool{
+	New: func() interface{} {
+		return new([36]byte)
+	},
+}
+
+// addCanonical encodes id under key without allocating a string.
+func addCanonical(enc zapcore.ObjectEncoder, key string, id microsharduuid.MicroShardUUID) {
+	buf := canonicalPool.Get().(*[36]byte)
+	b, _ := id.AppendText(buf[:0])
+	enc.AddByteString(key, b)
+	canonicalPool.Put(buf)
+}
+
+// ID returns a field that renders id as its canonical string.
+func ID(key string, id microsharduuid.MicroShardUUID) zap.Field {
+	return zap.Inline(inlineID{key: key, id: id})
+}
+
+type inlineID struct {
+	key string
+	id  microsharduuid.MicroShardUUID
+}
+
+func (i inlineID) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	addCanonical(enc, i.key, i.id)
+	return nil
+}
+
+// Field returns a field that renders id as an object of its canonical
+// string, Shard ID, and timestamp.
+func Field(key string, id microsharduuid.MicroShardUUID) zap.Field {
+	return zap.Object(key, Object(id))
+}
+
+// Object adapts id to zapcore.ObjectMarshaler, for use with zap.Object,
+// zap.Objects, or inside other marshalers.
+func Object(id microsharduuid.MicroShardUUID) zapcore.ObjectMarshaler {
+	return object(id)
+}
+
+type object microsharduuid.MicroShardUUID
+
+func (o object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	id := microsharduuid.MicroShardUUID(o)
+	addCanonical(enc, "id", id)
+	enc.AddUint32("shard_id", id.ShardID())
+	enc.AddTime("time", id.Time())
+	return nil
+}
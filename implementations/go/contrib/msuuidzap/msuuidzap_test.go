@@ -0,0 +1,89 @@
+package msuuidzap
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func newLogger(w io.Writer) *zap.Logger {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.TimeKey = ""
+	cfg.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+	return zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(cfg), zapcore.AddSync(w), zapcore.InfoLevel))
+}
+
+func TestID(t *testing.T) {
+	var buf bytes.Buffer
+	id := microsharduuid.MustParse("018e2b4a-5c3d-8f01-8000-0001f2a3b4c5")
+
+	newLogger(&buf).Info("created", ID("order_id", id))
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", buf.String(), err)
+	}
+	if entry["order_id"] != id.String() {
+		t.Errorf("Expected canonical ID, got %v", entry["order_id"])
+	}
+}
+
+func TestField(t *testing.T) {
+	var buf bytes.Buffer
+	id, _ := microsharduuid.FromParts(1700000000000000, 500, 1)
+
+	newLogger(&buf).Info("created", Field("order_id", id))
+
+	var entry struct {
+		OrderID struct {
+			ID      string `json:"id"`
+			ShardID uint32 `json:"shard_id"`
+			Time    string `json:"time"`
+		} `json:"order_id"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", buf.String(), err)
+	}
+	got := entry.OrderID
+	if got.ID != id.String() || got.ShardID != 500 || got.Time != "2023-11-14T22:13:20Z" {
+		t.Errorf("Unexpected object: %+v", got)
+	}
+}
+
+func TestEncodingDoesNotAllocate(t *testing.T) {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	id, _ := microsharduuid.Generate(1)
+
+	// Buffer growth is amortized; the canonical string must not cost an
+	// allocation per entry
+	allocs := testing.AllocsPerRun(1000, func() {
+		_ = inlineID{key: "id", id: id}.MarshalLogObject(enc)
+	})
+	if allocs >= 1 {
+		t.Errorf("Encoding an ID allocated %.2f times per call, expected 0", allocs)
+	}
+}
+
+func BenchmarkID(b *testing.B) {
+	logger := newLogger(io.Discard)
+	id, _ := microsharduuid.Generate(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("created", ID("id", id))
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	logger := newLogger(io.Discard)
+	id, _ := microsharduuid.Generate(1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("created", zap.String("id", id.String()))
+	}
+}
@@ -0,0 +1,354 @@
+// Package msuuidzk assigns Shard IDs through ZooKeeper ephemeral
+// sequential nodes, for fleets that already coordinate through ZooKeeper.
+//
+// Each instance creates an ephemeral sequential node named after a free
+// shard below a parent node (e.g. "/microshard/shards/0000000042-0000000007").
+// The node with the lowest sequence number for a shard owns it; a node
+// that loses the race is deleted and another shard is tried. When the
+// instance stops or its session expires, ZooKeeper deletes the node and the
+// shard becomes free for the next instance:
+//
+//	alloc, err := msuuidzk.New(msuuidzk.Config{Conn: conn, First: 0, Last: 1023})
+//	gen, err := microsharduuid.NewGenerator(0, microsharduuid.WithAllocator(alloc))
+//	defer alloc.Release(context.Background())
+//
+// Once the node is deleted, or no request has reached ZooKeeper for two
+// thirds of the session timeout, the Generator stops issuing IDs with
+// microsharduuid.ErrLeaseExpired, before the session can expire and another
+// instance take the shard over; Generator.Reacquire claims a new one.
+package msuuidzk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Defaults applied by New.
+const (
+	DefaultPrefix         = "/microshard/shards"
+	DefaultSessionTimeout = 10 * time.Second
+)
+
+// maxAttempts bounds the rescans when other instances claim the shards
+// this one picked.
+const maxAttempts = 5
+
+// Config configures an Allocator.
+type Config struct {
+	Conn   *zk.Conn
+	Prefix string // Parent node of the shard nodes, created if missing (DefaultPrefix if empty)
+	First  uint32 // First Shard ID of the range
+	Last   uint32 // Last Shard ID of the range (inclusive)
+	Owner  string // Data stored in the node, for operators (host:pid if empty)
+
+	// SessionTimeout is the timeout passed to zk.Connect, which must be
+	// within the server's limits (2 to 20 tick times, 4s to 40s by default)
+	// so the server does not shorten it. A claim counts as lost once no
+	// request has succeeded for two thirds of it (DefaultSessionTimeout if
+	// zero).
+	SessionTimeout time.Duration
+}
+
+// conn is the part of *zk.Conn the Allocator uses.
+type conn interface {
+	Create(path string, data []byte, flags int32, acl []zk.ACL) (string, error)
+	Children(path string) ([]string, *zk.Stat, error)
+	Delete(path string, version int32) error
+	Exists(path string) (bool, *zk.Stat, error)
+}
+
+// Allocator implements microsharduuid.Allocator with ZooKeeper ephemeral
+// sequential nodes. It holds at most one shard at a time and is safe for
+// concurrent use.
+type Allocator struct {
+	cfg  Config
+	conn conn
+
+	mu    sync.Mutex
+	claim *claim // nil when no shard is held
+}
+
+// claim is one held shard.
+type claim struct {
+	shard uint32
+	node  string        // Full path of the owning node
+	stop  chan struct{} // Closing it ends the watch
+	lost  chan struct{} // Closed when the watch ends
+}
+
+// New validates cfg and creates an Allocator. It fails with
+// microsharduuid.ErrInvalidOption for a nil connection, a reversed range,
+// or a prefix that is not an absolute node path.
+func New(cfg Config) (*Allocator, error) {
+	if cfg.Conn == nil {
+		return nil, fmt.Errorf("%w: zookeeper allocator needs a connection", microsharduuid.ErrInvalidOption)
+	}
+	return newAllocator(cfg, cfg.Conn)
+}
+
+func newAllocator(cfg Config, c conn) (*Allocator, error) {
+	if cfg.First > cfg.Last {
+		return nil, fmt.Errorf("%w: shard range %d-%d is reversed", microsharduuid.ErrInvalidOption, cfg.First, cfg.Last)
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = DefaultPrefix
+	}
+	if !strings.HasPrefix(cfg.Prefix, "/") || cfg.Prefix == "/" || path.Clean(cfg.Prefix) != cfg.Prefix {
+		return nil, fmt.Errorf("%w: zookeeper prefix %q is not an absolute node path", microsharduuid.ErrInvalidOption, cfg.Prefix)
+	}
+	if cfg.SessionTimeout <= 0 {
+		cfg.SessionTimeout = DefaultSessionTimeout
+	}
+	if cfg.Owner == "" {
+		host, _ := os.Hostname()
+		cfg.Owner = host + ":" + strconv.Itoa(os.Getpid())
+	}
+	return &Allocator{cfg: cfg, conn: c}, nil
+}
+
+// Acquire creates the node of a free shard of the range and checks it
+// until Release. A shard already held is released first. It fails with an
+// error wrapping microsharduuid.ErrNoShardAvailable when every shard of the
+// range is taken.
+func (a *Allocator) Acquire(ctx context.Context) (uint32, <-chan struct{}, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.release(ctx); err != nil {
+		return 0, nil, err
+	}
+	if err := a.ensurePrefix(); err != nil {
+		return 0, nil, err
+	}
+
+	claimed := time.Now() // The session is alive at least until a timeout after this
+	shard, node, err := a.claimFree(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	c := &claim{shard: shard, node: node, stop: make(chan struct{}), lost: make(chan struct{})}
+	go func() {
+		a.watch(c, claimed)
+		close(c.lost)
+	}()
+	a.claim = c
+	return shard, c.lost, nil
+}
+
+// ensurePrefix creates the missing persistent nodes of the prefix.
+func (a *Allocator) ensurePrefix() error {
+	p := ""
+	for _, part := range strings.Split(a.cfg.Prefix[1:], "/") {
+		p += "/" + part
+		if _, err := a.conn.Create(p, nil, 0, zk.WorldACL(zk.PermAll)); err != nil && !errors.Is(err, zk.ErrNodeExists) {
+			return fmt.Errorf("msuuidzk: creating %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// claimFree creates the node of a free shard, starting from a random shard
+// so concurrent instances rarely race for the same one.
+func (a *Allocator) claimFree(ctx context.Context) (uint32, string, error) {
+	size := uint64(a.cfg.Last) - uint64(a.cfg.First) + 1
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return 0, "", err
+		}
+		owners, err := a.owners()
+		if err != nil {
+			return 0, "", err
+		}
+		if uint64(len(owners)) >= size {
+			return 0, "", fmt.Errorf("%w: all %d shards of %s are taken", microsharduuid.ErrNoShardAvailable, size, a.cfg.Prefix)
+		}
+
+		start := uint64(rand.Int63n(int64(size)))
+		for i := uint64(0); i < size; i++ {
+			shard := a.cfg.First + uint32((start+i)%size)
+			if _, ok := owners[shard]; ok {
+				continue
+			}
+			node, err := a.conn.Create(fmt.Sprintf("%s/%010d-", a.cfg.Prefix, shard), []byte(a.cfg.Owner),
+				zk.FlagEphemeral|zk.FlagSequence, zk.WorldACL(zk.PermAll))
+			if err != nil {
+				return 0, "", fmt.Errorf("msuuidzk: claiming shard %d: %w", shard, err)
+			}
+
+			// Another instance may have created a node for the same shard
+			// since the scan: the lowest sequence wins
+			owners, err := a.owners()
+			if err != nil {
+				a.conn.Delete(node, -1) // Best effort: it disappears with the session anyway
+				return 0, "", err
+			}
+			if owners[shard] == path.Base(node) {
+				return shard, node, nil
+			}
+			if err := a.conn.Delete(node, -1); err != nil && !errors.Is(err, zk.ErrNoNode) {
+				return 0, "", fmt.Errorf("msuuidzk: withdrawing from shard %d: %w", shard, err)
+			}
+			break
+		}
+	}
+	return 0, "", fmt.Errorf("%w: lost %d races for a shard of %s", microsharduuid.ErrNoShardAvailable, maxAttempts, a.cfg.Prefix)
+}
+
+// owners maps each claimed shard of the range to the name of its node with
+// the lowest sequence number.
+func (a *Allocator) owners() (map[uint32]string, error) {
+	children, _, err := a.conn.Children(a.cfg.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("msuuidzk: listing shards: %w", err)
+	}
+	owners := make(map[uint32]string, len(children))
+	for _, name := range children {
+		// Names are "<shard>-<sequence>", both zero-padded to ten digits,
+		// so names of the same shard compare in sequence order
+		i := strings.IndexByte(name, '-')
+		if i < 0 {
+			continue
+		}
+		shard, err := strconv.ParseUint(name[:i], 10, 32)
+		if err != nil || uint32(shard) < a.cfg.First || uint32(shard) > a.cfg.Last {
+			continue
+		}
+		if held, ok := owners[uint32(shard)]; !ok || name < held {
+			owners[uint32(shard)] = name
+		}
+	}
+	return owners, nil
+}
+
+// probe is the outcome of one check of the claimed node.
+type probe struct {
+	sent   time.Time // When the request was sent
+	exists bool
+}
+
+// watch returns once the node of c is gone, no check has succeeded for two
+// thirds of the session timeout, or c.stop is closed.
+//
+// The server expires the session, deleting the node, once it has heard
+// nothing from the client for the session timeout. The connection state
+// only changes after the client's own receive timeout, which can be most
+// of the session timeout later, so watch instead times every successful
+// check from when it was sent and gives up a third of the timeout before
+// the earliest possible expiry. Checks run in their own goroutine, since a
+// request can hang while the client reconnects.
+func (a *Allocator) watch(c *claim, claimed time.Time) {
+	margin := a.cfg.SessionTimeout * 2 / 3
+	expiry := time.NewTimer(margin - time.Since(claimed))
+	defer expiry.Stop()
+
+	probes := make(chan probe)
+	done := make(chan struct{})
+	defer close(done)
+	go a.check(c.node, probes, done)
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-expiry.C:
+			return
+		case p := <-probes:
+			if !p.exists {
+				return
+			}
+			if !expiry.Stop() {
+				<-expiry.C
+			}
+			expiry.Reset(margin - time.Since(p.sent))
+		}
+	}
+}
+
+// check sends the outcome of a check of node to probes every sixth of the
+// session timeout, until done is closed. Failed checks are skipped, leaving
+// watch to give up once they have failed for too long.
+func (a *Allocator) check(node string, probes chan<- probe, done <-chan struct{}) {
+	ticker := time.NewTicker(a.cfg.SessionTimeout / 6)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		sent := time.Now()
+		exists, _, err := a.conn.Exists(node)
+		if err != nil {
+			continue
+		}
+		select {
+		case probes <- probe{sent: sent, exists: exists}:
+		case <-done:
+			return
+		}
+	}
+}
+
+// Release deletes the shard node and closes the channel returned by
+// Acquire. It does nothing if no shard is held.
+func (a *Allocator) Release(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.release(ctx)
+}
+
+func (a *Allocator) release(ctx context.Context) error {
+	c := a.claim
+	if c == nil {
+		return nil
+	}
+	a.claim = nil
+	close(c.stop)
+	<-c.lost
+
+	if err := ctx.Err(); err != nil {
+		// The node disappears with the session
+		return fmt.Errorf("msuuidzk: deleting node of shard %d: %w", c.shard, err)
+	}
+	// A node that went with an expired session has nothing left to delete
+	if err := a.conn.Delete(c.node, -1); err != nil && !errors.Is(err, zk.ErrNoNode) {
+		return fmt.Errorf("msuuidzk: deleting node of shard %d: %w", c.shard, err)
+	}
+	return nil
+}
+
+// Shard returns the held Shard ID, or false if none is held.
+func (a *Allocator) Shard() (uint32, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.claim == nil {
+		return 0, false
+	}
+	return a.claim.shard, true
+}
+
+// Node returns the path of the node that owns the held shard, or false if
+// none is held.
+func (a *Allocator) Node() (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.claim == nil {
+		return "", false
+	}
+	return a.claim.node, true
+}
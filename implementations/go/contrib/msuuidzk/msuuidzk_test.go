@@ -0,0 +1,344 @@
+package msuuidzk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// fakeZK is an in-memory ZooKeeper tree shared by the sessions of a test.
+type fakeZK struct {
+	mu    sync.Mutex
+	seq   int
+	nodes map[string]fakeNode
+
+	// beforeCreate runs before each sequential node is created
+	beforeCreate func(path string)
+}
+
+type fakeNode struct {
+	data    []byte
+	session int64 // Owning session of an ephemeral node, 0 if persistent
+}
+
+func newFakeZK() *fakeZK {
+	return &fakeZK{nodes: map[string]fakeNode{"/": {}}}
+}
+
+// session opens a connection with its own session.
+func (f *fakeZK) session(id int64) *fakeConn {
+	return &fakeConn{zk: f, id: id}
+}
+
+// expire deletes the ephemeral nodes of a session, as the server does once
+// it expires.
+func (f *fakeZK) expire(session int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for p, n := range f.nodes {
+		if n.session == session {
+			delete(f.nodes, p)
+		}
+	}
+}
+
+// fakeConn implements conn for one session of a fakeZK.
+type fakeConn struct {
+	zk *fakeZK
+	id int64
+
+	mu      sync.Mutex
+	stalled chan struct{} // Non-nil while Exists hangs, closed to resume
+}
+
+// stall makes Exists hang, as requests do while the client reconnects.
+func (c *fakeConn) stall() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stalled = make(chan struct{})
+}
+
+func (c *fakeConn) resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	close(c.stalled)
+	c.stalled = nil
+}
+
+func (c *fakeConn) Create(p string, data []byte, flags int32, acl []zk.ACL) (string, error) {
+	f := c.zk
+	if flags&zk.FlagSequence != 0 && f.beforeCreate != nil {
+		f.beforeCreate(p)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if flags&zk.FlagSequence != 0 {
+		p += fmt.Sprintf("%010d", f.seq)
+		f.seq++
+	}
+	if _, ok := f.nodes[p]; ok {
+		return "", zk.ErrNodeExists
+	}
+	if _, ok := f.nodes[path.Dir(p)]; !ok {
+		return "", zk.ErrNoNode
+	}
+	n := fakeNode{data: data}
+	if flags&zk.FlagEphemeral != 0 {
+		n.session = c.id
+	}
+	f.nodes[p] = n
+	return p, nil
+}
+
+func (c *fakeConn) Children(p string) ([]string, *zk.Stat, error) {
+	f := c.zk
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.nodes[p]; !ok {
+		return nil, nil, zk.ErrNoNode
+	}
+	var children []string
+	for child := range f.nodes {
+		if child != "/" && path.Dir(child) == p {
+			children = append(children, path.Base(child))
+		}
+	}
+	return children, &zk.Stat{}, nil
+}
+
+func (c *fakeConn) Delete(p string, version int32) error {
+	f := c.zk
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.nodes[p]; !ok {
+		return zk.ErrNoNode
+	}
+	delete(f.nodes, p)
+	return nil
+}
+
+func (c *fakeConn) Exists(p string) (bool, *zk.Stat, error) {
+	c.mu.Lock()
+	stalled := c.stalled
+	c.mu.Unlock()
+	if stalled != nil {
+		<-stalled
+	}
+
+	f := c.zk
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.nodes[p]
+	return ok, &zk.Stat{}, nil
+}
+
+func TestNewValidation(t *testing.T) {
+	if _, err := New(Config{}); !errors.Is(err, microsharduuid.ErrInvalidOption) {
+		t.Errorf("A missing connection should fail with ErrInvalidOption, got %v", err)
+	}
+	conn := newFakeZK().session(1)
+	for name, cfg := range map[string]Config{
+		"reversed range":  {First: 5, Last: 4},
+		"relative prefix": {Prefix: "microshard"},
+		"root prefix":     {Prefix: "/"},
+		"trailing slash":  {Prefix: "/microshard/"},
+	} {
+		if _, err := newAllocator(cfg, conn); !errors.Is(err, microsharduuid.ErrInvalidOption) {
+			t.Errorf("%s: expected ErrInvalidOption, got %v", name, err)
+		}
+	}
+}
+
+func TestAcquireUniqueShards(t *testing.T) {
+	fake := newFakeZK()
+	ctx := context.Background()
+
+	var allocs []*Allocator
+	seen := make(map[uint32]bool)
+	for i := 0; i < 3; i++ {
+		a, err := newAllocator(Config{First: 10, Last: 12, Owner: fmt.Sprintf("instance-%d", i)}, fake.session(int64(i+1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		shard, lost, err := a.Acquire(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shard < 10 || shard > 12 || seen[shard] {
+			t.Fatalf("Shard %d is outside the range or already taken (%v)", shard, seen)
+		}
+		select {
+		case <-lost:
+			t.Fatal("A fresh claim should not be lost")
+		default:
+		}
+		seen[shard] = true
+		allocs = append(allocs, a)
+	}
+
+	node, _ := allocs[0].Node()
+	fake.mu.Lock()
+	n := fake.nodes[node]
+	fake.mu.Unlock()
+	if string(n.data) != "instance-0" || n.session != 1 {
+		t.Errorf("Shard node should be ephemeral and name its owner, got %+v", n)
+	}
+	if !strings.HasPrefix(node, fmt.Sprintf("%s/%010d-", DefaultPrefix, mustShard(t, allocs[0]))) {
+		t.Errorf("Unexpected node path %s", node)
+	}
+
+	extra, _ := newAllocator(Config{First: 10, Last: 12}, fake.session(4))
+	if _, _, err := extra.Acquire(ctx); !errors.Is(err, microsharduuid.ErrNoShardAvailable) {
+		t.Fatalf("A full range should fail with ErrNoShardAvailable, got %v", err)
+	}
+
+	// Releasing frees the shard for the next instance right away
+	freed := mustShard(t, allocs[1])
+	if err := allocs[1].Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := allocs[1].Shard(); ok {
+		t.Error("Shard should report nothing after Release")
+	}
+	shard, _, err := extra.Acquire(ctx)
+	if err != nil || shard != freed {
+		t.Errorf("Expected the released shard %d, got %d (%v)", freed, shard, err)
+	}
+}
+
+func TestLostRace(t *testing.T) {
+	fake := newFakeZK()
+	ctx := context.Background()
+	rival := fake.session(2)
+
+	// Another instance creates a node for the same shard first
+	var contested uint32
+	fake.beforeCreate = func(p string) {
+		fake.beforeCreate = nil
+		fmt.Sscanf(path.Base(p), "%d-", &contested)
+		if _, err := rival.Create(p, nil, zk.FlagEphemeral|zk.FlagSequence, nil); err != nil {
+			t.Error(err)
+		}
+	}
+
+	a, _ := newAllocator(Config{First: 0, Last: 1}, fake.session(1))
+	shard, _, err := a.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shard == contested {
+		t.Errorf("Shard %d should belong to the node with the lower sequence", shard)
+	}
+
+	children, _, _ := rival.Children(DefaultPrefix)
+	if len(children) != 2 {
+		t.Errorf("The losing node should be deleted, got %v", children)
+	}
+}
+
+func TestGeneratorWithAllocator(t *testing.T) {
+	fake := newFakeZK()
+	ctx := context.Background()
+	conn := fake.session(1)
+
+	a, err := newAllocator(Config{Prefix: "/test/shards", First: 100, Last: 100, SessionTimeout: time.Second}, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := microsharduuid.NewGenerator(0, microsharduuid.WithAllocator(a))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := gen.NewID()
+	if err != nil || id.ShardID() != 100 {
+		t.Fatalf("Unexpected ID %v (%v)", id, err)
+	}
+
+	// Session expiry deletes the node and ends the claim
+	fake.expire(1)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if _, err := gen.NewID(); errors.Is(err, microsharduuid.ErrLeaseExpired) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Generation should stop once the node is deleted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The client reconnects with a new session
+	conn.id = 2
+	if err := gen.Reacquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gen.NewID(); err != nil {
+		t.Errorf("Reacquire should restore generation: %v", err)
+	}
+	if err := a.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if children, _, _ := conn.Children("/test/shards"); len(children) != 0 {
+		t.Errorf("Release should delete the shard node, got %v", children)
+	}
+}
+
+func TestStalledChecks(t *testing.T) {
+	fake := newFakeZK()
+	ctx := context.Background()
+	conn := fake.session(1)
+
+	timeout := 600 * time.Millisecond
+	a, _ := newAllocator(Config{First: 0, Last: 0, SessionTimeout: timeout}, conn)
+	_, lost, err := a.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Checks that keep succeeding hold the claim past the timeout
+	select {
+	case <-lost:
+		t.Fatal("A claim with successful checks should not be lost")
+	case <-time.After(2 * timeout):
+	}
+
+	// Once checks hang, the claim must end before the server can expire
+	// the session, a full timeout after the last check that got through
+	conn.stall()
+	stalled := time.Now()
+	select {
+	case <-lost:
+		if d := time.Since(stalled); d >= timeout {
+			t.Errorf("The claim should end before the session timeout, took %v", d)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stalled checks should close the lost channel")
+	}
+
+	conn.resume()
+	if err := a.Release(ctx); err != nil {
+		t.Errorf("Release after stalled checks should succeed, got %v", err)
+	}
+	if _, _, err := a.Acquire(ctx); err != nil {
+		t.Errorf("The shard should be free again: %v", err)
+	}
+	a.Release(ctx)
+}
+
+func mustShard(t *testing.T, a *Allocator) uint32 {
+	t.Helper()
+	shard, ok := a.Shard()
+	if !ok {
+		t.Fatal("No shard held")
+	}
+	return shard
+}
@@ -0,0 +1,162 @@
+// Package dedup provides a memory-bounded, probabilistic duplicate detector
+// for streams of MicroShardUUIDs, such as at-least-once delivered events
+// keyed by their ID.
+//
+// A Filter is a pair of Bloom filters over the full 128-bit value. It never
+// misses a duplicate of one of the most recent Capacity IDs, and reports a
+// fresh ID as a duplicate with a probability of at most FalsePositiveRate.
+// Once Capacity IDs have been added, the older filter is dropped and a new
+// one started, so memory stays fixed however long the stream runs; IDs seen
+// more than 2*Capacity IDs ago are forgotten.
+package dedup
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Filter detects repeated IDs. It is safe for concurrent use.
+type Filter struct {
+	mu       sync.Mutex
+	capacity uint64
+	bits     uint64 // Bits per generation, a multiple of 64
+	hashes   int
+	current  []uint64
+	previous []uint64 // Nil until the first rotation
+	added    uint64   // IDs added to current
+}
+
+// New creates a Filter that remembers at least the last capacity IDs with a
+// false-positive rate of at most fpRate (0 < fpRate < 1). Memory use is
+// about 2 * 1.44 * capacity * log2(2/fpRate) bits; for example one million
+// IDs at 1e-6 take about 7 MiB.
+//
+// It returns an error wrapping microsharduuid.ErrInvalidOption for a
+// non-positive capacity or an fpRate outside (0, 1).
+func New(capacity int, fpRate float64) (*Filter, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("%w: dedup capacity %d is not positive", microsharduuid.ErrInvalidOption, capacity)
+	}
+	if !(fpRate > 0 && fpRate < 1) {
+		return nil, fmt.Errorf("%w: dedup false-positive rate %v outside (0, 1)", microsharduuid.ErrInvalidOption, fpRate)
+	}
+
+	// A lookup checks both generations, so each gets half the budget
+	n, p := float64(capacity), fpRate/2
+	m := math.Ceil(-n * math.Log(p) / (math.Ln2 * math.Ln2))
+	words := (uint64(m) + 63) / 64
+	k := int(math.Round(float64(words*64) / n * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+
+	return &Filter{
+		capacity: uint64(capacity),
+		bits:     words * 64,
+		hashes:   k,
+		current:  make([]uint64, words),
+	}, nil
+}
+
+// Seen adds id and reports whether it was (probably) seen before. It is the
+// single call an ingestion pipeline needs: skip the event if Seen returns
+// true.
+func (f *Filter) Seen(id microsharduuid.MicroShardUUID) bool {
+	h1, h2 := hashID(id)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Always set the bits of current, so an ID found only in previous is
+	// remembered for another generation
+	inCurrent := true
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.bits
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if f.current[word]&mask == 0 {
+			inCurrent = false
+			f.current[word] |= mask
+		}
+	}
+	seen := inCurrent || f.contains(f.previous, h1, h2)
+	if !inCurrent {
+		f.added++
+		if f.added >= f.capacity {
+			f.rotate()
+		}
+	}
+	return seen
+}
+
+// Contains reports whether id was (probably) seen before, without adding it.
+func (f *Filter) Contains(id microsharduuid.MicroShardUUID) bool {
+	h1, h2 := hashID(id)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.contains(f.current, h1, h2) || f.contains(f.previous, h1, h2)
+}
+
+// Reset forgets every ID.
+func (f *Filter) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.current = make([]uint64, len(f.current))
+	f.previous = nil
+	f.added = 0
+}
+
+// SizeBytes returns the memory used by the bit arrays when both
+// generations are allocated.
+func (f *Filter) SizeBytes() int {
+	return 2 * len(f.current) * 8
+}
+
+func (f *Filter) contains(gen []uint64, h1, h2 uint64) bool {
+	if gen == nil {
+		return false
+	}
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.bits
+		if gen[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// rotate retires current to previous, reusing the old previous array.
+func (f *Filter) rotate() {
+	next := f.previous
+	if next == nil {
+		next = make([]uint64, len(f.current))
+	} else {
+		for i := range next {
+			next[i] = 0
+		}
+	}
+	f.previous, f.current = f.current, next
+	f.added = 0
+}
+
+// hashID derives the two base hashes for double hashing (Kirsch and
+// Mitzenmacher). Both halves go through a SplitMix64 finalizer because
+// IDs minted close together differ mostly in their low bits.
+func hashID(id microsharduuid.MicroShardUUID) (h1, h2 uint64) {
+	h1 = mix64(id.High ^ mix64(id.Low))
+	h2 = mix64(id.Low ^ mix64(id.High^0x9E3779B97F4A7C15))
+	return h1, h2 | 1 // Never a zero step
+}
+
+// mix64 is the SplitMix64 finalizer.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xBF58476D1CE4E5B9
+	x ^= x >> 27
+	x *= 0x94D049BB133111EB
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,107 @@
+package dedup
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func testIDs(t *testing.T, n int, shard uint32) []microsharduuid.MicroShardUUID {
+	t.Helper()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]microsharduuid.MicroShardUUID, n)
+	for i := range ids {
+		id, err := microsharduuid.FromTime(base.Add(time.Duration(i/4)*time.Microsecond), shard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+func TestNewValidation(t *testing.T) {
+	for _, tc := range []struct {
+		capacity int
+		fpRate   float64
+	}{{0, 0.01}, {-1, 0.01}, {100, 0}, {100, 1}, {100, -0.5}} {
+		if _, err := New(tc.capacity, tc.fpRate); !errors.Is(err, microsharduuid.ErrInvalidOption) {
+			t.Errorf("New(%d, %v) should fail with ErrInvalidOption, got %v", tc.capacity, tc.fpRate, err)
+		}
+	}
+}
+
+func TestSeen(t *testing.T) {
+	f, err := New(10000, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := testIDs(t, 5000, 7)
+
+	for _, id := range ids {
+		f.Seen(id)
+	}
+	for _, id := range ids {
+		if !f.Seen(id) {
+			t.Fatalf("Duplicate %s was missed", id)
+		}
+		if !f.Contains(id) {
+			t.Fatalf("Contains should report %s", id)
+		}
+	}
+
+	f.Reset()
+	if f.Contains(ids[0]) {
+		t.Error("Reset should forget every ID")
+	}
+}
+
+func TestFalsePositiveRate(t *testing.T) {
+	const capacity, fpRate = 20000, 0.01
+	f, err := New(capacity, fpRate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range testIDs(t, capacity-1, 1) {
+		f.Seen(id)
+	}
+
+	falsePositives := 0
+	fresh := testIDs(t, capacity, 2)
+	for _, id := range fresh {
+		if f.Contains(id) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / float64(len(fresh)); rate > fpRate {
+		t.Errorf("False-positive rate %v exceeds %v", rate, fpRate)
+	}
+}
+
+func TestRotationKeepsRecentIDs(t *testing.T) {
+	const capacity = 1000
+	f, err := New(capacity, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := f.SizeBytes()
+	ids := testIDs(t, 10*capacity, 3)
+
+	for i, id := range ids {
+		if f.Seen(id) {
+			continue // Rare false positive
+		}
+		// Every one of the last capacity IDs must still be detected
+		if i >= capacity && !f.Contains(ids[i-capacity+1]) {
+			t.Fatalf("ID %d forgotten after %d more IDs", i-capacity+1, capacity-1)
+		}
+	}
+	if f.SizeBytes() != size {
+		t.Errorf("Memory should stay fixed: %d -> %d bytes", size, f.SizeBytes())
+	}
+	if f.Contains(ids[0]) && f.Contains(ids[1]) && f.Contains(ids[2]) {
+		t.Error("IDs older than twice the capacity should be forgotten")
+	}
+}
@@ -0,0 +1,79 @@
+package microsharduuid
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ==========================================
+// Package-Level Default Generator
+// ==========================================
+
+// ErrDefaultShardNotSet is returned by New and Default before Init,
+// SetDefault, or SetDefaultShard has been called.
+var ErrDefaultShardNotSet = errors.New("default shard not set (call Init or SetDefaultShard first)")
+
+var (
+	defaultGenerator atomic.Value // *Generator
+	defaultMu        sync.Mutex   // Serializes changes of the default Generator
+)
+
+// Init replaces the process-wide default Generator used by New with a new
+// one for shardID. Call it once at startup; unlike SetDefaultShard, it
+// discards the state (counters, options) of any previous default.
+func Init(shardID uint32, opts ...Option) error {
+	g, err := NewGenerator(shardID, opts...)
+	if err != nil {
+		return err
+	}
+	SetDefault(g)
+	return nil
+}
+
+// SetDefault installs g as the process-wide default Generator used by New,
+// e.g. one built by dependency injection. A nil g unsets the default.
+func SetDefault(g *Generator) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultGenerator.Store(g)
+}
+
+// SetDefaultShard configures the process-wide default Generator used by New,
+// so small programs don't need to thread a *Generator everywhere. The first
+// call creates the Generator with opts; later calls change its shard (and
+// apply opts) atomically via Reconfigure, so callers holding Default() see
+// the change too. It is safe for concurrent use.
+func SetDefaultShard(shardID uint32, opts ...Option) error {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	if g, _ := defaultGenerator.Load().(*Generator); g != nil {
+		return g.Reconfigure(append([]Option{WithShardID(shardID)}, opts...)...)
+	}
+	g, err := NewGenerator(shardID, opts...)
+	if err != nil {
+		return err
+	}
+	defaultGenerator.Store(g)
+	return nil
+}
+
+// Default returns the process-wide default Generator, or
+// ErrDefaultShardNotSet if none has been set.
+func Default() (*Generator, error) {
+	g, _ := defaultGenerator.Load().(*Generator)
+	if g == nil {
+		return nil, ErrDefaultShardNotSet
+	}
+	return g, nil
+}
+
+// New generates an ID with the default Generator (see Init).
+func New() (MicroShardUUID, error) {
+	g, err := Default()
+	if err != nil {
+		return MicroShardUUID{}, err
+	}
+	return g.NewID()
+}
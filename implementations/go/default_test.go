@@ -0,0 +1,116 @@
+package microsharduuid
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// resetDefault clears the default Generator for the duration of a test.
+func resetDefault(t *testing.T) {
+	t.Helper()
+	old := defaultGenerator.Load()
+	defaultGenerator = atomic.Value{}
+	t.Cleanup(func() {
+		defaultGenerator = atomic.Value{}
+		if old != nil {
+			defaultGenerator.Store(old)
+		}
+	})
+}
+
+func TestDefaultNotSet(t *testing.T) {
+	resetDefault(t)
+
+	if _, err := New(); !errors.Is(err, ErrDefaultShardNotSet) {
+		t.Errorf("Expected ErrDefaultShardNotSet, got %v", err)
+	}
+	if _, err := Default(); !errors.Is(err, ErrDefaultShardNotSet) {
+		t.Errorf("Expected ErrDefaultShardNotSet from Default, got %v", err)
+	}
+}
+
+func TestSetDefaultShard(t *testing.T) {
+	resetDefault(t)
+
+	if err := SetDefaultShard(21); err != nil {
+		t.Fatalf("SetDefaultShard failed: %v", err)
+	}
+	gen, _ := Default()
+	id, err := New()
+	if err != nil || id.ShardID() != 21 {
+		t.Fatalf("Expected an ID for shard 21, got %s (%v)", id, err)
+	}
+
+	// Later calls reconfigure the same Generator
+	SetDefaultShard(22, WithMonotonicCounter())
+	if again, _ := Default(); again != gen || gen.ShardID() != 22 || !gen.cfg().counter {
+		t.Error("SetDefaultShard should reconfigure the existing default")
+	}
+}
+
+func TestSetDefaultShardConcurrent(t *testing.T) {
+	resetDefault(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(shard uint32) {
+			defer wg.Done()
+			SetDefaultShard(shard)
+			New()
+		}(uint32(i))
+	}
+	wg.Wait()
+
+	if _, err := New(); err != nil {
+		t.Errorf("New failed after concurrent setup: %v", err)
+	}
+}
+
+func TestInitAndSetDefault(t *testing.T) {
+	resetDefault(t)
+
+	if err := Init(7, WithMonotonicCounter()); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	first, _ := Default()
+	if id, err := New(); err != nil || id.ShardID() != 7 {
+		t.Fatalf("Expected an ID for shard 7, got %s (%v)", id, err)
+	}
+
+	// Init replaces the generator instead of reconfiguring it
+	Init(8)
+	if second, _ := Default(); second == first || second.cfg().counter {
+		t.Error("Init should install a fresh Generator")
+	}
+
+	custom, _ := NewGenerator(9)
+	SetDefault(custom)
+	if id, _ := New(); id.ShardID() != 9 {
+		t.Errorf("SetDefault should install the given Generator, got shard %d", id.ShardID())
+	}
+
+	SetDefault(nil)
+	if _, err := New(); !errors.Is(err, ErrDefaultShardNotSet) {
+		t.Errorf("SetDefault(nil) should unset the default, got %v", err)
+	}
+	if err := SetDefaultShard(3); err != nil || defaultShard(t) != 3 {
+		t.Error("SetDefaultShard should work after SetDefault(nil)")
+	}
+
+	if err := Init(1, WithRollbackPolicy(RollbackPolicy(-1))); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Init should report invalid options, got %v", err)
+	}
+}
+
+// defaultShard returns the shard of an ID from New.
+func defaultShard(t *testing.T) uint32 {
+	t.Helper()
+	id, err := New()
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return id.ShardID()
+}
@@ -0,0 +1,67 @@
+package microsharduuid
+
+import (
+	"io"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ==========================================
+// Deterministic Generation
+// ==========================================
+
+// DeterministicEpoch is the first timestamp issued by WithSeed (2024-01-01 UTC).
+var DeterministicEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// WithSeed makes the Generator fully reproducible for golden and snapshot
+// tests: timestamps start at DeterministicEpoch and advance by 1ms per call
+// (NewStepClock), and random bits come from a reader seeded with seed
+// (NewSeededReader). The same seed and call sequence always yield the same
+// IDs, on every platform and Go release.
+//
+// It cannot be combined with WithGlobalMonotonic, whose shared process-wide
+// clock depends on other Generators. Never use seeded IDs in production.
+func WithSeed(seed int64) Option {
+	return func(c *generatorConfig) {
+		c.clock = NewStepClock(DeterministicEpoch, time.Millisecond)
+		c.entropy = NewSeededReader(seed)
+		c.seeded = true
+	}
+}
+
+// NewStepClock returns a Clock whose first reading is start and that advances
+// by step on every subsequent reading. It is safe for concurrent use.
+func NewStepClock(start time.Time, step time.Duration) Clock {
+	return &stepClock{start: start, step: step, n: -1}
+}
+
+type stepClock struct {
+	start time.Time
+	step  time.Duration
+	n     int64 // readings so far minus one (atomic)
+}
+
+func (c *stepClock) Now() time.Time {
+	n := atomic.AddInt64(&c.n, 1)
+	return c.start.Add(time.Duration(n) * c.step)
+}
+
+// NewSeededReader returns a deterministic io.Reader for WithEntropy that
+// yields the same byte stream for the same seed. It is safe for concurrent
+// use but NOT cryptographically secure.
+func NewSeededReader(seed int64) io.Reader {
+	return &seededReader{rng: rand.New(rand.NewSource(seed))}
+}
+
+type seededReader struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func (r *seededReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rng.Read(p)
+}
@@ -0,0 +1,53 @@
+package microsharduuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithSeedReproducible(t *testing.T) {
+	a, _ := NewGenerator(5, WithSeed(42))
+	b, _ := NewGenerator(5, WithSeed(42))
+
+	for i := 0; i < 100; i++ {
+		idA, errA := a.NewID()
+		idB, errB := b.NewID()
+		if errA != nil || errB != nil {
+			t.Fatalf("NewID failed: %v, %v", errA, errB)
+		}
+		if idA != idB {
+			t.Fatalf("Seeded generators diverged at %d: %s vs %s", i, idA, idB)
+		}
+	}
+
+	c, _ := NewGenerator(5, WithSeed(42))
+	d, _ := NewGenerator(5, WithSeed(43))
+	first, _ := c.NewID()
+	other, _ := d.NewID()
+	if first == other {
+		t.Error("Different seeds should produce different IDs")
+	}
+}
+
+func TestWithSeedClockProgression(t *testing.T) {
+	gen, _ := NewGenerator(1, WithSeed(7))
+
+	for i := 0; i < 3; i++ {
+		id, _ := gen.NewID()
+		want := DeterministicEpoch.Add(time.Duration(i) * time.Millisecond)
+		if !id.Time().Equal(want) {
+			t.Errorf("ID %d: expected time %v, got %v", i, want, id.Time())
+		}
+	}
+}
+
+func TestWithSeedGolden(t *testing.T) {
+	gen, _ := NewGenerator(1, WithSeed(1))
+	id, _ := gen.NewID()
+
+	// Pinned so CI catches any change to the deterministic stream
+	const golden = "18375c40-8480-8000-8000-0012fdfc0721"
+	if id.String() != golden {
+		t.Errorf("Seeded output changed: expected %s, got %s", golden, id)
+	}
+}
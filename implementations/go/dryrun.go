@@ -0,0 +1,48 @@
+package microsharduuid
+
+// ==========================================
+// Dry-Run IDs
+// ==========================================
+
+// DryRunVariant is the variant value (binary 11) carried by dry-run IDs.
+//
+// The 54/32/36 layout has no spare bits, so instead of stealing entropy from
+// every ID, dry-run IDs use the variant that RFC 9562 reserves for future
+// definition. A production ID (variant 10) can therefore never be mistaken
+// for a dry-run ID and vice versa. All other fields, including the shard,
+// are encoded normally.
+//
+// Dry-run IDs are not valid MicroShardUUIDs: Parse, FromBytes, IsValid and
+// every other decoder reject them, as do the other implementations. Only
+// ParseDryRun accepts them, for pipelines that must recognize and drop
+// dry-run traffic.
+const DryRunVariant uint64 = 3
+
+// IsDryRun reports whether the ID was issued by a Generator in dry-run mode
+// (see WithDryRun). Downstream consumers should discard such IDs.
+func (u MicroShardUUID) IsDryRun() bool {
+	return (u.Low>>62)&0x3 == DryRunVariant
+}
+
+// ParseDryRun is Parse, except that it also accepts dry-run IDs (see
+// DryRunVariant). Use it only where dry-run traffic is expected, and check
+// IsDryRun on the result.
+func ParseDryRun(s string) (MicroShardUUID, error) {
+	high, low, ok := decodeCanonical(s)
+	if !ok {
+		var err error
+		if high, low, err = decodeDashed(trimBraces(trimURN(s))); err != nil {
+			return Parse(s) // Reports the format error
+		}
+	}
+	if (high>>12)&0xF == Version && low>>62 == DryRunVariant {
+		return MicroShardUUID{High: high, Low: low}, nil
+	}
+	return fromHighLow(high, low, FormatCanonical, len(s))
+}
+
+// markDryRun sets the variant to DryRunVariant.
+func (u MicroShardUUID) markDryRun() MicroShardUUID {
+	u.Low |= DryRunVariant << 62
+	return u
+}
@@ -0,0 +1,76 @@
+package microsharduuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDryRunGenerator(t *testing.T) {
+	gen, _ := NewGenerator(44, WithDryRun())
+
+	id, err := gen.NewID()
+	if err != nil {
+		t.Fatalf("NewID failed: %v", err)
+	}
+	if !id.IsDryRun() {
+		t.Error("Dry-run generator must mark its IDs")
+	}
+	if id.ShardID() != 44 {
+		t.Errorf("Dry-run IDs must keep the shard. Expected 44, got %d", id.ShardID())
+	}
+
+	// Only the opt-in decoder accepts dry-run IDs
+	if _, err := Parse(id.String()); !errors.Is(err, ErrInvalidVariant) {
+		t.Errorf("Parse should reject dry-run IDs with ErrInvalidVariant, got %v", err)
+	}
+	if _, err := FromBytes(id.Bytes()); !errors.Is(err, ErrInvalidVariant) {
+		t.Errorf("FromBytes should reject dry-run IDs with ErrInvalidVariant, got %v", err)
+	}
+	parsed, err := ParseDryRun(id.String())
+	if err != nil || parsed != id || !parsed.IsDryRun() {
+		t.Errorf("ParseDryRun should accept the ID and keep it marked: %v", err)
+	}
+
+	ids, _ := gen.NewIDs(10)
+	for _, batched := range ids {
+		if !batched.IsDryRun() {
+			t.Fatal("NewIDs must mark IDs in dry-run mode")
+		}
+	}
+
+	gen.Reconfigure(WithoutDryRun())
+	if id, _ := gen.NewID(); id.IsDryRun() {
+		t.Error("WithoutDryRun should stop marking IDs")
+	}
+}
+
+func TestParseDryRun(t *testing.T) {
+	id := MustGenerate(7)
+	dry := id.markDryRun()
+	for s, wantDry := range map[string]bool{
+		id.String():                   false,
+		"urn:uuid:" + id.String():     false,
+		strings.ToUpper(dry.String()): true,
+		"{" + dry.String() + "}":      true,
+	} {
+		parsed, err := ParseDryRun(s)
+		if err != nil || parsed.IsDryRun() != wantDry || parsed.Random() != id.Random() {
+			t.Errorf("ParseDryRun(%q) = %s, %v", s, parsed, err)
+		}
+	}
+
+	for _, s := range []string{"", "550e8400-e29b-41d4-a716-446655440000", "550e8400-e29b-81d4-0716-446655440000"} {
+		if _, err := ParseDryRun(s); err == nil {
+			t.Errorf("ParseDryRun(%q) should fail", s)
+		}
+	}
+}
+
+func TestRegularIDsAreNotDryRun(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if MustGenerate(uint32(i)).IsDryRun() {
+			t.Fatal("Regular IDs must never be reported as dry-run")
+		}
+	}
+}
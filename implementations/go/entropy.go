@@ -0,0 +1,72 @@
+package microsharduuid
+
+import (
+	"crypto/rand"
+	"io"
+	"sync"
+)
+
+// ==========================================
+// Buffered Entropy
+// ==========================================
+
+// entropyBufferSize is the number of crypto/rand bytes fetched per refill.
+// At 5 bytes per ID, one syscall serves ~800 IDs.
+const entropyBufferSize = 4096
+
+// entropyBuffer holds pre-read bytes from crypto/rand.
+type entropyBuffer struct {
+	buf [entropyBufferSize]byte
+	pos int
+}
+
+// entropyPool caches buffers per P (sync.Pool keeps a per-processor cache),
+// so concurrent generators rarely contend and never share bytes.
+// Bytes are handed out once and never reused.
+var entropyPool = sync.Pool{
+	New: func() interface{} {
+		return &entropyBuffer{pos: entropyBufferSize}
+	},
+}
+
+// entropySource is where buffers are refilled from. Replaced only in tests.
+var entropySource io.Reader = rand.Reader
+
+// readEntropy fills p (len(p) <= entropyBufferSize) with cryptographically
+// secure random bytes, amortizing reads of the OS entropy source.
+func readEntropy(p []byte) error {
+	eb := entropyPool.Get().(*entropyBuffer)
+	defer entropyPool.Put(eb)
+
+	if entropyBufferSize-eb.pos < len(p) {
+		if err := readEntropyFrom(entropySource, eb.buf[:]); err != nil {
+			// Don't hand out a partially filled buffer
+			eb.pos = entropyBufferSize
+			return err
+		}
+		eb.pos = 0
+	}
+
+	n := copy(p, eb.buf[eb.pos:])
+	// Wipe consumed bytes so they can't leak from memory later
+	for i := eb.pos; i < eb.pos+n; i++ {
+		eb.buf[i] = 0
+	}
+	eb.pos += n
+	return nil
+}
+
+// random36Source is implemented by internal entropy sources that can produce
+// 36-bit values directly, avoiding a byte slice round-trip.
+type random36Source interface {
+	random36() (uint64, error)
+}
+
+// pooledRandom36 draws 36 random bits from the buffered crypto/rand pool.
+func pooledRandom36() (uint64, error) {
+	var b [5]byte
+	if err := readEntropy(b[:]); err != nil {
+		return 0, err
+	}
+	return bytesToRandom36(b[:]), nil
+}
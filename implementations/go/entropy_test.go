@@ -0,0 +1,86 @@
+package microsharduuid
+
+import (
+	"errors"
+	"io"
+	"runtime"
+	"testing"
+)
+
+// countingReader counts Read calls on the wrapped source.
+type countingReader struct {
+	calls int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.calls++
+	for i := range p {
+		p[i] = byte(r.calls + i)
+	}
+	return len(p), nil
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("entropy unavailable")
+}
+
+func withEntropySource(t *testing.T, src io.Reader) {
+	t.Helper()
+	old := entropySource
+	entropySource = src
+	// Drop buffers filled from the previous source
+	for i := 0; i < 64; i++ {
+		entropyPool.Get()
+	}
+	t.Cleanup(func() { entropySource = old })
+}
+
+func TestEntropyIsBuffered(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops a quarter of its buffers under the race detector")
+	}
+	src := &countingReader{}
+	withEntropySource(t, src)
+
+	const n = 1000
+	for i := 0; i < n; i++ {
+		if _, err := Generate(1); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+	}
+
+	// Each buffer serves at least entropyBufferSize/16 IDs per refill, and
+	// the goroutine may run on every P, each with its own pooled buffer
+	limit := n*16/entropyBufferSize + runtime.GOMAXPROCS(0)
+	if src.calls == 0 || src.calls > limit {
+		t.Errorf("Expected 1-%d reads of the entropy source for %d IDs, got %d", limit, n, src.calls)
+	}
+}
+
+func TestEntropyFailure(t *testing.T) {
+	withEntropySource(t, failingReader{})
+
+	_, err := Generate(1)
+	var ge *GenerateError
+	if !errors.As(err, &ge) || ge.Err == nil {
+		t.Fatalf("Expected a GenerateError wrapping the entropy failure, got %v", err)
+	}
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = Generate(1)
+	}
+}
+
+func BenchmarkGenerateParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = Generate(1)
+		}
+	})
+}
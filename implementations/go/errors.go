@@ -0,0 +1,93 @@
+package microsharduuid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ==========================================
+// Typed Errors
+// ==========================================
+
+// Format names reported in ParseError.Format.
+const (
+	FormatCanonical = "canonical"
+	FormatBase32    = "base32"
+	FormatBase58    = "base58"
+	FormatProquint  = "proquint"
+	FormatHex       = "hex"
+	FormatBytes     = "bytes"
+)
+
+// Sentinel errors wrapped by ParseError and GenerateError, so callers can
+// branch with errors.Is instead of matching messages.
+var (
+	ErrInvalidLength   = errors.New("invalid length")
+	ErrInvalidHex      = errors.New("invalid hex")
+	ErrInvalidEncoding = errors.New("invalid encoding") // Bad Base32/Base58/Proquint input, or non-canonical input to ParseStrict
+	ErrInvalidVersion  = errors.New("invalid version")
+	ErrInvalidVariant  = errors.New("invalid variant")
+	ErrShardOverflow   = fmt.Errorf("shard ID must be between 0 and %d", MaxShardID)
+	ErrTimeOverflow    = errors.New("time overflow (Year > 2541)")
+	ErrRandomOverflow  = errors.New("random overflow (must fit in 36 bits)")
+	ErrInvalidOption   = errors.New("invalid generator option")
+	ErrUnrepresentable = errors.New("value not representable in target format") // Conversion to another ID scheme whose time range or field widths are too small
+)
+
+// ParseError is returned when an encoded MicroShardUUID cannot be decoded.
+// It carries enough context for error aggregation systems to group failures
+// (e.g. all "invalid version" errors from v4 inputs) without parsing messages.
+type ParseError struct {
+	Format   string // Encoding that was being decoded (FormatCanonical, FormatBase32, ...)
+	InputLen int    // Length of the rejected input in bytes
+	Version  int    // Detected version field, or -1 if decoding failed earlier
+	Variant  int    // Detected variant field, or -1 if decoding failed earlier
+	Reason   string // Human readable description
+	Err      error  // Sentinel category (ErrInvalidLength, ErrInvalidVersion, ...)
+}
+
+func (e *ParseError) Error() string {
+	return e.Reason
+}
+
+// Unwrap returns the sentinel category, so errors.Is(err, ErrInvalidVersion) works.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// GenerateError is returned when a MicroShardUUID cannot be generated.
+type GenerateError struct {
+	ShardID uint32 // Requested Shard ID
+	Micros  uint64 // Requested timestamp (Unix Microseconds)
+	Reason  string // Human readable description
+	Err     error  // Underlying cause (entropy read failure, ErrTimeOverflow, ...), may be nil
+}
+
+func (e *GenerateError) Error() string {
+	// Sentinel causes already are the reason; don't repeat them
+	if e.Err != nil && e.Err.Error() != e.Reason {
+		return e.Reason + ": " + e.Err.Error()
+	}
+	return e.Reason
+}
+
+// Unwrap returns the underlying cause, if any.
+func (e *GenerateError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError creates a ParseError for failures that happen before the
+// version and variant fields could be decoded.
+func newParseError(format string, inputLen int, sentinel error, reason string) *ParseError {
+	return parseFailed(&ParseError{Format: format, InputLen: inputLen, Version: -1, Variant: -1, Reason: reason, Err: sentinel})
+}
+
+// errShardRange reports a Shard ID outside [0, MaxShardID].
+func errShardRange(shardID uint32) *GenerateError {
+	return &GenerateError{ShardID: shardID, Reason: ErrShardOverflow.Error(), Err: ErrShardOverflow}
+}
+
+// errTimeOverflow reports a timestamp above MaxTime.
+func errTimeOverflow(shardID uint32, micros uint64) *GenerateError {
+	return &GenerateError{ShardID: shardID, Micros: micros, Reason: ErrTimeOverflow.Error(), Err: ErrTimeOverflow}
+}
@@ -0,0 +1,31 @@
+//go:build go1.21
+
+package microsharduuid
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer, rendering the error as a group
+// of its context fields.
+func (e *ParseError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("msg", e.Reason),
+		slog.String("format", e.Format),
+		slog.Int("input_len", e.InputLen),
+		slog.Int("version", e.Version),
+		slog.Int("variant", e.Variant),
+	)
+}
+
+// LogValue implements slog.LogValuer, rendering the error as a group
+// of its context fields.
+func (e *GenerateError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("msg", e.Reason),
+		slog.Uint64("shard_id", uint64(e.ShardID)),
+		slog.Uint64("micros", e.Micros),
+	}
+	if e.Err != nil {
+		attrs = append(attrs, slog.String("cause", e.Err.Error()))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,25 @@
+//go:build go1.21
+
+package microsharduuid
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestErrorLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	_, err := Parse("550e8400-e29b-41d4-a716-446655440000")
+	logger.Error("parse failed", "err", err)
+
+	out := buf.String()
+	for _, want := range []string{"err.format=canonical", "err.input_len=36", "err.version=4"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Log output missing %q: %s", want, out)
+		}
+	}
+}
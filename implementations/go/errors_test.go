@@ -0,0 +1,75 @@
+package microsharduuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseErrorContext(t *testing.T) {
+	// Invalid length
+	_, err := Parse("123")
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Expected *ParseError, got %T", err)
+	}
+	if pe.Format != FormatCanonical || pe.InputLen != 3 || pe.Version != -1 {
+		t.Errorf("Unexpected context for length error: %+v", pe)
+	}
+
+	// Wrong version (a v4 UUID) must report the detected version
+	_, err = Parse("550e8400-e29b-41d4-a716-446655440000")
+	if !errors.As(err, &pe) {
+		t.Fatalf("Expected *ParseError, got %T", err)
+	}
+	if pe.Version != 4 || pe.Variant != 2 || pe.InputLen != 36 {
+		t.Errorf("Unexpected context for version error: %+v", pe)
+	}
+
+	// Other formats report their own name
+	_, err = ParseBase32("too-short")
+	if !errors.As(err, &pe) || pe.Format != FormatBase32 {
+		t.Errorf("Base32 errors should report FormatBase32, got %v", err)
+	}
+}
+
+func TestGenerateErrorContext(t *testing.T) {
+	future := time.UnixMicro(int64(MaxTime + 1000))
+	_, err := FromTime(future, 42)
+
+	var ge *GenerateError
+	if !errors.As(err, &ge) {
+		t.Fatalf("Expected *GenerateError, got %T", err)
+	}
+	if ge.ShardID != 42 || ge.Micros != MaxTime+1000 {
+		t.Errorf("Unexpected context for overflow error: %+v", ge)
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	parse := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"length", func() error { _, err := Parse("123"); return err }(), ErrInvalidLength},
+		{"hex", func() error { _, err := Parse("zzzzzzzz-e29b-81d4-a716-446655440000"); return err }(), ErrInvalidHex},
+		{"version", func() error { _, err := Parse("550e8400-e29b-41d4-a716-446655440000"); return err }(), ErrInvalidVersion},
+		{"variant", func() error { _, err := Parse("550e8400-e29b-81d4-0716-446655440000"); return err }(), ErrInvalidVariant},
+		{"base32", func() error { _, err := ParseBase32("UUUUUUUUUUUUUUUUUUUUUUUUUU"); return err }(), ErrInvalidEncoding},
+		{"bytes", func() error { _, err := FromBytes([]byte{1, 2}); return err }(), ErrInvalidLength},
+		{"time", func() error { _, err := FromTime(time.UnixMicro(int64(MaxTime+1)), 1); return err }(), ErrTimeOverflow},
+		{"random", func() error { _, err := FromParts(1, 1, MaxRandom+1); return err }(), ErrRandomOverflow},
+	}
+	for _, tt := range parse {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: expected errors.Is(%v, %v)", tt.name, tt.err, tt.want)
+		}
+	}
+
+	// Sentinel causes are not repeated in the message
+	_, err := FromTime(time.UnixMicro(int64(MaxTime+1)), 1)
+	if err.Error() != "time overflow (Year > 2541)" {
+		t.Errorf("Unexpected message: %q", err.Error())
+	}
+}
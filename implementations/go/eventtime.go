@@ -0,0 +1,67 @@
+package microsharduuid
+
+import "time"
+
+// ==========================================
+// Event Time vs Ingestion Time
+// ==========================================
+
+// lagBits is the number of low random bits used by NewEventTimeIDWithLag.
+const lagBits = 16
+
+// maxEmbeddedLag is the largest ingestion lag NewEventTimeIDWithLag can record.
+const maxEmbeddedLag = (1<<lagBits - 1) * time.Second
+
+// EventTimeID is an ID keyed by when an event happened, paired with when it
+// was ingested. Late-arriving data still sorts by event time, while the
+// sidecar IngestedAt keeps the arrival time for auditing and watermarking.
+type EventTimeID struct {
+	ID         MicroShardUUID // Sortable ID carrying the event time
+	IngestedAt time.Time      // When the event was received
+}
+
+// NewEventTimeID creates an ID whose timestamp is eventTime, recording
+// ingestedAt in the sidecar field only. All 36 random bits stay random.
+func NewEventTimeID(eventTime, ingestedAt time.Time, shardID uint32) (EventTimeID, error) {
+	id, err := FromTime(eventTime, shardID)
+	if err != nil {
+		return EventTimeID{}, err
+	}
+	return EventTimeID{ID: id, IngestedAt: ingestedAt}, nil
+}
+
+// NewEventTimeIDWithLag is like NewEventTimeID but also embeds the ingestion
+// lag (in whole seconds, saturating at ~18 hours) in the low 16 random bits,
+// so the lag survives even when only the ID is stored. Read it back with
+// EmbeddedLag.
+//
+// Only 20 random bits remain, so use it for moderate per-microsecond rates.
+func NewEventTimeIDWithLag(eventTime, ingestedAt time.Time, shardID uint32) (EventTimeID, error) {
+	e, err := NewEventTimeID(eventTime, ingestedAt, shardID)
+	if err != nil {
+		return EventTimeID{}, err
+	}
+
+	lag := ingestedAt.Sub(e.ID.Time())
+	if lag < 0 {
+		lag = 0
+	}
+	if lag > maxEmbeddedLag {
+		lag = maxEmbeddedLag
+	}
+
+	const lagMask = 1<<lagBits - 1
+	e.ID.Low = e.ID.Low&^lagMask | uint64(lag/time.Second)
+	return e, nil
+}
+
+// Lag returns how long after the event it was ingested.
+func (e EventTimeID) Lag() time.Duration {
+	return e.IngestedAt.Sub(e.ID.Time())
+}
+
+// EmbeddedLag returns the ingestion lag recorded by NewEventTimeIDWithLag.
+// For other IDs the result is meaningless (it decodes random bits).
+func EmbeddedLag(id MicroShardUUID) time.Duration {
+	return time.Duration(id.Low&(1<<lagBits-1)) * time.Second
+}
@@ -0,0 +1,52 @@
+package microsharduuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventTimeID(t *testing.T) {
+	event := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	ingested := event.Add(90 * time.Minute)
+
+	e, err := NewEventTimeID(event, ingested, 9)
+	if err != nil {
+		t.Fatalf("NewEventTimeID failed: %v", err)
+	}
+	if !e.ID.Time().Equal(event) {
+		t.Errorf("ID must carry the event time. Expected %v, got %v", event, e.ID.Time())
+	}
+	if e.Lag() != 90*time.Minute {
+		t.Errorf("Unexpected lag: %v", e.Lag())
+	}
+
+	// Late events still sort by event time
+	late, _ := NewEventTimeID(event.Add(-time.Hour), ingested.Add(time.Hour), 9)
+	if !late.ID.Before(e.ID) {
+		t.Error("Late-arriving event should sort before the newer event")
+	}
+}
+
+func TestEventTimeIDWithLag(t *testing.T) {
+	event := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	e, _ := NewEventTimeIDWithLag(event, event.Add(42*time.Second), 3)
+	if EmbeddedLag(e.ID) != 42*time.Second {
+		t.Errorf("Expected embedded lag 42s, got %v", EmbeddedLag(e.ID))
+	}
+	if _, err := Parse(e.ID.String()); err != nil || e.ID.ShardID() != 3 {
+		t.Errorf("Embedding the lag must keep the ID valid: %v", err)
+	}
+
+	// Saturates instead of wrapping
+	e, _ = NewEventTimeIDWithLag(event, event.Add(48*time.Hour), 3)
+	if EmbeddedLag(e.ID) != maxEmbeddedLag {
+		t.Errorf("Expected saturated lag, got %v", EmbeddedLag(e.ID))
+	}
+
+	// Events "from the future" record zero lag
+	e, _ = NewEventTimeIDWithLag(event, event.Add(-time.Minute), 3)
+	if EmbeddedLag(e.ID) != 0 {
+		t.Errorf("Expected zero lag, got %v", EmbeddedLag(e.ID))
+	}
+}
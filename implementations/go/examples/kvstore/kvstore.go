@@ -0,0 +1,388 @@
+// Package kvstore is a small reference key-value store keyed by MicroShardUUID.
+//
+// It demonstrates the recommended storage layout for these IDs:
+//
+//   - Keys are encoded as the 16-byte Big Endian form (MicroShardUUID.Bytes),
+//     whose byte order equals time order, so ordered iteration is chronological.
+//   - Each shard has its own append-only log file ("shard-<id>.log"), so a hot
+//     or retired shard can be compacted without touching the others.
+//   - A single in-memory sorted index spans all shards, so time-range scans
+//     are a binary search followed by a sequential walk.
+//
+// It is meant as documentation and as an integration test bed, not as a
+// production database.
+package kvstore
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Record layout in a shard log:
+//
+//	[Key 16] [Op 1] [Value Length 4] [Value N] [CRC-32 4]
+//
+// The CRC covers everything before it, so a torn write at the tail of the
+// log is detected and truncated on Open.
+const (
+	opPut    byte = 1
+	opDelete byte = 2
+
+	recordHeaderLen  = 16 + 1 + 4
+	recordTrailerLen = 4
+)
+
+// ErrNotFound is returned by Get when the key does not exist.
+var ErrNotFound = errors.New("kvstore: key not found")
+
+// indexEntry locates the value for a key in its shard log.
+type indexEntry struct {
+	key    microsharduuid.MicroShardUUID
+	offset int64 // Offset of the value bytes
+	size   uint32
+}
+
+// Store is an append-only, per-shard log store with a sorted in-memory index.
+// It is safe for concurrent use.
+type Store struct {
+	mu    sync.RWMutex
+	dir   string
+	logs  map[uint32]*os.File
+	index []indexEntry // Sorted by key
+}
+
+// Open opens (or creates) a store in dir and rebuilds the index from the shard logs.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	s := &Store{dir: dir, logs: make(map[uint32]*os.File)}
+
+	names, err := filepath.Glob(filepath.Join(dir, "shard-*.log"))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		base := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(name), "shard-"), ".log")
+		shard, err := strconv.ParseUint(base, 10, 32)
+		if err != nil {
+			continue // Not one of ours
+		}
+		if err := s.replay(uint32(shard)); err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Close closes all shard logs.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var firstErr error
+	for shard, f := range s.logs {
+		if err := f.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(s.logs, shard)
+	}
+	return firstErr
+}
+
+// Put stores value under key, replacing any previous value.
+func (s *Store) Put(key microsharduuid.MicroShardUUID, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offset, err := s.append(key, opPut, value)
+	if err != nil {
+		return err
+	}
+	s.upsert(indexEntry{key: key, offset: offset, size: uint32(len(value))})
+	return nil
+}
+
+// Delete removes key. Deleting a missing key is not an error.
+func (s *Store) Delete(key microsharduuid.MicroShardUUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.find(key); !ok {
+		return nil
+	}
+	if _, err := s.append(key, opDelete, nil); err != nil {
+		return err
+	}
+	s.remove(key)
+	return nil
+}
+
+// Get returns the value stored under key.
+func (s *Store) Get(key microsharduuid.MicroShardUUID) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i, ok := s.find(key)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return s.read(s.index[i])
+}
+
+// Len returns the number of live keys.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.index)
+}
+
+// Scan calls fn for every key created in [from, to), in chronological order,
+// stopping early if fn returns false.
+func (s *Store) Scan(from, to time.Time, fn func(key microsharduuid.MicroShardUUID, value []byte) bool) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Keys are sorted by their bytes, which sort by embedded time first
+	start := sort.Search(len(s.index), func(i int) bool {
+		return !s.index[i].key.Time().Before(from)
+	})
+
+	for i := start; i < len(s.index); i++ {
+		e := s.index[i]
+		if !e.key.Time().Before(to) {
+			break
+		}
+		value, err := s.read(e)
+		if err != nil {
+			return err
+		}
+		if !fn(e.key, value) {
+			break
+		}
+	}
+	return nil
+}
+
+// Compact rewrites the log of a single shard so it only contains live records,
+// reclaiming space taken by overwritten and deleted values.
+func (s *Store) Compact(shard uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, ok := s.logs[shard]
+	if !ok {
+		return nil
+	}
+
+	tmpPath := s.logPath(shard) + ".compact"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+
+	// Copy live records in key order, remembering their new offsets
+	moved := make(map[int]int64)
+	var offset int64
+	for i, e := range s.index {
+		if e.key.ShardID() != shard {
+			continue
+		}
+		value, err := s.read(e)
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return err
+		}
+		rec := encodeRecord(e.key, opPut, value)
+		if _, err := tmp.Write(rec); err != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return err
+		}
+		moved[i] = offset + recordHeaderLen
+		offset += int64(len(rec))
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, s.logPath(shard)); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	old.Close()
+	s.logs[shard] = tmp
+	for i, off := range moved {
+		s.index[i].offset = off
+	}
+	return nil
+}
+
+// ==========================================
+// Internal Helpers
+// ==========================================
+
+func (s *Store) logPath(shard uint32) string {
+	return filepath.Join(s.dir, fmt.Sprintf("shard-%d.log", shard))
+}
+
+// log returns the open log for shard, creating it if needed.
+func (s *Store) log(shard uint32) (*os.File, error) {
+	if f, ok := s.logs[shard]; ok {
+		return f, nil
+	}
+	f, err := os.OpenFile(s.logPath(shard), os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	s.logs[shard] = f
+	return f, nil
+}
+
+// append writes a record to the end of the key's shard log and returns
+// the offset of its value bytes.
+func (s *Store) append(key microsharduuid.MicroShardUUID, op byte, value []byte) (int64, error) {
+	f, err := s.log(key.ShardID())
+	if err != nil {
+		return 0, err
+	}
+
+	end, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := f.Write(encodeRecord(key, op, value)); err != nil {
+		return 0, err
+	}
+	return end + recordHeaderLen, nil
+}
+
+func (s *Store) read(e indexEntry) ([]byte, error) {
+	value := make([]byte, e.size)
+	if _, err := s.logs[e.key.ShardID()].ReadAt(value, e.offset); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// replay rebuilds the index entries of one shard log, truncating a torn tail.
+func (s *Store) replay(shard uint32) error {
+	f, err := s.log(shard)
+	if err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+
+	var offset int64
+	for {
+		key, op, value, n, ok := decodeRecord(data[offset:])
+		if !ok {
+			break
+		}
+		if key.ShardID() != shard {
+			return fmt.Errorf("kvstore: record for shard %d found in log of shard %d", key.ShardID(), shard)
+		}
+
+		switch op {
+		case opPut:
+			s.upsert(indexEntry{key: key, offset: offset + recordHeaderLen, size: uint32(len(value))})
+		case opDelete:
+			s.remove(key)
+		}
+		offset += int64(n)
+	}
+
+	// Drop any partially written record
+	if offset != int64(len(data)) {
+		return f.Truncate(offset)
+	}
+	return nil
+}
+
+// find returns the index position of key.
+func (s *Store) find(key microsharduuid.MicroShardUUID) (int, bool) {
+	i := sort.Search(len(s.index), func(i int) bool {
+		return s.index[i].key.Compare(key) >= 0
+	})
+	return i, i < len(s.index) && s.index[i].key == key
+}
+
+// upsert inserts or replaces an index entry, keeping the index sorted.
+// New IDs are usually the newest, so the insert is usually an append.
+func (s *Store) upsert(e indexEntry) {
+	i, ok := s.find(e.key)
+	if ok {
+		s.index[i] = e
+		return
+	}
+	s.index = append(s.index, indexEntry{})
+	copy(s.index[i+1:], s.index[i:])
+	s.index[i] = e
+}
+
+func (s *Store) remove(key microsharduuid.MicroShardUUID) {
+	if i, ok := s.find(key); ok {
+		s.index = append(s.index[:i], s.index[i+1:]...)
+	}
+}
+
+func encodeRecord(key microsharduuid.MicroShardUUID, op byte, value []byte) []byte {
+	rec := make([]byte, recordHeaderLen+len(value)+recordTrailerLen)
+	copy(rec[0:16], key.Bytes())
+	rec[16] = op
+	binary.BigEndian.PutUint32(rec[17:21], uint32(len(value)))
+	copy(rec[recordHeaderLen:], value)
+
+	body := rec[:recordHeaderLen+len(value)]
+	binary.BigEndian.PutUint32(rec[len(body):], crc32.ChecksumIEEE(body))
+	return rec
+}
+
+// decodeRecord decodes the record at the start of data, returning its total size.
+// ok is false if data does not start with a complete, intact record.
+func decodeRecord(data []byte) (key microsharduuid.MicroShardUUID, op byte, value []byte, n int, ok bool) {
+	if len(data) < recordHeaderLen+recordTrailerLen {
+		return key, 0, nil, 0, false
+	}
+
+	size := int(binary.BigEndian.Uint32(data[17:21]))
+	n = recordHeaderLen + size + recordTrailerLen
+	if size < 0 || len(data) < n {
+		return key, 0, nil, 0, false
+	}
+
+	body := data[:recordHeaderLen+size]
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(data[len(body):n]) {
+		return key, 0, nil, 0, false
+	}
+
+	key = microsharduuid.MicroShardUUID{
+		High: binary.BigEndian.Uint64(data[0:8]),
+		Low:  binary.BigEndian.Uint64(data[8:16]),
+	}
+	return key, data[16], data[recordHeaderLen : recordHeaderLen+size], n, true
+}
@@ -0,0 +1,97 @@
+// Package expvarmetrics publishes MicroShard UUID counters through expvar,
+// for services that expose /debug/vars but do not run Prometheus.
+//
+// It lives outside the core package because importing expvar registers the
+// /debug/vars handler on http.DefaultServeMux. One call enables it:
+//
+//	expvarmetrics.Publish("msuuid")
+//
+// /debug/vars then shows a map such as
+//
+//	"msuuid": {"ids_generated": 1200, "generate_errors": 0, "parse_errors": 3,
+//	           "parse_errors_by_format": {"canonical": 3}, "entropy_reads": 2,
+//	           "entropy_errors": 0, "clock_rollbacks": 0}
+//
+// Issuance rates are the difference of ids_generated between two scrapes.
+package expvarmetrics
+
+import (
+	"expvar"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Metrics implements microsharduuid.Metrics with expvar counters.
+type Metrics struct {
+	vars *expvar.Map
+
+	generated      expvar.Int
+	generateErrors expvar.Int
+	parseErrors    expvar.Int
+	parseByFormat  expvar.Map
+	entropyReads   expvar.Int
+	entropyErrors  expvar.Int
+	clockRollbacks expvar.Int
+}
+
+var _ microsharduuid.Metrics = (*Metrics)(nil)
+
+// New creates unpublished counters. Publish them with expvar.Publish(name,
+// m.Vars()) and combine them with other sinks via microsharduuid.MultiMetrics.
+func New() *Metrics {
+	m := &Metrics{vars: new(expvar.Map).Init()}
+	m.parseByFormat.Init()
+	m.vars.Set("ids_generated", &m.generated)
+	m.vars.Set("generate_errors", &m.generateErrors)
+	m.vars.Set("parse_errors", &m.parseErrors)
+	m.vars.Set("parse_errors_by_format", &m.parseByFormat)
+	m.vars.Set("entropy_reads", &m.entropyReads)
+	m.vars.Set("entropy_errors", &m.entropyErrors)
+	m.vars.Set("clock_rollbacks", &m.clockRollbacks)
+	return m
+}
+
+// Publish creates the counters, publishes them under name, and installs them
+// with microsharduuid.SetMetrics. Like expvar.Publish, it panics if name is
+// already in use.
+func Publish(name string) *Metrics {
+	m := New()
+	expvar.Publish(name, m.vars)
+	microsharduuid.SetMetrics(m)
+	return m
+}
+
+// Vars returns the map holding all counters.
+func (m *Metrics) Vars() *expvar.Map {
+	return m.vars
+}
+
+// IDsGenerated implements microsharduuid.Metrics.
+func (m *Metrics) IDsGenerated(shardID uint32, n int) {
+	m.generated.Add(int64(n))
+}
+
+// GenerateFailed implements microsharduuid.Metrics.
+func (m *Metrics) GenerateFailed(shardID uint32, err error) {
+	m.generateErrors.Add(1)
+}
+
+// ParseFailed implements microsharduuid.Metrics.
+func (m *Metrics) ParseFailed(err *microsharduuid.ParseError) {
+	m.parseErrors.Add(1)
+	m.parseByFormat.Add(err.Format, 1)
+}
+
+// EntropyRead implements microsharduuid.Metrics.
+func (m *Metrics) EntropyRead(d time.Duration, err error) {
+	m.entropyReads.Add(1)
+	if err != nil {
+		m.entropyErrors.Add(1)
+	}
+}
+
+// ClockRollback implements microsharduuid.Metrics.
+func (m *Metrics) ClockRollback(shardID uint32, drift time.Duration) {
+	m.clockRollbacks.Add(1)
+}
@@ -0,0 +1,43 @@
+package expvarmetrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestPublish(t *testing.T) {
+	m := Publish("msuuid_test")
+	defer microsharduuid.SetMetrics(nil)
+
+	gen, _ := microsharduuid.NewGenerator(3)
+	gen.NewIDs(4)
+	microsharduuid.Generate(3)
+	microsharduuid.Parse("bad")
+
+	if expvar.Get("msuuid_test") != m.Vars() {
+		t.Fatal("Counters were not published")
+	}
+
+	var vars map[string]interface{}
+	if err := json.Unmarshal([]byte(m.Vars().String()), &vars); err != nil {
+		t.Fatalf("Invalid expvar JSON: %v", err)
+	}
+	if vars["ids_generated"] != float64(5) || vars["parse_errors"] != float64(1) {
+		t.Errorf("Unexpected counters: %v", vars)
+	}
+	byFormat, _ := vars["parse_errors_by_format"].(map[string]interface{})
+	if byFormat[microsharduuid.FormatCanonical] != float64(1) {
+		t.Errorf("Expected a canonical parse error, got %v", byFormat)
+	}
+}
+
+func TestNewIsUnpublished(t *testing.T) {
+	m := New()
+	m.IDsGenerated(1, 2)
+	if m.generated.Value() != 2 {
+		t.Errorf("Expected 2 IDs, got %d", m.generated.Value())
+	}
+}
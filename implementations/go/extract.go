@@ -0,0 +1,97 @@
+package microsharduuid
+
+import "time"
+
+// ==========================================
+// Fast-Path String Extraction
+// ==========================================
+
+// Positions of the fields in the canonical 8-4-4-4-12 string.
+const (
+	versionPos = 14 // Version nibble
+	variantPos = 19 // Top 2 bits are the Variant
+)
+
+// shardHighPos holds the 2 hex digits of High byte 7 (Shard High = low 6 bits).
+var shardHighPos = []int{16, 17}
+
+// shardLowPos holds the first 7 hex digits of Low (Variant + Shard Low).
+var shardLowPos = []int{19, 20, 21, 22, 24, 25, 26}
+
+// timePos holds Time High (12 digits) followed by the 3 digits after the
+// version nibble ([Time Low 6][Shard High 6]).
+var timePos = []int{0, 1, 2, 3, 4, 5, 6, 7, 9, 10, 11, 12, 15, 16, 17}
+
+// ShardIDFromString extracts the Shard ID directly from a canonical string,
+// decoding only the shard, version and variant digits instead of the full
+// ID. It does not allocate on canonical input. Routing tiers that only need
+// the shard should call it instead of Parse.
+//
+// Hex digits outside those positions are not checked; use IsValid or Parse
+// to validate the whole string. Non-canonical input (URN, braces, no dashes)
+// falls back to Parse.
+func ShardIDFromString(s string) (uint32, error) {
+	if hasCanonicalHeader(s) {
+		high, okHigh := decodeAt(s, shardHighPos)
+		low, okLow := decodeAt(s, shardLowPos)
+		if okHigh && okLow {
+			return uint32((high&0x3F)<<26 | low&0x3FFFFFF), nil
+		}
+	}
+	id, err := Parse(s)
+	return id.ShardID(), err
+}
+
+// MicrosFromString extracts the timestamp (Unix microseconds) directly from a
+// canonical string, decoding only the time, version and variant digits. Like
+// ShardIDFromString it does not validate the remaining digits and falls back
+// to Parse for non-canonical input. Useful for log enrichment and retention
+// tooling.
+func MicrosFromString(s string) (int64, error) {
+	if hasCanonicalHeader(s) {
+		if v, ok := decodeAt(s, timePos); ok {
+			// v = [Time High 48][Time Low 6][Shard High 6]
+			return int64((v>>12)<<6 | (v>>6)&0x3F), nil
+		}
+	}
+	id, err := Parse(s)
+	if err != nil {
+		return 0, err
+	}
+	return id.UnixMicro(), nil
+}
+
+// TimeFromString is MicrosFromString returning a time.Time (UTC).
+func TimeFromString(s string) (time.Time, error) {
+	micros, err := MicrosFromString(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMicro(micros).UTC(), nil
+}
+
+// hasCanonicalHeader reports whether s has the canonical layout with a valid
+// Version and Variant.
+func hasCanonicalHeader(s string) bool {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return false
+	}
+	if uint64(hexDecode[s[versionPos]]) != Version {
+		return false
+	}
+	v := uint64(hexDecode[s[variantPos]])
+	return v <= 0xF && v>>2 == Variant
+}
+
+// decodeAt decodes the hex digits at positions (at most 16) as one number.
+func decodeAt(s string, positions []int) (uint64, bool) {
+	var v uint64
+	for _, pos := range positions {
+		n := hexDecode[s[pos]]
+		if n > 0xF {
+			return 0, false
+		}
+		v = v<<4 | uint64(n)
+	}
+	return v, true
+}
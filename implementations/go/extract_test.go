@@ -0,0 +1,79 @@
+package microsharduuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShardIDFromString(t *testing.T) {
+	for _, shard := range []uint32{0, 1, 63, 64, 1 << 26, 500, MaxShardID} {
+		id, _ := Generate(shard)
+
+		for _, s := range []string{id.String(), strings.ToUpper(id.String()), "urn:uuid:" + id.String(), id.Hex()} {
+			got, err := ShardIDFromString(s)
+			if err != nil || got != shard {
+				t.Errorf("ShardIDFromString(%q) = %d, %v; expected %d", s, got, err, shard)
+			}
+		}
+	}
+
+	if _, err := ShardIDFromString("550e8400-e29b-41d4-a716-446655440000"); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("Expected ErrInvalidVersion, got %v", err)
+	}
+	if _, err := ShardIDFromString("not-a-uuid"); err == nil {
+		t.Error("Expected error for garbage input")
+	}
+}
+
+func TestShardIDFromStringAllocs(t *testing.T) {
+	str := MustGenerate(42).String()
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = ShardIDFromString(str)
+	})
+	if allocs != 0 {
+		t.Errorf("ShardIDFromString allocated %.0f times, expected 0", allocs)
+	}
+}
+
+func BenchmarkShardIDFromString(b *testing.B) {
+	str := MustGenerate(42).String()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = ShardIDFromString(str)
+	}
+}
+
+func TestTimeFromString(t *testing.T) {
+	ts := time.Date(2025, 12, 12, 1, 35, 0, 123456000, time.UTC)
+	for _, shard := range []uint32{0, 63, MaxShardID} {
+		id, _ := FromTime(ts, shard)
+
+		for _, s := range []string{id.String(), strings.ToUpper(id.String()), "{" + id.String() + "}"} {
+			micros, err := MicrosFromString(s)
+			if err != nil || micros != ts.UnixMicro() {
+				t.Errorf("MicrosFromString(%q) = %d, %v; expected %d", s, micros, err, ts.UnixMicro())
+			}
+			got, err := TimeFromString(s)
+			if err != nil || !got.Equal(ts) || got.Location() != time.UTC {
+				t.Errorf("TimeFromString(%q) = %v, %v; expected %v", s, got, err, ts)
+			}
+		}
+	}
+
+	if _, err := TimeFromString("550e8400-e29b-41d4-a716-446655440000"); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("Expected ErrInvalidVersion, got %v", err)
+	}
+	if _, err := MicrosFromString(""); err == nil {
+		t.Error("Expected error for empty input")
+	}
+
+	str := MustGenerate(1).String()
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = TimeFromString(str)
+	})
+	if allocs != 0 {
+		t.Errorf("TimeFromString allocated %.0f times, expected 0", allocs)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !go1.22
+
+package microsharduuid
+
+import "io"
+
+// newFastEntropy keeps the default crypto/rand source on toolchains
+// without math/rand/v2.
+func newFastEntropy() io.Reader {
+	return nil
+}
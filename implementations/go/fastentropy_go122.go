@@ -0,0 +1,93 @@
+//go:build go1.22
+
+package microsharduuid
+
+import (
+	"crypto/rand"
+	"io"
+	mrand "math/rand/v2"
+	"sync"
+)
+
+// fastEntropyReseedBytes is how much output a ChaCha8 seed may produce.
+const fastEntropyReseedBytes = 64 << 20
+
+// fastEntropy is an io.Reader over periodically reseeded ChaCha8 streams.
+// Streams are cached per P (sync.Pool), so concurrent generators never
+// contend on a lock; a stream dropped by the GC is simply replaced by a
+// freshly seeded one.
+type fastEntropy struct {
+	streams sync.Pool // *chachaStream
+}
+
+// chachaStream is one ChaCha8 stream, used by one goroutine at a time.
+type chachaStream struct {
+	rng  *mrand.ChaCha8
+	left int // Bytes until the next reseed
+}
+
+func newFastEntropy() io.Reader {
+	return &fastEntropy{}
+}
+
+func (f *fastEntropy) Read(p []byte) (int, error) {
+	c, err := f.get()
+	if err != nil {
+		return 0, err
+	}
+	defer f.streams.Put(c)
+
+	for n := 0; n < len(p); {
+		if err := c.reseedIfNeeded(); err != nil {
+			return n, err
+		}
+
+		v := c.rng.Uint64()
+		for i := 0; i < 8 && n < len(p); i++ {
+			p[n] = byte(v >> (8 * i))
+			n++
+		}
+		c.left -= 8
+	}
+	return len(p), nil
+}
+
+// random36 implements random36Source.
+func (f *fastEntropy) random36() (uint64, error) {
+	c, err := f.get()
+	if err != nil {
+		return 0, err
+	}
+	defer f.streams.Put(c)
+
+	if err := c.reseedIfNeeded(); err != nil {
+		return 0, err
+	}
+	c.left -= 8
+	return c.rng.Uint64() & MaxRandom, nil
+}
+
+// get takes a stream from the pool or seeds a new one.
+func (f *fastEntropy) get() (*chachaStream, error) {
+	if c, ok := f.streams.Get().(*chachaStream); ok {
+		return c, nil
+	}
+	c := &chachaStream{}
+	if err := c.reseedIfNeeded(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *chachaStream) reseedIfNeeded() error {
+	if c.left > 0 {
+		return nil
+	}
+	var seed [32]byte
+	if _, err := io.ReadFull(rand.Reader, seed[:]); err != nil {
+		return err
+	}
+	c.rng = mrand.NewChaCha8(seed)
+	c.left = fastEntropyReseedBytes
+	return nil
+}
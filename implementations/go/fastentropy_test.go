@@ -0,0 +1,80 @@
+//go:build go1.22
+
+package microsharduuid
+
+import "testing"
+
+func TestFastEntropy(t *testing.T) {
+	gen, _ := NewGenerator(3, WithFastEntropy())
+
+	seen := make(map[MicroShardUUID]bool)
+	for i := 0; i < 1000; i++ {
+		id, err := gen.NewID()
+		if err != nil {
+			t.Fatalf("NewID failed: %v", err)
+		}
+		if seen[id] {
+			t.Fatalf("Duplicate ID from fast entropy: %s", id)
+		}
+		seen[id] = true
+	}
+
+	ids, err := gen.NewIDs(100)
+	if err != nil || len(ids) != 100 {
+		t.Fatalf("NewIDs with fast entropy failed: %v", err)
+	}
+}
+
+func TestFastEntropyReseeds(t *testing.T) {
+	c := &chachaStream{}
+	if err := c.reseedIfNeeded(); err != nil {
+		t.Fatalf("Seeding failed: %v", err)
+	}
+	first := c.rng
+
+	c.left = 0
+	if err := c.reseedIfNeeded(); err != nil {
+		t.Fatalf("Reseeding failed: %v", err)
+	}
+	if c.rng == first {
+		t.Error("Exhausted seed budget should trigger a reseed")
+	}
+}
+
+func TestFastEntropyConcurrent(t *testing.T) {
+	f := newFastEntropy()
+	done := make(chan struct{})
+	for g := 0; g < 8; g++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			buf := make([]byte, 64)
+			for i := 0; i < 1000; i++ {
+				if _, err := f.Read(buf); err != nil {
+					t.Errorf("Read failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	for g := 0; g < 8; g++ {
+		<-done
+	}
+}
+
+func BenchmarkNewIDFastEntropy(b *testing.B) {
+	gen, _ := NewGenerator(1, WithFastEntropy())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = gen.NewID()
+	}
+}
+
+func BenchmarkNewIDFastEntropyParallel(b *testing.B) {
+	gen, _ := NewGenerator(1, WithFastEntropy())
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = gen.NewID()
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package microsharduuid
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+)
+
+// ==========================================
+// Alternative Text Formats
+// ==========================================
+
+// urnPrefix is the RFC 9562 URN namespace prefix.
+const urnPrefix = "urn:uuid:"
+
+// URN returns the URN representation required by several registries and XML standards.
+// Format: urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+func (u MicroShardUUID) URN() string {
+	return urnPrefix + u.String()
+}
+
+// trimURN strips a case-insensitive "urn:uuid:" prefix, if present.
+func trimURN(s string) string {
+	if len(s) >= len(urnPrefix) && strings.EqualFold(s[:len(urnPrefix)], urnPrefix) {
+		return s[len(urnPrefix):]
+	}
+	return s
+}
+
+// Braced returns the braced GUID representation emitted by Windows/.NET systems.
+// Format: {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}
+func (u MicroShardUUID) Braced() string {
+	return "{" + u.String() + "}"
+}
+
+// trimBraces strips a matching pair of surrounding curly braces, if present.
+func trimBraces(s string) string {
+	if len(s) >= 2 && s[0] == '{' && s[len(s)-1] == '}' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// Hex returns the 32-character lowercase hex representation without dashes
+// (the "simple" form used in many URLs and Mongo-style APIs).
+// Format: xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx
+func (u MicroShardUUID) Hex() string {
+	return hex.EncodeToString(u.Bytes())
+}
+
+// ParseHex converts a 32-character hex string (no dashes) into a MicroShardUUID.
+// Unlike Parse, dashes, braces, and prefixes are rejected.
+// It validates length, hex, Version (8), and Variant (2).
+func ParseHex(s string) (MicroShardUUID, error) {
+	if len(s) != 32 {
+		return MicroShardUUID{}, newParseError(FormatHex, len(s), ErrInvalidLength, "invalid hex length")
+	}
+
+	bytes, err := hex.DecodeString(s)
+	if err != nil {
+		return MicroShardUUID{}, newParseError(FormatHex, len(s), ErrInvalidHex, "invalid UUID hex")
+	}
+
+	high := binary.BigEndian.Uint64(bytes[0:8])
+	low := binary.BigEndian.Uint64(bytes[8:16])
+
+	return fromHighLow(high, low, FormatHex, len(s))
+}
+
+// ParseAny is a lenient parser that auto-detects the encoding, so ingestion
+// pipelines can normalize IDs from heterogeneous sources with one call.
+// Accepted forms (case-insensitive where the encoding allows it):
+//
+//   - Canonical:  xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+//   - Braced:     {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}
+//   - URN:        urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+//   - Hex:        xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx
+//   - Base32:     26 characters (see Base32)
+//   - Base58:     22 characters (see Base58)
+//   - Proquint:   53 characters (see Proquint)
+//
+// Surrounding whitespace is ignored.
+func ParseAny(s string) (MicroShardUUID, error) {
+	s = strings.TrimSpace(s)
+
+	switch len(s) {
+	case Base58Len:
+		return ParseBase58(s)
+	case Base32Len:
+		return ParseBase32(s)
+	case 32:
+		return ParseHex(s)
+	case ProquintLen:
+		return ParseProquint(s)
+	default:
+		return Parse(s)
+	}
+}
+
+// UnmarshalParam decodes a URL path or query parameter with ParseAny, so
+// IDs in any supported encoding bind directly to a MicroShardUUID. It
+// implements the BindUnmarshaler interface of Gin and Echo.
+func (u *MicroShardUUID) UnmarshalParam(param string) error {
+	id, err := ParseAny(param)
+	if err != nil {
+		return err
+	}
+	*u = id
+	return nil
+}
@@ -0,0 +1,134 @@
+package microsharduuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestURN(t *testing.T) {
+	original, _ := Generate(321)
+	urn := original.URN()
+
+	if urn != "urn:uuid:"+original.String() {
+		t.Errorf("Unexpected URN: %s", urn)
+	}
+
+	parsed, err := Parse(urn)
+	if err != nil {
+		t.Fatalf("Failed to parse URN: %v", err)
+	}
+	if parsed != original {
+		t.Errorf("URN roundtrip failed. Original %v != Parsed %v", original, parsed)
+	}
+
+	// The namespace prefix is case-insensitive
+	if _, err := Parse(strings.ToUpper(urn)); err != nil {
+		t.Errorf("Uppercase URN should parse: %v", err)
+	}
+
+	// Unknown namespaces are rejected
+	if _, err := Parse("urn:oid:" + original.String()); err == nil {
+		t.Error("Should have errored on a non-uuid URN namespace")
+	}
+}
+
+func TestBraced(t *testing.T) {
+	original, _ := Generate(654)
+	braced := original.Braced()
+
+	if braced != "{"+original.String()+"}" {
+		t.Errorf("Unexpected braced GUID: %s", braced)
+	}
+
+	parsed, err := Parse(braced)
+	if err != nil {
+		t.Fatalf("Failed to parse braced GUID: %v", err)
+	}
+	if parsed != original {
+		t.Errorf("Braced roundtrip failed. Original %v != Parsed %v", original, parsed)
+	}
+
+	// .NET emits uppercase hex
+	if _, err := Parse(strings.ToUpper(braced)); err != nil {
+		t.Errorf("Uppercase braced GUID should parse: %v", err)
+	}
+
+	// Unbalanced braces are rejected
+	if _, err := Parse("{" + original.String()); err == nil {
+		t.Error("Should have errored on unbalanced braces")
+	}
+}
+
+func TestHex(t *testing.T) {
+	original, _ := Generate(987)
+	simple := original.Hex()
+
+	if simple != strings.ReplaceAll(original.String(), "-", "") {
+		t.Errorf("Unexpected hex form: %s", simple)
+	}
+
+	parsed, err := ParseHex(simple)
+	if err != nil {
+		t.Fatalf("Failed to parse hex form: %v", err)
+	}
+	if parsed != original {
+		t.Errorf("Hex roundtrip failed. Original %v != Parsed %v", original, parsed)
+	}
+
+	// Strict: the canonical dashed form is rejected
+	if _, err := ParseHex(original.String()); err == nil {
+		t.Error("ParseHex should reject dashed input")
+	}
+
+	// Strict: dashes cannot replace hex digits either
+	if _, err := ParseHex("-" + simple[1:]); err == nil {
+		t.Error("ParseHex should reject dashes within 32 characters")
+	}
+}
+
+func TestParseAny(t *testing.T) {
+	original, _ := Generate(2024)
+
+	inputs := map[string]string{
+		"canonical": original.String(),
+		"uppercase": strings.ToUpper(original.String()),
+		"braced":    original.Braced(),
+		"urn":       original.URN(),
+		"hex":       original.Hex(),
+		"base32":    original.Base32(),
+		"base58":    original.Base58(),
+		"proquint":  original.Proquint(),
+		"padded":    "  " + original.String() + "\n",
+	}
+
+	for name, input := range inputs {
+		parsed, err := ParseAny(input)
+		if err != nil {
+			t.Errorf("ParseAny(%s) failed: %v", name, err)
+			continue
+		}
+		if parsed != original {
+			t.Errorf("ParseAny(%s) mismatch. Original %v != Parsed %v", name, original, parsed)
+		}
+	}
+
+	if _, err := ParseAny("not-an-id"); err == nil {
+		t.Error("ParseAny should reject unknown formats")
+	}
+}
+
+func TestUnmarshalParam(t *testing.T) {
+	original, _ := Generate(2024)
+
+	var got MicroShardUUID
+	if err := got.UnmarshalParam(original.Base58()); err != nil || got != original {
+		t.Errorf("UnmarshalParam(Base58) = %s, %v", got, err)
+	}
+	if err := got.UnmarshalParam("nope"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Expected ErrInvalidLength, got %v", err)
+	}
+	if got != original {
+		t.Error("A failed UnmarshalParam must not modify the receiver")
+	}
+}
@@ -0,0 +1,57 @@
+package microsharduuid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ==========================================
+// fmt.Formatter
+// ==========================================
+
+// Format implements fmt.Formatter:
+//
+//	%s, %v  canonical form (8-4-4-4-12)
+//	%q      quoted canonical form
+//	%x, %X  32 hex digits without dashes, lower/upper case
+//	%+v     canonical form followed by the decomposed fields
+//	%#v     Go syntax, e.g. microsharduuid.MicroShardUUID{High:0x..., Low:0x...}
+//
+// Width and the '-' flag pad the output as for strings.
+func (u MicroShardUUID) Format(f fmt.State, verb rune) {
+	var s string
+	switch verb {
+	case 's':
+		s = u.String()
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			s = fmt.Sprintf("microsharduuid.MicroShardUUID{High:%#x, Low:%#x}", u.High, u.Low)
+		case f.Flag('+'):
+			c := u.Decompose()
+			s = fmt.Sprintf("%s (time=%s shard=%d random=%#x version=%d variant=%d)",
+				u.String(), u.ISOTime(), c.ShardID, c.Random, c.Version, c.Variant)
+		default:
+			s = u.String()
+		}
+	case 'q':
+		s = strconv.Quote(u.String())
+	case 'x':
+		s = u.Hex()
+	case 'X':
+		s = strings.ToUpper(u.Hex())
+	default:
+		s = "%!" + string(verb) + "(microsharduuid.MicroShardUUID=" + u.String() + ")"
+	}
+
+	if width, ok := f.Width(); ok && width > len(s) {
+		padding := strings.Repeat(" ", width-len(s))
+		if f.Flag('-') {
+			s += padding
+		} else {
+			s = padding + s
+		}
+	}
+	_, _ = f.Write([]byte(s))
+}
@@ -0,0 +1,42 @@
+package microsharduuid
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	uuid := MustParse("018e2b4a-5c3d-8f01-8000-0001f2a3b4c5")
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%s", "018e2b4a-5c3d-8f01-8000-0001f2a3b4c5"},
+		{"%v", "018e2b4a-5c3d-8f01-8000-0001f2a3b4c5"},
+		{"%q", `"018e2b4a-5c3d-8f01-8000-0001f2a3b4c5"`},
+		{"%x", "018e2b4a5c3d8f0180000001f2a3b4c5"},
+		{"%X", "018E2B4A5C3D8F0180000001F2A3B4C5"},
+		{"%#v", "microsharduuid.MicroShardUUID{High:0x18e2b4a5c3d8f01, Low:0x80000001f2a3b4c5}"},
+		{"%40s", "    018e2b4a-5c3d-8f01-8000-0001f2a3b4c5"},
+		{"%-38s|", "018e2b4a-5c3d-8f01-8000-0001f2a3b4c5  |"},
+		{"%d", "%!d(microsharduuid.MicroShardUUID=018e2b4a-5c3d-8f01-8000-0001f2a3b4c5)"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, uuid); got != tt.want {
+			t.Errorf("Sprintf(%q) = %s, expected %s", tt.format, got, tt.want)
+		}
+	}
+
+	want := fmt.Sprintf("%s (time=%s shard=%d random=%#x version=8 variant=2)",
+		uuid, uuid.ISOTime(), uuid.ShardID(), uuid.Random())
+	if got := fmt.Sprintf("%+v", uuid); got != want {
+		t.Errorf("%%+v = %s, expected %s", got, want)
+	}
+
+	// Structs holding IDs print readable values too
+	holder := struct{ ID MicroShardUUID }{uuid}
+	if got := fmt.Sprintf("%v", holder); got != "{018e2b4a-5c3d-8f01-8000-0001f2a3b4c5}" {
+		t.Errorf("Nested %%v = %s", got)
+	}
+}
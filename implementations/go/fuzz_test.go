@@ -0,0 +1,163 @@
+//go:build go1.18
+
+package microsharduuid
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// ==========================================
+// Fuzz Targets
+// ==========================================
+//
+// Run one target at a time, e.g.:
+//
+//	go test -run xxx -fuzz FuzzParse -fuzztime 1m .
+//
+// Without -fuzz, the seeds below run as regular tests.
+
+// fuzzSeedIDs are valid IDs covering the field limits.
+var fuzzSeedIDs = []MicroShardUUID{
+	pack(0, 0, 0),
+	pack(MaxTime, MaxShardID, MaxRandom),
+	pack(1735689600123456, 42, 0x123456789),
+}
+
+// checkParseError fails unless err is a *ParseError wrapping one of the
+// parse sentinels.
+func checkParseError(t *testing.T, input interface{}, err error) {
+	t.Helper()
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Error for %q is not a *ParseError: %v", input, err)
+	}
+	if !errors.Is(err, ErrInvalidLength) && !errors.Is(err, ErrInvalidHex) && !errors.Is(err, ErrInvalidEncoding) &&
+		!errors.Is(err, ErrInvalidVersion) && !errors.Is(err, ErrInvalidVariant) {
+		t.Fatalf("Error for %q wraps no parse sentinel: %v", input, err)
+	}
+}
+
+// checkValidID fails unless id carries a valid Version and Variant and
+// survives a String round-trip.
+func checkValidID(t *testing.T, input string, id MicroShardUUID) {
+	t.Helper()
+	if !validFields(id.High, id.Low) {
+		t.Fatalf("%q parsed to %v with invalid version or variant", input, id)
+	}
+	again, err := Parse(id.String())
+	if err != nil || again != id {
+		t.Fatalf("String round-trip of %q failed: %v (%v)", input, again, err)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, id := range fuzzSeedIDs {
+		s := id.String()
+		f.Add(s)
+		f.Add(strings.ToUpper(s))
+		f.Add("urn:uuid:" + s)
+		f.Add("{" + s + "}")
+		f.Add(strings.ReplaceAll(s, "-", ""))
+		f.Add(s[:35])                                  // Truncated hex
+		f.Add(s[:8] + s[9:13] + "-" + s[8:9] + s[13:]) // Misplaced dash
+		f.Add(s[:14] + "4" + s[15:])                   // Bad version
+		f.Add(s[:19] + "c" + s[20:])                   // Bad variant
+		f.Add(s[:9] + "-" + s[9:])                     // Extra dash
+		f.Add(s + "0")
+	}
+	f.Add("")
+	f.Add("-")
+	f.Add("{}")
+	f.Add("urn:uuid:")
+	f.Add("zzzzzzzz-zzzz-8zzz-azzz-zzzzzzzzzzzz")
+	f.Add("--------------------------------------")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		id, err := Parse(s)
+		if IsValid(s) != (err == nil) {
+			t.Fatalf("IsValid(%q) disagrees with Parse error %v", s, err)
+		}
+		if err != nil {
+			checkParseError(t, s, err)
+		} else {
+			checkValidID(t, s, id)
+		}
+
+		// ParseStrict only accepts the exact output of String
+		if strict, err := ParseStrict(s); err == nil {
+			if strict.String() != s {
+				t.Fatalf("ParseStrict accepted non-canonical %q", s)
+			}
+			if strict != id {
+				t.Fatalf("ParseStrict and Parse disagree on %q", s)
+			}
+		} else {
+			checkParseError(t, s, err)
+		}
+
+		if lenient, err := ParseAny(s); err == nil {
+			checkValidID(t, s, lenient)
+		} else {
+			checkParseError(t, s, err)
+		}
+	})
+}
+
+func FuzzFromBytes(f *testing.F) {
+	for _, id := range fuzzSeedIDs {
+		b := id.Bytes()
+		f.Add(b)
+		f.Add(b[:15])
+		f.Add(append(b, 0))
+	}
+	f.Add([]byte{})
+	f.Add(make([]byte, 16))
+	f.Add(bytes.Repeat([]byte{0xFF}, 16))
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		id, err := FromBytes(b)
+		if err != nil {
+			checkParseError(t, b, err)
+			return
+		}
+		if !bytes.Equal(id.Bytes(), b) {
+			t.Fatalf("Bytes round-trip of %x gave %x", b, id.Bytes())
+		}
+		checkValidID(t, id.String(), id)
+	})
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	for _, id := range fuzzSeedIDs {
+		f.Add(id.micros(), id.ShardID(), id.Random())
+	}
+
+	f.Fuzz(func(t *testing.T, micros uint64, shard uint32, random uint64) {
+		micros &= MaxTime
+		random &= MaxRandom
+		id, err := FromParts(micros, shard, random)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.micros() != micros || id.ShardID() != shard || id.Random() != random {
+			t.Fatalf("Fields of %v do not match %d/%d/%d", id, micros, shard, random)
+		}
+
+		decoders := map[string]func() (MicroShardUUID, error){
+			"String":   func() (MicroShardUUID, error) { return ParseStrict(id.String()) },
+			"Bytes":    func() (MicroShardUUID, error) { return FromBytes(id.Bytes()) },
+			"Hex":      func() (MicroShardUUID, error) { return ParseHex(id.Hex()) },
+			"Base32":   func() (MicroShardUUID, error) { return ParseBase32(id.Base32()) },
+			"Base58":   func() (MicroShardUUID, error) { return ParseBase58(id.Base58()) },
+			"Proquint": func() (MicroShardUUID, error) { return ParseProquint(id.Proquint()) },
+		}
+		for name, decode := range decoders {
+			if got, err := decode(); err != nil || got != id {
+				t.Fatalf("%s round-trip of %v failed: %v (%v)", name, id, got, err)
+			}
+		}
+	})
+}
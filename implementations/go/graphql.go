@@ -0,0 +1,40 @@
+package microsharduuid
+
+import (
+	"fmt"
+	"io"
+)
+
+// ==========================================
+// GraphQL Scalar
+// ==========================================
+
+// MarshalGQL writes the ID as a quoted canonical string. Together with
+// UnmarshalGQL it implements the gqlgen graphql.Marshaler interfaces, so a
+// schema can declare the scalar and bind it in gqlgen.yml:
+//
+//	scalar MicroShardUUID
+//
+//	models:
+//	  MicroShardUUID:
+//	    model: github.com/dilipvamsi/microshard-uuid/implementations/go.MicroShardUUID
+func (u MicroShardUUID) MarshalGQL(w io.Writer) {
+	var buf [38]byte
+	b := u.appendCanonical(append(buf[:0], '"'))
+	_, _ = w.Write(append(b, '"'))
+}
+
+// UnmarshalGQL decodes a GraphQL input value with Parse. Inputs that are not
+// strings are rejected with a ParseError wrapping ErrInvalidEncoding.
+func (u *MicroShardUUID) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return newParseError(FormatCanonical, 0, ErrInvalidEncoding, fmt.Sprintf("GraphQL input must be a string, got %T", v))
+	}
+	id, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*u = id
+	return nil
+}
@@ -0,0 +1,37 @@
+package microsharduuid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestMarshalGQL(t *testing.T) {
+	id, _ := Generate(77)
+
+	var buf bytes.Buffer
+	id.MarshalGQL(&buf)
+	if buf.String() != `"`+id.String()+`"` {
+		t.Errorf("MarshalGQL wrote %s", buf.String())
+	}
+}
+
+func TestUnmarshalGQL(t *testing.T) {
+	original, _ := Generate(77)
+
+	var got MicroShardUUID
+	if err := got.UnmarshalGQL(original.String()); err != nil || got != original {
+		t.Errorf("UnmarshalGQL(canonical) = %s, %v", got, err)
+	}
+
+	var pe *ParseError
+	if err := got.UnmarshalGQL(42); !errors.As(err, &pe) || !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Expected a ParseError wrapping ErrInvalidEncoding for a non-string, got %v", err)
+	}
+	if err := got.UnmarshalGQL("not-an-id"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Expected ErrInvalidLength, got %v", err)
+	}
+	if got != original {
+		t.Error("A failed UnmarshalGQL must not modify the receiver")
+	}
+}
@@ -0,0 +1,69 @@
+package microsharduuid
+
+// ==========================================
+// Generation Hooks
+// ==========================================
+
+// Hook observes every ID issued by a Generator, e.g. to audit, count, or
+// replicate new IDs without wrapping the Generator.
+//
+// OnGenerate is called synchronously after each generation attempt: with the
+// new ID and a nil error on success, or with the zero ID and the error on
+// failure. Batches from NewIDs call it once per ID (or once with the error).
+// Hooks must be safe for concurrent use and should not block.
+type Hook interface {
+	OnGenerate(id MicroShardUUID, err error)
+}
+
+// HookFunc adapts a function to the Hook interface.
+type HookFunc func(id MicroShardUUID, err error)
+
+// OnGenerate calls f(id, err).
+func (f HookFunc) OnGenerate(id MicroShardUUID, err error) {
+	f(id, err)
+}
+
+// WithHooks appends hooks to the Generator's chain. Hooks run in the order
+// they were added, across repeated WithHooks options and Reconfigure calls.
+// Nil hooks are ignored.
+func WithHooks(hooks ...Hook) Option {
+	return func(c *generatorConfig) {
+		// Copy on append: the old configuration may still be in use
+		chain := make([]Hook, len(c.hooks), len(c.hooks)+len(hooks))
+		copy(chain, c.hooks)
+		for _, h := range hooks {
+			if h != nil {
+				chain = append(chain, h)
+			}
+		}
+		c.hooks = chain
+	}
+}
+
+// WithoutHooks removes all hooks (for Reconfigure).
+func WithoutHooks() Option {
+	return func(c *generatorConfig) {
+		c.hooks = nil
+	}
+}
+
+// runHooks passes one generation result to the hook chain.
+func (c *generatorConfig) runHooks(id MicroShardUUID, err error) {
+	for _, h := range c.hooks {
+		h.OnGenerate(id, err)
+	}
+}
+
+// runBatchHooks passes the result of a batch to the hook chain.
+func (c *generatorConfig) runBatchHooks(ids []MicroShardUUID, err error) {
+	if len(c.hooks) == 0 {
+		return
+	}
+	if err != nil {
+		c.runHooks(MicroShardUUID{}, err)
+		return
+	}
+	for _, id := range ids {
+		c.runHooks(id, nil)
+	}
+}
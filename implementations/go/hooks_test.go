@@ -0,0 +1,96 @@
+package microsharduuid
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// hookRecorder collects the IDs and errors passed to a hook.
+type hookRecorder struct {
+	mu   sync.Mutex
+	ids  []MicroShardUUID
+	errs []error
+}
+
+func (r *hookRecorder) OnGenerate(id MicroShardUUID, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.errs = append(r.errs, err)
+		return
+	}
+	r.ids = append(r.ids, id)
+}
+
+func TestHooks(t *testing.T) {
+	rec := &hookRecorder{}
+	gen, _ := NewGenerator(13, WithHooks(rec, nil))
+
+	id, _ := gen.NewID()
+	batch, _ := gen.NewIDs(3)
+	at, _ := gen.NewIDAt(time.Now())
+	other, _ := gen.NewIDForShard(14)
+
+	want := append([]MicroShardUUID{id}, batch...)
+	want = append(want, at, other)
+	if len(rec.ids) != len(want) {
+		t.Fatalf("Expected %d hook calls, got %d", len(want), len(rec.ids))
+	}
+	for i := range want {
+		if rec.ids[i] != want[i] {
+			t.Errorf("Hook call %d saw %s, expected %s", i, rec.ids[i], want[i])
+		}
+	}
+}
+
+func TestHooksSeeErrors(t *testing.T) {
+	rec := &hookRecorder{}
+	gen, _ := NewGenerator(13, WithHooks(rec), WithEntropy(failingReader{}))
+
+	gen.NewID()
+	gen.NewIDs(5)
+	if len(rec.errs) != 2 || len(rec.ids) != 0 {
+		t.Fatalf("Expected 2 failures and no IDs, got %d and %d", len(rec.errs), len(rec.ids))
+	}
+	var ge *GenerateError
+	if !errors.As(rec.errs[0], &ge) {
+		t.Errorf("Expected a GenerateError, got %v", rec.errs[0])
+	}
+}
+
+func TestHooksChainOrder(t *testing.T) {
+	var order []string
+	hook := func(name string) Hook {
+		return HookFunc(func(MicroShardUUID, error) { order = append(order, name) })
+	}
+
+	gen, _ := NewGenerator(13, WithHooks(hook("audit")), WithHooks(hook("replicate")))
+	before := gen.cfg()
+	gen.Reconfigure(WithHooks(hook("metrics")))
+	if len(before.hooks) != 2 {
+		t.Fatalf("Reconfigure modified the old hook chain: %d hooks", len(before.hooks))
+	}
+
+	gen.NewID()
+	if len(order) != 3 || order[0] != "audit" || order[1] != "replicate" || order[2] != "metrics" {
+		t.Errorf("Unexpected hook order %v", order)
+	}
+
+	order = nil
+	gen.Reconfigure(WithoutHooks())
+	gen.NewID()
+	if len(order) != 0 {
+		t.Errorf("WithoutHooks should clear the chain, got %v", order)
+	}
+}
+
+func TestHooksSeeDryRunIDs(t *testing.T) {
+	rec := &hookRecorder{}
+	gen, _ := NewGenerator(13, WithHooks(rec), WithDryRun())
+	gen.NewID()
+	if len(rec.ids) != 1 || !rec.ids[0].IsDryRun() {
+		t.Error("Hooks should see the final (dry-run) ID")
+	}
+}
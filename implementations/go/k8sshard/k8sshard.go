@@ -0,0 +1,186 @@
+// Package k8sshard derives the Shard ID of a pod from Kubernetes metadata,
+// so operators configure sharding purely through manifests.
+//
+// Two sources are supported:
+//
+//   - A downward API volume file of labels or annotations, where the shard is
+//     stored under a key such as "microshard.io/shard".
+//   - The ordinal suffix of a StatefulSet pod name ("ledger-7" -> 7), usually
+//     exposed through the POD_NAME environment variable.
+//
+// Mounting the annotations through the downward API:
+//
+//	volumes:
+//	  - name: podinfo
+//	    downwardAPI:
+//	      items:
+//	        - path: annotations
+//	          fieldRef: {fieldPath: metadata.annotations}
+//
+// Kubelet rewrites downward API files in place when annotations change, so a
+// Watcher can pick up a new shard without restarting the pod.
+package k8sshard
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultKey is the label/annotation key read by default.
+const DefaultKey = "microshard.io/shard"
+
+// ErrKeyNotFound is returned when the metadata file has no entry for the key.
+var ErrKeyNotFound = errors.New("k8sshard: key not found")
+
+// Range restricts valid shards to [Min, Max]. The zero value allows any shard.
+type Range struct {
+	Min, Max uint32
+}
+
+// Validate reports whether shard is inside r.
+func (r Range) Validate(shard uint32) error {
+	if r == (Range{}) {
+		return nil
+	}
+	if shard < r.Min || shard > r.Max {
+		return fmt.Errorf("k8sshard: shard %d outside allowed range [%d, %d]", shard, r.Min, r.Max)
+	}
+	return nil
+}
+
+// ReadShard reads the shard stored under key in a downward API labels or
+// annotations file (lines of the form key="value").
+func ReadShard(path, key string) (uint32, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 || line[:eq] != key {
+			continue
+		}
+
+		value, err := strconv.Unquote(line[eq+1:])
+		if err != nil {
+			value = line[eq+1:]
+		}
+		return parseShard(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, ErrKeyNotFound
+}
+
+// ShardFromPodName extracts the StatefulSet ordinal from a pod name ("ledger-7" -> 7).
+func ShardFromPodName(podName string) (uint32, error) {
+	dash := strings.LastIndexByte(podName, '-')
+	if dash < 0 || dash == len(podName)-1 {
+		return 0, fmt.Errorf("k8sshard: pod name %q has no ordinal suffix", podName)
+	}
+	return parseShard(podName[dash+1:])
+}
+
+// FromEnv resolves the shard from the StatefulSet ordinal in the POD_NAME environment variable.
+func FromEnv() (uint32, error) {
+	name := os.Getenv("POD_NAME")
+	if name == "" {
+		return 0, errors.New("k8sshard: POD_NAME is not set")
+	}
+	return ShardFromPodName(name)
+}
+
+func parseShard(s string) (uint32, error) {
+	v, err := strconv.ParseUint(strings.TrimSpace(s), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("k8sshard: invalid shard %q", s)
+	}
+	return uint32(v), nil
+}
+
+// ==========================================
+// Hot Reload
+// ==========================================
+
+// Watcher polls a downward API file and reports shard changes.
+type Watcher struct {
+	Path     string             // Downward API file
+	Key      string             // Label/annotation key (DefaultKey if empty)
+	Allowed  Range              // Valid shards (zero value = any)
+	Interval time.Duration      // Poll interval (10s if zero)
+	OnChange func(shard uint32) // Called with every new valid shard
+	OnError  func(err error)    // Called when the file is unreadable or invalid (optional)
+
+	mu    sync.RWMutex
+	shard uint32
+	ok    bool
+}
+
+// Load reads and validates the current shard once. Call it at startup to fail fast.
+func (w *Watcher) Load() (uint32, error) {
+	key := w.Key
+	if key == "" {
+		key = DefaultKey
+	}
+
+	shard, err := ReadShard(w.Path, key)
+	if err == nil {
+		err = w.Allowed.Validate(shard)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	w.mu.Lock()
+	changed := !w.ok || w.shard != shard
+	w.shard, w.ok = shard, true
+	w.mu.Unlock()
+
+	if changed && w.OnChange != nil {
+		w.OnChange(shard)
+	}
+	return shard, nil
+}
+
+// Shard returns the last valid shard and whether one has been loaded.
+func (w *Watcher) Shard() (uint32, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.shard, w.ok
+}
+
+// Run polls until ctx is canceled. Invalid updates are reported through
+// OnError and ignored, so a bad edit never replaces a good shard.
+func (w *Watcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.Load(); err != nil && w.OnError != nil {
+			w.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package k8sshard
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadShard(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "annotations")
+	writeFile(t, path, "app=\"ledger\"\nmicroshard.io/shard=\"42\"\n")
+
+	shard, err := ReadShard(path, DefaultKey)
+	if err != nil || shard != 42 {
+		t.Errorf("Expected shard 42, got %d (%v)", shard, err)
+	}
+
+	if _, err := ReadShard(path, "missing"); err != ErrKeyNotFound {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+
+	writeFile(t, path, "microshard.io/shard=\"not-a-number\"\n")
+	if _, err := ReadShard(path, DefaultKey); err == nil {
+		t.Error("Should have errored on invalid shard value")
+	}
+}
+
+func TestShardFromPodName(t *testing.T) {
+	if shard, err := ShardFromPodName("ledger-db-7"); err != nil || shard != 7 {
+		t.Errorf("Expected ordinal 7, got %d (%v)", shard, err)
+	}
+	for _, bad := range []string{"ledger", "ledger-", "ledger-x"} {
+		if _, err := ShardFromPodName(bad); err == nil {
+			t.Errorf("Should have errored on pod name %q", bad)
+		}
+	}
+}
+
+func TestWatcherHotReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "labels")
+	writeFile(t, path, "microshard.io/shard=\"1\"\n")
+
+	changes := make(chan uint32, 10)
+	errs := make(chan error, 10)
+	w := &Watcher{
+		Path:     path,
+		Allowed:  Range{Min: 1, Max: 10},
+		Interval: 5 * time.Millisecond,
+		OnChange: func(shard uint32) { changes <- shard },
+		OnError:  func(err error) { errs <- err },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	if got := <-changes; got != 1 {
+		t.Fatalf("Expected initial shard 1, got %d", got)
+	}
+
+	// Out of range updates are rejected and the old shard is kept
+	writeFile(t, path, "microshard.io/shard=\"99\"\n")
+	<-errs
+	if shard, _ := w.Shard(); shard != 1 {
+		t.Errorf("Invalid update replaced the shard: %d", shard)
+	}
+
+	writeFile(t, path, "microshard.io/shard=\"5\"\n")
+	select {
+	case got := <-changes:
+		if got != 5 {
+			t.Errorf("Expected reloaded shard 5, got %d", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Watcher did not pick up the new shard")
+	}
+}
@@ -0,0 +1,56 @@
+package microsharduuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ==========================================
+// KSUID Interop
+// ==========================================
+
+// KSUIDEpoch is the KSUID epoch (2014-05-13T16:53:20Z) as Unix seconds.
+const KSUIDEpoch = 1400000000
+
+// ToKSUID converts u into a 20-byte KSUID with the same second-level
+// timestamp, for Segment-style infrastructure. The 16-byte payload is
+//
+//	[sub-second micros 20] [zero 40] [Shard ID 32] [Random 36]
+//
+// so KSUIDs minted from IDs in the same second keep their order, and
+// FromKSUID with the original Shard ID returns u. KSUID consumers see the
+// shard only as payload.
+//
+// It fails with ErrUnrepresentable if u is outside the KSUID time range
+// (before KSUIDEpoch, or more than 2^32 seconds after it).
+func (u MicroShardUUID) ToKSUID() ([20]byte, error) {
+	var b [20]byte
+	micros := u.micros()
+	secs := micros / 1e6
+	if secs < KSUIDEpoch || secs-KSUIDEpoch > 0xFFFFFFFF {
+		return b, fmt.Errorf("%w: %s is outside the KSUID time range", ErrUnrepresentable, u.ISOTime())
+	}
+
+	binary.BigEndian.PutUint32(b[0:4], uint32(secs-KSUIDEpoch))
+	shard := uint64(u.ShardID())
+	binary.BigEndian.PutUint64(b[4:12], (micros%1e6)<<44|shard>>28)
+	binary.BigEndian.PutUint64(b[12:20], shard<<36|u.Random())
+	return b, nil
+}
+
+// FromKSUID converts a 20-byte KSUID into a MicroShardUUID for shardID. The
+// conversion is lossy:
+//   - the timestamp is kept to the second;
+//   - the top 20 payload bits are read as sub-second micros (as written by
+//     ToKSUID), with values above 999999 clamped to 999999, so time order holds;
+//   - the low 36 payload bits become the random bits;
+//   - the remaining 72 payload bits are discarded.
+func FromKSUID(b [20]byte, shardID uint32) (MicroShardUUID, error) {
+	secs := uint64(binary.BigEndian.Uint32(b[0:4])) + KSUIDEpoch
+	sub := binary.BigEndian.Uint64(b[4:12]) >> 44
+	if sub > 999999 {
+		sub = 999999
+	}
+	random := binary.BigEndian.Uint64(b[12:20]) & MaxRandom
+	return FromParts(secs*1e6+sub, shardID, random)
+}
@@ -0,0 +1,81 @@
+package microsharduuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKSUIDRoundTrip(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 999999000, time.UTC)
+	for _, shard := range []uint32{0, 1, 1 << 26, MaxShardID} {
+		id, _ := FromTime(ts, shard)
+		b, err := id.ToKSUID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if secs := int64(binary.BigEndian.Uint32(b[0:4])) + KSUIDEpoch; secs != ts.Unix() {
+			t.Errorf("KSUID time %d, expected %d", secs, ts.Unix())
+		}
+
+		back, err := FromKSUID(b, shard)
+		if err != nil || back != id {
+			t.Errorf("shard=%d: FromKSUID = %v, %v; expected %v", shard, back, err, id)
+		}
+	}
+}
+
+func TestKSUIDPayload(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 123456000, time.UTC)
+	for _, shard := range []uint32{0, 0xF0000000, MaxShardID} {
+		id, _ := FromParts(uint64(ts.UnixMicro()), shard, 0xABCDEF123)
+		b, _ := id.ToKSUID()
+		hi, lo := binary.BigEndian.Uint64(b[4:12]), binary.BigEndian.Uint64(b[12:20])
+		if sub := hi >> 44; sub != 123456 {
+			t.Errorf("shard=%#x: sub-second micros %d, expected 123456", shard, sub)
+		}
+		if zero := hi >> 4 & (1<<40 - 1); zero != 0 {
+			t.Errorf("shard=%#x: zero bits are %#x", shard, zero)
+		}
+		if got := uint32(hi<<28 | lo>>36); got != shard {
+			t.Errorf("Payload shard %#x, expected %#x", got, shard)
+		}
+		if random := lo & MaxRandom; random != 0xABCDEF123 {
+			t.Errorf("shard=%#x: payload random %#x", shard, random)
+		}
+	}
+}
+
+func TestKSUIDKeepsOrder(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	prev := [20]byte{}
+	for i := 0; i < 2000; i++ {
+		id, _ := FromTime(ts.Add(time.Duration(i)*time.Millisecond), uint32(2000-i))
+		b, _ := id.ToKSUID()
+		if string(b[:]) <= string(prev[:]) {
+			t.Fatalf("KSUID %d out of order", i)
+		}
+		prev = b
+	}
+}
+
+func TestKSUIDRange(t *testing.T) {
+	old, _ := FromTime(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), 1)
+	if _, err := old.ToKSUID(); !errors.Is(err, ErrUnrepresentable) {
+		t.Errorf("Before the KSUID epoch: expected ErrUnrepresentable, got %v", err)
+	}
+
+	// Foreign payloads: the sub-second field is clamped
+	foreign := [20]byte{0x12, 0x34, 0x56, 0x78}
+	for i := 4; i < 20; i++ {
+		foreign[i] = 0xFF
+	}
+	id, err := FromKSUID(foreign, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.UnixMicro()%1e6 != 999999 || id.ShardID() != 9 || id.Random() != MaxRandom {
+		t.Errorf("Unexpected conversion: %+v", id.Decompose())
+	}
+}
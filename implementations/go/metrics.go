@@ -0,0 +1,150 @@
+package microsharduuid
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// ==========================================
+// Metrics Hook
+// ==========================================
+
+// Metrics receives process-wide instrumentation events, e.g. to export them
+// to Prometheus (see contrib/msuuidprom). Install an implementation with
+// SetMetrics. Methods are called synchronously on the hot path, so they
+// must be fast and safe for concurrent use.
+//
+// Embed NopMetrics to implement only the events you need; methods added in
+// future releases will then default to no-ops.
+type Metrics interface {
+	// IDsGenerated is called after n IDs were generated for shardID.
+	IDsGenerated(shardID uint32, n int)
+	// GenerateFailed is called when generating IDs for shardID failed.
+	GenerateFailed(shardID uint32, err error)
+	// ParseFailed is called for every rejected input of the parsers.
+	ParseFailed(err *ParseError)
+	// EntropyRead is called after every read from an entropy source, i.e.
+	// every refill of the crypto/rand buffer or read of a WithEntropy reader.
+	EntropyRead(d time.Duration, err error)
+	// ClockRollback is called when a Generator's clock reads drift behind
+	// its latest reading, whatever the rollback policy.
+	ClockRollback(shardID uint32, drift time.Duration)
+}
+
+// NopMetrics implements Metrics with no-ops.
+type NopMetrics struct{}
+
+// IDsGenerated implements Metrics.
+func (NopMetrics) IDsGenerated(uint32, int) {}
+
+// GenerateFailed implements Metrics.
+func (NopMetrics) GenerateFailed(uint32, error) {}
+
+// ParseFailed implements Metrics.
+func (NopMetrics) ParseFailed(*ParseError) {}
+
+// EntropyRead implements Metrics.
+func (NopMetrics) EntropyRead(time.Duration, error) {}
+
+// ClockRollback implements Metrics.
+func (NopMetrics) ClockRollback(uint32, time.Duration) {}
+
+// MultiMetrics returns a Metrics that forwards every event to each of ms
+// (nil entries are skipped), e.g. to export to Prometheus and expvar at once.
+func MultiMetrics(ms ...Metrics) Metrics {
+	all := make(multiMetrics, 0, len(ms))
+	for _, m := range ms {
+		if m != nil {
+			all = append(all, m)
+		}
+	}
+	return all
+}
+
+type multiMetrics []Metrics
+
+func (mm multiMetrics) IDsGenerated(shardID uint32, n int) {
+	for _, m := range mm {
+		m.IDsGenerated(shardID, n)
+	}
+}
+
+func (mm multiMetrics) GenerateFailed(shardID uint32, err error) {
+	for _, m := range mm {
+		m.GenerateFailed(shardID, err)
+	}
+}
+
+func (mm multiMetrics) ParseFailed(err *ParseError) {
+	for _, m := range mm {
+		m.ParseFailed(err)
+	}
+}
+
+func (mm multiMetrics) EntropyRead(d time.Duration, err error) {
+	for _, m := range mm {
+		m.EntropyRead(d, err)
+	}
+}
+
+func (mm multiMetrics) ClockRollback(shardID uint32, drift time.Duration) {
+	for _, m := range mm {
+		m.ClockRollback(shardID, drift)
+	}
+}
+
+// metricsHolder wraps the installed Metrics, since atomic.Value cannot
+// store nil.
+type metricsHolder struct {
+	m Metrics
+}
+
+var currentMetrics atomic.Value // metricsHolder
+
+// SetMetrics installs m as the process-wide metrics sink. A nil m disables
+// instrumentation, which is the default.
+func SetMetrics(m Metrics) {
+	currentMetrics.Store(metricsHolder{m})
+}
+
+// loadMetrics returns the installed Metrics, or nil.
+func loadMetrics() Metrics {
+	h, _ := currentMetrics.Load().(metricsHolder)
+	return h.m
+}
+
+// observeGenerate reports the outcome of generating n IDs for shardID.
+func observeGenerate(shardID uint32, n int, err error) {
+	m := loadMetrics()
+	if m == nil {
+		return
+	}
+	if err != nil {
+		m.GenerateFailed(shardID, err)
+	} else if n > 0 {
+		m.IDsGenerated(shardID, n)
+	}
+}
+
+// parseFailed reports e and returns it.
+func parseFailed(e *ParseError) *ParseError {
+	if m := loadMetrics(); m != nil {
+		m.ParseFailed(e)
+	}
+	return e
+}
+
+// readEntropyFrom fills p from src, reporting the read latency.
+func readEntropyFrom(src io.Reader, p []byte) error {
+	m := loadMetrics()
+	if m == nil {
+		_, err := io.ReadFull(src, p)
+		return err
+	}
+
+	start := time.Now()
+	_, err := io.ReadFull(src, p)
+	m.EntropyRead(time.Since(start), err)
+	return err
+}
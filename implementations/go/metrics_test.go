@@ -0,0 +1,150 @@
+package microsharduuid
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics counts events per shard (other tests may run alongside,
+// so assertions only look at the shards they use).
+type recordingMetrics struct {
+	NopMetrics
+
+	mu          sync.Mutex
+	generated   map[uint32]int
+	failed      map[uint32]int
+	parseErrors []error
+	entropy     int
+	rollbacks   map[uint32]time.Duration
+}
+
+func installRecordingMetrics(t *testing.T) *recordingMetrics {
+	t.Helper()
+	m := &recordingMetrics{
+		generated: map[uint32]int{},
+		failed:    map[uint32]int{},
+		rollbacks: map[uint32]time.Duration{},
+	}
+	SetMetrics(m)
+	t.Cleanup(func() { SetMetrics(nil) })
+	return m
+}
+
+func (m *recordingMetrics) IDsGenerated(shardID uint32, n int) {
+	m.mu.Lock()
+	m.generated[shardID] += n
+	m.mu.Unlock()
+}
+
+func (m *recordingMetrics) GenerateFailed(shardID uint32, err error) {
+	m.mu.Lock()
+	m.failed[shardID]++
+	m.mu.Unlock()
+}
+
+func (m *recordingMetrics) ParseFailed(err *ParseError) {
+	m.mu.Lock()
+	m.parseErrors = append(m.parseErrors, err)
+	m.mu.Unlock()
+}
+
+func (m *recordingMetrics) EntropyRead(d time.Duration, err error) {
+	m.mu.Lock()
+	m.entropy++
+	m.mu.Unlock()
+}
+
+func (m *recordingMetrics) ClockRollback(shardID uint32, drift time.Duration) {
+	m.mu.Lock()
+	m.rollbacks[shardID] += drift
+	m.mu.Unlock()
+}
+
+func TestMetricsGenerate(t *testing.T) {
+	m := installRecordingMetrics(t)
+
+	gen, _ := NewGenerator(9001)
+	gen.NewID()
+	gen.NewIDs(10)
+	gen.NewIDAt(time.Now())
+	Generate(9002)
+	GenerateBatch(9002, 5)
+
+	failing, _ := NewGenerator(9003, WithEntropy(failingReader{}))
+	failing.NewID()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.generated[9001] != 12 || m.generated[9002] != 6 {
+		t.Errorf("Unexpected generated counts: %v", m.generated)
+	}
+	if m.failed[9003] != 1 || m.generated[9003] != 0 {
+		t.Errorf("Expected one failure for shard 9003, got %v", m.failed)
+	}
+	if m.entropy == 0 {
+		t.Error("Custom entropy reads should be reported")
+	}
+}
+
+func TestMetricsParseFailed(t *testing.T) {
+	m := installRecordingMetrics(t)
+
+	Parse("not-a-uuid")
+	ParseBase32("0630F6Q1W0G08000A4F1D3B8E1") // Wrong version
+	if IsValid("not-a-uuid") {
+		t.Fatal("IsValid accepted garbage")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.parseErrors) != 2 {
+		t.Fatalf("Expected 2 parse failures (IsValid is not counted), got %d", len(m.parseErrors))
+	}
+	if !errors.Is(m.parseErrors[0], ErrInvalidLength) {
+		t.Errorf("Expected ErrInvalidLength, got %v", m.parseErrors[0])
+	}
+}
+
+func TestMetricsClockRollback(t *testing.T) {
+	m := installRecordingMetrics(t)
+
+	// Reported under the default RollbackAllow policy as well
+	clock := &manualClock{micros: 1700000000000000}
+	gen, _ := NewGenerator(9004, WithClock(clock))
+	gen.NewID()
+	clock.set(1700000000000000 - 1500)
+	if _, err := gen.NewID(); err != nil {
+		t.Fatalf("RollbackAllow should not fail: %v", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.rollbacks[9004] != 1500*time.Microsecond {
+		t.Errorf("Expected a 1.5ms rollback, got %v", m.rollbacks[9004])
+	}
+}
+
+func TestMetricsDisabledAllocs(t *testing.T) {
+	SetMetrics(nil)
+	gen, _ := NewGenerator(1)
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = gen.NewID()
+	})
+	if allocs != 0 {
+		t.Errorf("NewID without metrics allocated %.1f times", allocs)
+	}
+}
+
+func TestMultiMetrics(t *testing.T) {
+	a := &recordingMetrics{generated: map[uint32]int{}}
+	b := &recordingMetrics{generated: map[uint32]int{}}
+	SetMetrics(MultiMetrics(a, nil, b))
+	t.Cleanup(func() { SetMetrics(nil) })
+
+	GenerateBatch(9005, 3)
+	if a.generated[9005] != 3 || b.generated[9005] != 3 {
+		t.Errorf("Every sink should see the batch, got %d and %d", a.generated[9005], b.generated[9005])
+	}
+}
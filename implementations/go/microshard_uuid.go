@@ -1,12 +1,11 @@
 package microsharduuid
 
 import (
-	"crypto/rand"
+	"context"
 	"encoding/binary"
-	"encoding/hex"
-	"errors"
 	"fmt"
-	"strings"
+	"io"
+	"sync/atomic"
 	"time"
 )
 
@@ -27,6 +26,27 @@ type MicroShardUUID struct {
 	Low  uint64
 }
 
+// Nil is the all-zero UUID (RFC 9562 Nil UUID). It sorts before every
+// MicroShardUUID and is the zero value of the type, so it marks unset IDs.
+var Nil = MicroShardUUID{}
+
+// Max is the all-ones UUID (RFC 9562 Max UUID). It sorts after every
+// MicroShardUUID, so [Nil, Max] bounds a full range scan.
+//
+// Neither sentinel is a valid version 8 ID: Parse and the other decoders reject them.
+var Max = MicroShardUUID{High: ^uint64(0), Low: ^uint64(0)}
+
+// IsNil reports whether u is the Nil UUID.
+func (u MicroShardUUID) IsNil() bool {
+	return u == Nil
+}
+
+// IsZero reports whether u is the zero value (the Nil UUID). It lets
+// encoding/json's omitzero option (Go 1.24+) skip unset IDs.
+func (u MicroShardUUID) IsZero() bool {
+	return u == Nil
+}
+
 // ==========================================
 // 1. Generation
 // ==========================================
@@ -34,25 +54,43 @@ type MicroShardUUID struct {
 // Generate creates a new MicroShardUUID using the current system time.
 func Generate(shardID uint32) (MicroShardUUID, error) {
 	if shardID > MaxShardID {
-		return MicroShardUUID{}, fmt.Errorf("shard ID must be between 0 and %d", MaxShardID)
+		return MicroShardUUID{}, errShardRange(shardID)
 	}
 
 	// 1. Time (Microseconds)
 	now := uint64(time.Now().UnixMicro())
 
 	// 2. Build
-	return buildUUID(now, shardID)
+	id, err := buildUUID(now, shardID, nil)
+	observeGenerate(shardID, 1, err)
+	return id, err
 }
 
 // FromTime creates a MicroShardUUID for a specific timestamp.
 // Useful for backfilling.
 func FromTime(ts time.Time, shardID uint32) (MicroShardUUID, error) {
 	if shardID > MaxShardID {
-		return MicroShardUUID{}, fmt.Errorf("shard ID must be between 0 and %d", MaxShardID)
+		return MicroShardUUID{}, errShardRange(shardID)
 	}
 
 	micros := uint64(ts.UnixMicro())
-	return buildUUID(micros, shardID)
+	id, err := buildUUID(micros, shardID, nil)
+	observeGenerate(shardID, 1, err)
+	return id, err
+}
+
+// FromParts assembles a MicroShardUUID from explicit components without
+// reading entropy. Useful for deterministic tests, codecs, and converters
+// that already hold the random bits.
+// micros must not exceed MaxTime and random must not exceed MaxRandom.
+func FromParts(micros uint64, shardID uint32, random uint64) (MicroShardUUID, error) {
+	if micros > MaxTime {
+		return MicroShardUUID{}, errTimeOverflow(shardID, micros)
+	}
+	if random > MaxRandom {
+		return MicroShardUUID{}, &GenerateError{ShardID: shardID, Micros: micros, Reason: ErrRandomOverflow.Error(), Err: ErrRandomOverflow}
+	}
+	return pack(micros, shardID, random), nil
 }
 
 // ==========================================
@@ -60,57 +98,74 @@ func FromTime(ts time.Time, shardID uint32) (MicroShardUUID, error) {
 // ==========================================
 
 // Parse converts a UUID string (standard 8-4-4-4-12 format) into a MicroShardUUID struct.
+// The URN form ("urn:uuid:<canonical>") and the braced GUID form ("{<canonical>}") are also accepted.
 // It validates format, length, Version (8), and Variant (2).
+//
+// Canonical 36-character input is decoded in place without allocating;
+// other forms go through a slower, lenient path.
 func Parse(uuidStr string) (MicroShardUUID, error) {
-	clean := strings.ReplaceAll(uuidStr, "-", "")
-	if len(clean) != 32 {
-		return MicroShardUUID{}, errors.New("invalid UUID length")
+	if high, low, ok := decodeCanonical(uuidStr); ok {
+		return fromHighLow(high, low, FormatCanonical, len(uuidStr))
 	}
+	return parseLenient(uuidStr)
+}
 
-	bytes, err := hex.DecodeString(clean)
-	if err != nil {
-		return MicroShardUUID{}, errors.New("invalid UUID hex")
-	}
-
-	high := binary.BigEndian.Uint64(bytes[0:8])
-	low := binary.BigEndian.Uint64(bytes[8:16])
-
-	// Validate Version (Bits 48-51 of High) => (High >> 12) & 0xF
-	// Wait, bits are: [TimeHigh 48][Ver 4]...
-	// High is 64 bits.
-	// Layout: 0-47 (TimeHigh), 48-51 (Ver), 52-57 (TimeLow), 58-63 (ShardHigh) -- NO, Big Endian reads left to right.
-	//
-	// Let's look at the bit packing in buildUUID:
-	// high64 := (timeHigh << 16) | (Version << 12) | ...
-	//
-	// Position 12 (from bottom) means bits 12-15.
-	// So (High >> 12) & 0xF is correct.
-	ver := (high >> 12) & 0xF
-	if ver != Version {
-		return MicroShardUUID{}, fmt.Errorf("invalid version: %d (expected %d)", ver, Version)
+// ParseStrict converts only the exact canonical form: 36 characters, dashes
+// at positions 8, 13, 18 and 23, and lowercase hex, as produced by String.
+// Use it at API boundaries that must reject non-canonical spellings of an ID
+// (uppercase, URN, braces, missing dashes) that Parse would accept.
+func ParseStrict(s string) (MicroShardUUID, error) {
+	if len(s) != 36 {
+		return MicroShardUUID{}, newParseError(FormatCanonical, len(s), ErrInvalidLength, "invalid UUID length")
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= 'A' && c <= 'F' {
+			return MicroShardUUID{}, newParseError(FormatCanonical, len(s), ErrInvalidEncoding, "non-canonical UUID (uppercase hex)")
+		}
 	}
+	high, low, ok := decodeCanonical(s)
+	if !ok {
+		return MicroShardUUID{}, newParseError(FormatCanonical, len(s), ErrInvalidHex, "invalid UUID hex or dash position")
+	}
+	return fromHighLow(high, low, FormatCanonical, len(s))
+}
 
-	// Validate Variant (Top 2 bits of Low)
-	// low64 := (Variant << 62) | ...
-	// So (Low >> 62) & 0x3
-	varnt := (low >> 62) & 0x3
-	if varnt != Variant {
-		return MicroShardUUID{}, fmt.Errorf("invalid variant: %d (expected %d)", varnt, Variant)
+// parseLenient strips URN prefixes, braces, and dashes anywhere before decoding.
+func parseLenient(uuidStr string) (MicroShardUUID, error) {
+	high, low, err := decodeDashed(trimBraces(trimURN(uuidStr)))
+	if err == ErrInvalidLength {
+		return MicroShardUUID{}, newParseError(FormatCanonical, len(uuidStr), ErrInvalidLength, "invalid UUID length")
+	}
+	if err != nil {
+		return MicroShardUUID{}, newParseError(FormatCanonical, len(uuidStr), ErrInvalidHex, "invalid UUID hex")
 	}
 
-	return MicroShardUUID{High: high, Low: low}, nil
+	// Validate Version (8) and Variant (2)
+	return fromHighLow(high, low, FormatCanonical, len(uuidStr))
+}
+
+// IsValid reports whether Parse would accept s, checking format, Version,
+// and Variant without allocating or building a MicroShardUUID. Use it on
+// request-validation hot paths that only need a yes/no.
+func IsValid(s string) bool {
+	high, low, ok := decodeCanonical(s)
+	if !ok {
+		var err error
+		if high, low, err = decodeDashed(trimBraces(trimURN(s))); err != nil {
+			return false
+		}
+	}
+	return validFields(high, low)
 }
 
 // String returns the standard canonical UUID string representation.
 // Format: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+// The only allocation is the returned string; use AppendText to format
+// into a reusable buffer without allocating.
 func (u MicroShardUUID) String() string {
-	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
-		u.High>>32,           // First 8 bytes (32 bits)
-		(u.High>>16)&0xFFFF,  // Next 2 bytes (16 bits)
-		u.High&0xFFFF,        // Next 2 bytes (16 bits)
-		u.Low>>48,            // Next 2 bytes (16 bits)
-		u.Low&0xFFFFFFFFFFFF, // Last 6 bytes (48 bits)
-	)
+	var buf [36]byte
+	u.encodeCanonical(&buf)
+	return string(buf[:])
 }
 
 // Bytes returns the raw 16-byte slice (Big Endian).
@@ -121,6 +176,25 @@ func (u MicroShardUUID) Bytes() []byte {
 	return buf
 }
 
+// FromBytes reconstructs a MicroShardUUID from its raw 16-byte Big Endian form,
+// e.g. as read from a BINARY(16) column or a wire protocol.
+// It validates length, Version (8), and Variant (2).
+func FromBytes(b []byte) (MicroShardUUID, error) {
+	if len(b) != 16 {
+		return MicroShardUUID{}, newParseError(FormatBytes, len(b), ErrInvalidLength, "invalid UUID byte length")
+	}
+
+	high := binary.BigEndian.Uint64(b[0:8])
+	low := binary.BigEndian.Uint64(b[8:16])
+
+	return fromHighLow(high, low, FormatBytes, len(b))
+}
+
+// FromArray is like FromBytes for a fixed-size [16]byte, so no length check is needed.
+func FromArray(a [16]byte) (MicroShardUUID, error) {
+	return FromBytes(a[:])
+}
+
 // ==========================================
 // 3. Extraction (Methods on Struct)
 // ==========================================
@@ -137,8 +211,33 @@ func (u MicroShardUUID) ShardID() uint32 {
 	return uint32((shardHigh << 26) | shardLow)
 }
 
+// Random extracts the 36 random bits.
+func (u MicroShardUUID) Random() uint64 {
+	// Low[35:0] is Random (36 bits)
+	return u.Low & MaxRandom
+}
+
+// VersionField extracts the 4-bit version field (8 for valid IDs).
+// It reads the bits as stored, so it also works on unvalidated values.
+func (u MicroShardUUID) VersionField() uint8 {
+	// High[15:12] is Version (4 bits)
+	return uint8((u.High >> 12) & 0xF)
+}
+
+// VariantField extracts the 2-bit variant field (2 for valid IDs,
+// DryRunVariant for dry-run IDs).
+func (u MicroShardUUID) VariantField() uint8 {
+	// Low[63:62] is Variant (2 bits)
+	return uint8(u.Low >> 62)
+}
+
 // Time extracts the timestamp as a standard Go time.Time object (UTC).
 func (u MicroShardUUID) Time() time.Time {
+	return time.UnixMicro(int64(u.micros())).UTC()
+}
+
+// micros extracts the raw 54-bit timestamp (Unix Microseconds).
+func (u MicroShardUUID) micros() uint64 {
 	// Logic:
 	// High[63:16] is Time High (48 bits)
 	// High[11:6]  is Time Low (6 bits)
@@ -146,9 +245,24 @@ func (u MicroShardUUID) Time() time.Time {
 	timeHigh := (u.High >> 16) & 0xFFFFFFFFFFFF
 	timeLow := (u.High >> 6) & 0x3F
 
-	micros := int64((timeHigh << 6) | timeLow)
+	return (timeHigh << 6) | timeLow
+}
 
-	return time.UnixMicro(micros).UTC()
+// UnixMicro returns the timestamp as Unix microseconds, without constructing
+// a time.Time. This is the full precision of the ID.
+func (u MicroShardUUID) UnixMicro() int64 {
+	return int64(u.micros())
+}
+
+// UnixMilli returns the timestamp as Unix milliseconds (truncated).
+func (u MicroShardUUID) UnixMilli() int64 {
+	return int64(u.micros() / 1000)
+}
+
+// UnixNano returns the timestamp as Unix nanoseconds. As with
+// time.Time.UnixNano, the result is undefined beyond the year 2262.
+func (u MicroShardUUID) UnixNano() int64 {
+	return int64(u.micros()) * 1000
 }
 
 // ISOTime extracts the timestamp as an ISO 8601 string.
@@ -156,59 +270,347 @@ func (u MicroShardUUID) ISOTime() string {
 	return u.Time().Format("2006-01-02T15:04:05.000000Z")
 }
 
+// Components holds every field encoded in a MicroShardUUID.
+type Components struct {
+	Time    time.Time // Timestamp (UTC, microsecond precision)
+	ShardID uint32    // 32-bit Shard ID
+	Random  uint64    // 36 random bits
+	Version uint8     // Version field (8)
+	Variant uint8     // Variant field (2, or DryRunVariant)
+}
+
+// Decompose extracts all fields in one call, for logging, auditing, and
+// inspection tooling.
+func (u MicroShardUUID) Decompose() Components {
+	return Components{
+		Time:    u.Time(),
+		ShardID: u.ShardID(),
+		Random:  u.Random(),
+		Version: u.VersionField(),
+		Variant: u.VariantField(),
+	}
+}
+
 // ==========================================
 // 4. Stateful Generator
 // ==========================================
 
 // Generator holds the configuration for a specific Shard ID.
+// It is safe for concurrent use, including concurrent Reconfigure calls.
+// All mutable state is updated with atomics, so NewID never takes a lock.
 type Generator struct {
-	shardID uint32
+	config    atomic.Value // *generatorConfig, replaced wholesale by Reconfigure
+	counter   counterState // Last tick in WithMonotonicCounter mode (64-bit aligned)
+	lastClock uint64       // Latest clock reading, for the rollback policy (atomic)
+}
+
+// generatorConfig is the immutable configuration of a Generator.
+type generatorConfig struct {
+	shardID         uint32
+	globalMonotonic bool
+	entropy         io.Reader // nil = buffered crypto/rand
+	dryRun          bool
+	chaos           *chaos // nil = no fault injection
+	clock           Clock  // nil = system clock
+	counter         bool   // Per-generator monotonic counter (WithMonotonicCounter)
+	rollback        RollbackPolicy
+	rollbackMaxWait time.Duration
+	watermark       *watermark      // nil = no persisted watermark
+	seeded          bool            // Set by WithSeed
+	hooks           []Hook          // Called after every generation (WithHooks)
+	allocation      *allocation     // nil = fixed Shard ID (WithAllocator)
+	shardSet        bool            // WithShardID was among the options being applied
+	lost            <-chan struct{} // Closed when the claim is lost; nil without an allocator
 }
 
 // NewGenerator creates a new Generator instance.
-func NewGenerator(defaultShardID uint32) (*Generator, error) {
+// Behavior can be customized with Options (e.g. WithGlobalMonotonic).
+// With WithAllocator, defaultShardID is ignored and the Shard ID is claimed
+// from the allocator before NewGenerator returns, which can block for as
+// long as the coordination service is unreachable; use NewGeneratorContext
+// to bound the wait.
+func NewGenerator(defaultShardID uint32, opts ...Option) (*Generator, error) {
+	return NewGeneratorContext(context.Background(), defaultShardID, opts...)
+}
+
+// NewGeneratorContext is NewGenerator with cancellation. ctx only bounds
+// the Allocator.Acquire call of WithAllocator; it fails with the error
+// Acquire returns once ctx is done.
+func NewGeneratorContext(ctx context.Context, defaultShardID uint32, opts ...Option) (*Generator, error) {
 	if defaultShardID > MaxShardID {
-		return nil, fmt.Errorf("shard ID must be between 0 and %d", MaxShardID)
+		return nil, errShardRange(defaultShardID)
 	}
-	return &Generator{shardID: defaultShardID}, nil
+	cfg := &generatorConfig{shardID: defaultShardID}
+	cfg.apply(opts)
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if cfg.allocation != nil {
+		shardID, lost, err := acquireShard(ctx, cfg.allocation.allocator)
+		if err != nil {
+			return nil, err
+		}
+		cfg.shardID, cfg.lost = shardID, lost
+	}
+
+	g := &Generator{}
+	g.config.Store(cfg)
+	return g, nil
 }
 
 // NewID generates a UUID using the configured Shard ID.
 func (g *Generator) NewID() (MicroShardUUID, error) {
-	now := uint64(time.Now().UnixMicro())
-	return buildUUID(now, g.shardID)
+	cfg := g.cfg()
+	return g.generate(context.Background(), cfg, cfg.shardID)
+}
+
+// NewIDContext is NewID with cancellation. It fails with ctx.Err() if ctx
+// is done before generation starts or while RollbackWait is waiting for the
+// clock to catch up.
+func (g *Generator) NewIDContext(ctx context.Context) (MicroShardUUID, error) {
+	cfg := g.cfg()
+	return g.generate(ctx, cfg, cfg.shardID)
+}
+
+// NewIDForShard generates a UUID for a one-off shardID (e.g. writing a
+// record on behalf of another tenant) with the Generator's clock, entropy,
+// rollback policy, watermark, and global monotonic mode. The
+// WithMonotonicCounter sequence only tracks the configured shard, so IDs
+// for other shards are not part of it.
+func (g *Generator) NewIDForShard(shardID uint32) (MicroShardUUID, error) {
+	return g.generate(context.Background(), g.cfg(), shardID)
+}
+
+// NewIDAt generates a UUID for an explicit timestamp (backfill) with the
+// Generator's Shard ID, entropy source, and dry-run setting. The clock,
+// ordering modes, rollback policy, and watermark do not apply, since the
+// caller picks the time.
+func (g *Generator) NewIDAt(ts time.Time) (MicroShardUUID, error) {
+	cfg := g.cfg()
+	if err := cfg.checkLease(); err != nil {
+		return MicroShardUUID{}, err
+	}
+	micros := ts.UnixMicro()
+	if micros < 0 {
+		return MicroShardUUID{}, &GenerateError{ShardID: cfg.shardID, Reason: "time is before the Unix epoch"}
+	}
+
+	src := cfg.entropy
+	if cfg.chaos != nil {
+		src = cfg.chaos.entropy(src)
+	}
+	id, err := buildUUID(uint64(micros), cfg.shardID, src)
+	if err == nil && cfg.dryRun {
+		id = id.markDryRun()
+	}
+	observeGenerate(cfg.shardID, 1, err)
+	cfg.runHooks(id, err)
+	return id, err
+}
+
+// generate runs the full NewID pipeline for shardID.
+func (g *Generator) generate(ctx context.Context, cfg *generatorConfig, shardID uint32) (MicroShardUUID, error) {
+	id, err := g.generateOne(ctx, cfg, shardID)
+	observeGenerate(shardID, 1, err)
+	cfg.runHooks(id, err)
+	return id, err
+}
+
+func (g *Generator) generateOne(ctx context.Context, cfg *generatorConfig, shardID uint32) (MicroShardUUID, error) {
+	now, src, err := g.sample(ctx, cfg)
+	if err != nil {
+		return MicroShardUUID{}, err
+	}
+	if cfg.globalMonotonic {
+		now = nextGlobalMicros(now)
+	}
+	var id MicroShardUUID
+	if cfg.useCounter() && shardID == cfg.shardID {
+		var one [1]MicroShardUUID
+		err = g.counter.next(now, shardID, one[:], src)
+		id = one[0]
+	} else {
+		id, err = buildUUID(now, shardID, src)
+	}
+	if err == nil && cfg.watermark != nil {
+		err = cfg.watermark.reserve(id.micros(), shardID)
+	}
+	if err != nil {
+		return MicroShardUUID{}, err
+	}
+	if cfg.dryRun {
+		id = id.markDryRun()
+	}
+	return id, nil
+}
+
+// ShardID returns the currently configured Shard ID.
+func (g *Generator) ShardID() uint32 {
+	return g.cfg().shardID
+}
+
+// Reconfigure applies opts on top of the current configuration, so long-lived
+// services can change settings (e.g. WithShardID after a shard reassignment)
+// without a restart. The new configuration replaces the old one atomically:
+// every ID is generated entirely under either the old or the new settings.
+// If the resulting configuration is invalid, it returns an error wrapping
+// ErrInvalidOption and keeps the current configuration.
+func (g *Generator) Reconfigure(opts ...Option) error {
+	for {
+		old := g.cfg()
+		next := *old
+		next.apply(opts)
+		if next.allocation != old.allocation {
+			return fmt.Errorf("%w: WithAllocator cannot be changed by Reconfigure", ErrInvalidOption)
+		}
+		if err := next.validate(); err != nil {
+			return err
+		}
+		if g.config.CompareAndSwap(old, &next) {
+			return nil
+		}
+	}
+}
+
+func (g *Generator) cfg() *generatorConfig {
+	return g.config.Load().(*generatorConfig)
+}
+
+// sample reads the clock and picks the entropy source for one NewID or NewIDs
+// call, applying chaos faults, the rollback policy, and the watermark floor.
+// It fails with ctx.Err() once ctx is done.
+func (g *Generator) sample(ctx context.Context, cfg *generatorConfig) (uint64, io.Reader, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, nil, err
+	}
+	if err := cfg.checkLease(); err != nil {
+		return 0, nil, err
+	}
+	now, err := nowMicros(cfg.clock, cfg.shardID)
+	if err != nil {
+		return 0, nil, err
+	}
+	src := cfg.entropy
+	if cfg.chaos != nil {
+		if now, src, err = cfg.chaos.inject(now, cfg.shardID, src); err != nil {
+			return 0, nil, err
+		}
+	}
+	if now, err = g.checkRollback(ctx, cfg, now); err != nil {
+		return 0, nil, err
+	}
+	if cfg.watermark != nil {
+		now, err = cfg.watermark.clamp(now, cfg.shardID)
+	}
+	return now, src, err
+}
+
+// useCounter reports whether IDs go through the per-generator counter.
+func (c *generatorConfig) useCounter() bool {
+	return c.counter || c.rollback == RollbackReuse
 }
 
 // ==========================================
 // Internal Helpers
 // ==========================================
 
-func getRandom36() (uint64, error) {
+// getRandom36 draws 36 random bits from src, or from the buffered
+// crypto/rand pool if src is nil.
+func getRandom36(src io.Reader) (uint64, error) {
+	switch r := src.(type) {
+	case nil:
+		return pooledRandom36()
+	case random36Source:
+		// Internal sources skip the io.Reader round-trip (and its allocation)
+		return r.random36()
+	}
+
 	// Read 5 bytes (40 bits)
 	b := make([]byte, 5)
-	_, err := rand.Read(b)
-	if err != nil {
+	if err := readEntropyFrom(src, b); err != nil {
 		return 0, err
 	}
+	return bytesToRandom36(b), nil
+}
+
+// bytesToRandom36 converts 5 bytes (40 bits) and masks them to 36 bits.
+func bytesToRandom36(b []byte) uint64 {
+	val := uint64(b[0])<<32 | uint64(b[1])<<24 | uint64(b[2])<<16 | uint64(b[3])<<8 | uint64(b[4])
+	return val & MaxRandom
+}
 
-	// Convert to uint64
-	fullBytes := append([]byte{0, 0, 0}, b...)
-	val := binary.BigEndian.Uint64(fullBytes)
+// fromHighLow validates the Version and Variant fields of a decoded
+// 128-bit value and wraps it in a MicroShardUUID.
+// format and inputLen are only used to describe failures.
+func fromHighLow(high, low uint64, format string, inputLen int) (MicroShardUUID, error) {
+	// Version lives in bits 12-15 of High
+	ver := (high >> 12) & 0xF
 
-	// Mask to 36 bits
-	return val & MaxRandom, nil
+	// Validate Variant (Top 2 bits of Low)
+	// low64 := (Variant << 62) | ...
+	// So (Low >> 62) & 0x3
+	varnt := (low >> 62) & 0x3
+
+	if !validFields(high, low) {
+		e := &ParseError{Format: format, InputLen: inputLen, Version: int(ver), Variant: int(varnt)}
+		if ver != Version {
+			e.Reason = fmt.Sprintf("invalid version: %d (expected %d)", ver, Version)
+			e.Err = ErrInvalidVersion
+		} else {
+			e.Reason = fmt.Sprintf("invalid variant: %d (expected %d)", varnt, Variant)
+			e.Err = ErrInvalidVariant
+		}
+		return MicroShardUUID{}, parseFailed(e)
+	}
+
+	return MicroShardUUID{High: high, Low: low}, nil
 }
 
-func buildUUID(micros uint64, shardID uint32) (MicroShardUUID, error) {
+// validFields reports whether the Version is 8 and the Variant is 2.
+func validFields(high, low uint64) bool {
+	return (high>>12)&0xF == Version && low>>62 == Variant
+}
+
+// withMicros returns a copy of u with the 54-bit timestamp replaced.
+// Version, Shard, Variant, and Random bits are preserved.
+func (u MicroShardUUID) withMicros(micros uint64) MicroShardUUID {
+	timeHigh := (micros >> 6) & 0xFFFFFFFFFFFF
+	timeLow := micros & 0x3F
+
+	// Clear bits 63-16 (Time High) and 11-6 (Time Low)
+	high := u.High &^ (0xFFFFFFFFFFFF<<16 | 0x3F<<6)
+	high |= (timeHigh << 16) | (timeLow << 6)
+
+	return MicroShardUUID{High: high, Low: u.Low}
+}
+
+// withShard returns a copy of u with the 32-bit Shard ID replaced.
+// Time, Version, Variant, and Random bits are preserved.
+func (u MicroShardUUID) withShard(shardID uint32) MicroShardUUID {
+	shardID64 := uint64(shardID)
+
+	high := u.High&^0x3F | (shardID64>>26)&0x3F
+	low := u.Low&^(0x3FFFFFF<<36) | (shardID64&0x3FFFFFF)<<36
+
+	return MicroShardUUID{High: high, Low: low}
+}
+
+// buildUUID generates an ID with random bits from src (nil = default pool).
+func buildUUID(micros uint64, shardID uint32, src io.Reader) (MicroShardUUID, error) {
 	if micros > MaxTime {
-		return MicroShardUUID{}, errors.New("time overflow (Year > 2541)")
+		return MicroShardUUID{}, errTimeOverflow(shardID, micros)
 	}
 
-	rnd, err := getRandom36()
+	rnd, err := getRandom36(src)
 	if err != nil {
-		return MicroShardUUID{}, err
+		return MicroShardUUID{}, &GenerateError{ShardID: shardID, Micros: micros, Reason: "entropy read failed", Err: err}
 	}
 
+	return pack(micros, shardID, rnd), nil
+}
+
+// pack assembles the 128-bit layout from already validated components.
+func pack(micros uint64, shardID uint32, rnd uint64) MicroShardUUID {
 	shardID64 := uint64(shardID)
 
 	// --- High 64 Bits ---
@@ -224,7 +626,7 @@ func buildUUID(micros uint64, shardID uint32) (MicroShardUUID, error) {
 	shardLow := shardID64 & 0x3FFFFFF
 	low64 := (Variant << 62) | (shardLow << 36) | rnd
 
-	return MicroShardUUID{High: high64, Low: low64}, nil
+	return MicroShardUUID{High: high64, Low: low64}
 }
 
 // ==========================================
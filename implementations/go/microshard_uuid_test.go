@@ -1,6 +1,8 @@
 package microsharduuid
 
 import (
+	"context"
+	"errors"
 	"sort"
 	"strings"
 	"testing"
@@ -59,6 +61,190 @@ func TestISOTime(t *testing.T) {
 	}
 }
 
+func TestUnixAccessors(t *testing.T) {
+	ts := time.Date(2025, 12, 12, 1, 35, 0, 123456000, time.UTC)
+	uuid, _ := FromTime(ts, 55)
+
+	if uuid.UnixMicro() != ts.UnixMicro() {
+		t.Errorf("UnixMicro mismatch. Expected %d, got %d", ts.UnixMicro(), uuid.UnixMicro())
+	}
+	if uuid.UnixMilli() != ts.UnixMilli() {
+		t.Errorf("UnixMilli mismatch. Expected %d, got %d", ts.UnixMilli(), uuid.UnixMilli())
+	}
+	if uuid.UnixNano() != ts.UnixNano() {
+		t.Errorf("UnixNano mismatch. Expected %d, got %d", ts.UnixNano(), uuid.UnixNano())
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = uuid.UnixMicro() + uuid.UnixMilli() + uuid.UnixNano()
+	})
+	if allocs != 0 {
+		t.Errorf("Unix accessors allocated %.0f times, expected 0", allocs)
+	}
+}
+
+func TestNilAndMax(t *testing.T) {
+	var unset MicroShardUUID
+	if !unset.IsNil() || !unset.IsZero() || !Nil.IsNil() {
+		t.Error("The zero value should be Nil")
+	}
+	if Max.IsNil() || Max.IsZero() {
+		t.Error("Max must not be Nil")
+	}
+	if Nil.String() != "00000000-0000-0000-0000-000000000000" || Max.String() != "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+		t.Errorf("Unexpected sentinel strings: %s, %s", Nil, Max)
+	}
+
+	id, _ := Generate(MaxShardID)
+	if !Nil.Before(id) || !Max.After(id) || id.IsNil() {
+		t.Error("Every ID must sort between Nil and Max")
+	}
+
+	if _, err := Parse(Nil.String()); err == nil {
+		t.Error("Nil is not a valid version 8 ID")
+	}
+}
+
+func TestFieldAccessors(t *testing.T) {
+	uuid, _ := FromParts(1700000000000000, 9, 0xABCDEF123)
+
+	if uuid.Random() != 0xABCDEF123 {
+		t.Errorf("Random mismatch. Expected abcdef123, got %x", uuid.Random())
+	}
+	if uuid.VersionField() != 8 || uuid.VariantField() != 2 {
+		t.Errorf("Expected version 8 / variant 2, got %d / %d", uuid.VersionField(), uuid.VariantField())
+	}
+	if Max.VersionField() != 15 || Max.VariantField() != 3 || Max.Random() != MaxRandom {
+		t.Error("Accessors must report raw bits of unvalidated values")
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	ts := time.Date(2025, 12, 12, 1, 35, 0, 123456000, time.UTC)
+	uuid, _ := FromParts(uint64(ts.UnixMicro()), 500, 42)
+
+	want := Components{Time: ts, ShardID: 500, Random: 42, Version: 8, Variant: 2}
+	if got := uuid.Decompose(); got != want {
+		t.Errorf("Decompose mismatch.\nExpected %+v\ngot      %+v", want, got)
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	id, _ := Generate(77)
+	canonical := id.String()
+
+	if parsed, err := ParseStrict(canonical); err != nil || parsed != id {
+		t.Fatalf("ParseStrict rejected canonical input %s: %v", canonical, err)
+	}
+
+	// Spellings Parse accepts but ParseStrict must reject
+	lenient := []string{
+		strings.ToUpper(canonical),
+		"urn:uuid:" + canonical,
+		"{" + canonical + "}",
+		strings.ReplaceAll(canonical, "-", ""),
+		canonical[:8] + canonical[9:13] + "-" + canonical[8:9] + canonical[13:], // Shifted dash
+	}
+	for _, s := range lenient {
+		if _, err := Parse(s); err != nil {
+			t.Errorf("Parse should accept %q: %v", s, err)
+		}
+		if _, err := ParseStrict(s); err == nil {
+			t.Errorf("ParseStrict accepted non-canonical input %q", s)
+		}
+	}
+
+	if _, err := ParseStrict("550e8400-e29b-41d4-a716-446655440000"); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("ParseStrict must validate the version, got %v", err)
+	}
+}
+
+func TestGeneratorNewIDAt(t *testing.T) {
+	ts := time.Date(2020, 2, 29, 12, 0, 0, 1000, time.UTC)
+	gen, _ := NewGenerator(12, WithEntropy(constantReader(0x11)), WithDryRun(), WithMonotonicCounter())
+
+	id, err := gen.NewIDAt(ts)
+	if err != nil {
+		t.Fatalf("NewIDAt failed: %v", err)
+	}
+	if !id.Time().Equal(ts) || id.ShardID() != 12 {
+		t.Errorf("Expected shard 12 at %v, got shard %d at %v", ts, id.ShardID(), id.Time())
+	}
+	if id.Random() != 0x111111111 || !id.IsDryRun() {
+		t.Error("NewIDAt must use the generator's entropy and dry-run settings")
+	}
+
+	if _, err := gen.NewIDAt(time.Unix(-1, 0)); err == nil {
+		t.Error("Expected error for a time before the Unix epoch")
+	}
+}
+
+func TestGeneratorNewIDContext(t *testing.T) {
+	gen, _ := NewGenerator(12)
+
+	id, err := gen.NewIDContext(context.Background())
+	if err != nil || id.ShardID() != 12 {
+		t.Fatalf("NewIDContext failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := gen.NewIDContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Canceled context should fail with context.Canceled, got %v", err)
+	}
+	if _, err := gen.NewIDsContext(ctx, 10); !errors.Is(err, context.Canceled) {
+		t.Errorf("Canceled context should fail NewIDsContext, got %v", err)
+	}
+}
+
+func TestGeneratorNewIDForShard(t *testing.T) {
+	fixed := time.UnixMicro(1700000000000000)
+	gen, _ := NewGenerator(1, WithClock(ClockFunc(func() time.Time { return fixed })), WithMonotonicCounter())
+
+	first, _ := gen.NewID()
+	other, err := gen.NewIDForShard(99)
+	if err != nil || other.ShardID() != 99 || !other.Time().Equal(fixed) {
+		t.Fatalf("Expected shard 99 from the generator's clock, got %s (%v)", other, err)
+	}
+
+	// One-off shards don't break the configured shard's sequence
+	next, _ := gen.NewID()
+	if !next.After(first) || next.Random()>>counterRandomBits != first.Random()>>counterRandomBits+1 {
+		t.Error("NewIDForShard must not advance the monotonic counter")
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	id, _ := Generate(5)
+	dry := MustGenerate(5).markDryRun()
+
+	valid := []string{id.String(), strings.ToUpper(id.String()), id.Hex(), "urn:uuid:" + id.String(), "{" + id.String() + "}"}
+	for _, s := range valid {
+		if !IsValid(s) {
+			t.Errorf("IsValid(%q) = false, expected true", s)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"123",
+		"550e8400-e29b-41d4-a716-446655440000", // v4
+		"550e8400-e29b-81d4-0716-446655440000", // Variant 0
+		dry.String(),                           // Variant 3
+		strings.Replace(id.String(), id.String()[0:1], "g", 1),
+		Nil.String(),
+		Max.String(),
+	}
+	for _, s := range invalid {
+		if IsValid(s) {
+			t.Errorf("IsValid(%q) = true, expected false", s)
+		}
+		if _, err := Parse(s); err == nil {
+			t.Errorf("IsValid and Parse disagree on %q", s)
+		}
+	}
+}
+
 func TestGeneratorStruct(t *testing.T) {
 	shard := uint32(777)
 	gen, err := NewGenerator(shard)
@@ -239,3 +425,51 @@ func TestLexicalSorting(t *testing.T) {
 		t.Error("Chronological string sorting failed. Old ID string should be lexically smaller.")
 	}
 }
+
+func TestFromParts(t *testing.T) {
+	uid, err := FromParts(1700000000000000, 42, 0xABCDEF012)
+	if err != nil {
+		t.Fatalf("FromParts failed: %v", err)
+	}
+	if uid.Time().UnixMicro() != 1700000000000000 || uid.ShardID() != 42 || uid.Low&MaxRandom != 0xABCDEF012 {
+		t.Errorf("FromParts lost data: %s", uid)
+	}
+	if _, err := Parse(uid.String()); err != nil {
+		t.Errorf("FromParts produced an invalid UUID: %v", err)
+	}
+
+	if _, err := FromParts(MaxTime+1, 1, 0); err == nil {
+		t.Error("Should have errored on time overflow")
+	}
+	if _, err := FromParts(0, 1, MaxRandom+1); err == nil {
+		t.Error("Should have errored on random overflow")
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	original, _ := Generate(77)
+
+	parsed, err := FromBytes(original.Bytes())
+	if err != nil {
+		t.Fatalf("FromBytes failed: %v", err)
+	}
+	if parsed != original {
+		t.Errorf("Bytes roundtrip failed. Original %v != Parsed %v", original, parsed)
+	}
+
+	var arr [16]byte
+	copy(arr[:], original.Bytes())
+	if parsed, err := FromArray(arr); err != nil || parsed != original {
+		t.Errorf("FromArray roundtrip failed: %v", err)
+	}
+
+	// Invalid length
+	if _, err := FromBytes(original.Bytes()[:15]); err == nil {
+		t.Error("Should have errored on invalid byte length")
+	}
+
+	// Invalid version (all zero bytes)
+	if _, err := FromArray([16]byte{}); err == nil {
+		t.Error("Should have errored on invalid version")
+	}
+}
@@ -0,0 +1,196 @@
+package microsharduuid
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGlobalMonotonicAcrossShards(t *testing.T) {
+	// Higher shard IDs sort after lower ones within the same microsecond,
+	// so alternate from a high shard to a low one to catch violations
+	high, _ := NewGenerator(MaxShardID, WithGlobalMonotonic())
+	low, _ := NewGenerator(0, WithGlobalMonotonic())
+
+	var last MicroShardUUID
+	for i := 0; i < 1000; i++ {
+		gen := high
+		if i%2 == 1 {
+			gen = low
+		}
+		id, err := gen.NewID()
+		if err != nil {
+			t.Fatalf("NewID failed: %v", err)
+		}
+		if !id.After(last) {
+			t.Fatalf("ID %d (%s) is not after the previous ID (%s)", i, id, last)
+		}
+		last = id
+	}
+}
+
+func TestGlobalMonotonicConcurrent(t *testing.T) {
+	const workers, perWorker = 8, 500
+
+	var mu sync.Mutex
+	var all []MicroShardUUID
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(shard uint32) {
+			defer wg.Done()
+			gen, _ := NewGenerator(shard, WithGlobalMonotonic())
+			ids := make([]MicroShardUUID, 0, perWorker)
+			for i := 0; i < perWorker; i++ {
+				id, _ := gen.NewID()
+				ids = append(ids, id)
+			}
+			mu.Lock()
+			all = append(all, ids...)
+			mu.Unlock()
+		}(uint32(w))
+	}
+	wg.Wait()
+
+	// Every ID must have its own timestamp
+	sort.Sort(ByTime(all))
+	for i := 1; i < len(all); i++ {
+		if all[i].Time().Equal(all[i-1].Time()) {
+			t.Fatalf("Duplicate timestamp across generators: %s and %s", all[i-1], all[i])
+		}
+	}
+}
+
+func TestMonotonicCounterSameMicrosecond(t *testing.T) {
+	fixed := ClockFunc(func() time.Time { return time.UnixMicro(1700000000000000) })
+	gen, _ := NewGenerator(3, WithMonotonicCounter(), WithClock(fixed))
+
+	prev, _ := gen.NewID()
+	for i := 0; i < 1000; i++ {
+		id, err := gen.NewID()
+		if err != nil {
+			t.Fatalf("NewID failed: %v", err)
+		}
+		if !id.After(prev) {
+			t.Fatalf("Counter mode must be strictly increasing within a microsecond (%d)", i)
+		}
+		if seq := id.Random() >> counterRandomBits; seq != prev.Random()>>counterRandomBits+1 || !id.Time().Equal(prev.Time()) {
+			t.Fatalf("Expected the sequence bits to count up within the microsecond, got %d at %v", seq, id.Time())
+		}
+		prev = id
+	}
+
+	ids, _ := gen.NewIDs(100)
+	if !ids[0].After(prev) || !IsSorted(ids) {
+		t.Error("NewIDs must continue the generator's sequence")
+	}
+
+	// 1001 IDs so far: the 1025th of the microsecond spills into the next one
+	spill := 1<<counterSeqBits - 1001
+	if last := ids[spill-1]; last.UnixMicro() != 1700000000000000 || last.Random()>>counterRandomBits != counterSeqMask {
+		t.Errorf("ID 1024 should close the microsecond, got %s", last)
+	}
+	if next := ids[spill]; next.UnixMicro() != 1700000000000001 || next.Random()>>counterRandomBits != 0 {
+		t.Errorf("ID 1025 should start the next microsecond, got %s", next)
+	}
+}
+
+func TestMonotonicCounterClockStepBack(t *testing.T) {
+	now := int64(1700000000000000)
+	clock := ClockFunc(func() time.Time { return time.UnixMicro(now) })
+	gen, _ := NewGenerator(3, WithMonotonicCounter(), WithClock(clock))
+
+	first, _ := gen.NewID()
+	now -= 5000000 // 5 seconds back
+	second, _ := gen.NewID()
+	if !second.After(first) {
+		t.Error("Counter mode must stay ordered when the clock steps back")
+	}
+}
+
+func TestMonotonicCounterOverflow(t *testing.T) {
+	gen, _ := NewGenerator(3, WithMonotonicCounter())
+	last, _ := FromParts(1700000000000000, 3, MaxRandom)
+	gen.counter.last = 1700000000000000<<counterSeqBits | counterSeqMask
+
+	gen.Reconfigure(WithClock(ClockFunc(func() time.Time { return last.Time() })))
+	id, _ := gen.NewID()
+	if id.Time().UnixMicro() != 1700000000000001 || !id.After(last) {
+		t.Errorf("Counter overflow should advance the timestamp, got %v", id.Time())
+	}
+}
+
+func TestMonotonicCounterAllocs(t *testing.T) {
+	gen, _ := NewGenerator(3, WithMonotonicCounter())
+	allocs := testing.AllocsPerRun(1000, func() {
+		_, _ = gen.NewID()
+	})
+	if allocs != 0 {
+		t.Errorf("Counter mode NewID should not allocate, got %.1f allocs", allocs)
+	}
+}
+
+func TestMonotonicCounterConcurrent(t *testing.T) {
+	const workers, perWorker = 8, 2000
+
+	gen, _ := NewGenerator(3, WithMonotonicCounter())
+	results := make([][]MicroShardUUID, workers)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			ids := make([]MicroShardUUID, 0, perWorker)
+			for i := 0; i < perWorker; i++ {
+				id, err := gen.NewID()
+				if err != nil {
+					t.Errorf("NewID failed: %v", err)
+					return
+				}
+				ids = append(ids, id)
+			}
+			results[w] = ids
+		}(w)
+	}
+	wg.Wait()
+
+	seen := make(map[MicroShardUUID]bool, workers*perWorker)
+	for _, ids := range results {
+		for i, id := range ids {
+			if seen[id] {
+				t.Fatalf("Duplicate ID across goroutines: %s", id)
+			}
+			seen[id] = true
+			// Each goroutine observes a strictly increasing sequence
+			if i > 0 && !id.After(ids[i-1]) {
+				t.Fatalf("ID %s is not after %s in the same goroutine", id, ids[i-1])
+			}
+		}
+	}
+}
+
+func BenchmarkNewIDParallel(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		opts []Option
+	}{
+		{"Default", nil},
+		{"Counter", []Option{WithMonotonicCounter()}},
+		{"Global", []Option{WithGlobalMonotonic()}},
+		{"RollbackError", []Option{WithRollbackPolicy(RollbackError)}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			gen, _ := NewGenerator(1, bm.opts...)
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_, _ = gen.NewID()
+				}
+			})
+		})
+	}
+}
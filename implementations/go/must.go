@@ -0,0 +1,35 @@
+package microsharduuid
+
+import "time"
+
+// ==========================================
+// Must Helpers
+// ==========================================
+
+// MustParse is like Parse but panics if the string cannot be parsed.
+// It simplifies safe initialization of package-level variables and test tables.
+func MustParse(uuidStr string) MicroShardUUID {
+	u, err := Parse(uuidStr)
+	if err != nil {
+		panic("microsharduuid: Parse(" + uuidStr + "): " + err.Error())
+	}
+	return u
+}
+
+// MustGenerate is like Generate but panics on error.
+func MustGenerate(shardID uint32) MicroShardUUID {
+	u, err := Generate(shardID)
+	if err != nil {
+		panic("microsharduuid: Generate: " + err.Error())
+	}
+	return u
+}
+
+// MustFromTime is like FromTime but panics on error.
+func MustFromTime(ts time.Time, shardID uint32) MicroShardUUID {
+	u, err := FromTime(ts, shardID)
+	if err != nil {
+		panic("microsharduuid: FromTime: " + err.Error())
+	}
+	return u
+}
@@ -0,0 +1,35 @@
+package microsharduuid
+
+import (
+	"testing"
+	"time"
+)
+
+func expectPanic(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s should have panicked", name)
+		}
+	}()
+	fn()
+}
+
+func TestMustHelpers(t *testing.T) {
+	uid := MustGenerate(12)
+	if uid.ShardID() != 12 {
+		t.Errorf("MustGenerate used wrong shard. Expected 12, got %d", uid.ShardID())
+	}
+
+	if MustParse(uid.String()) != uid {
+		t.Error("MustParse roundtrip failed")
+	}
+
+	ts := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if !MustFromTime(ts, 1).Time().Equal(ts) {
+		t.Error("MustFromTime used wrong time")
+	}
+
+	expectPanic(t, "MustParse", func() { MustParse("invalid") })
+	expectPanic(t, "MustFromTime", func() { MustFromTime(time.UnixMicro(int64(MaxTime+1)), 1) })
+}
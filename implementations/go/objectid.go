@@ -0,0 +1,61 @@
+package microsharduuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ==========================================
+// MongoDB ObjectID Interop
+// ==========================================
+
+// FromObjectID converts a 12-byte MongoDB ObjectID
+//
+//	[Unix seconds 4] [process 5] [counter 3]
+//
+// into a MicroShardUUID, keeping rough chronology for Mongo-to-SQL
+// migrations. A bson primitive.ObjectID converts to [12]byte directly.
+//
+// The timestamp is kept to the second (sub-second micros are zero).
+// shardOf maps the 5 process bytes (machine and process ID in older
+// drivers, a per-process random value in newer ones) to the Shard ID, e.g.
+// with a lookup table of known hosts, or ObjectIDShard for ObjectIDs made by
+// ToObjectID. The random bits are the low 12 process bits followed by the
+// 24-bit counter, so ObjectIDs from one process in one second stay distinct
+// and ordered.
+func FromObjectID(oid [12]byte, shardOf func(process [5]byte) uint32) (MicroShardUUID, error) {
+	var process [5]byte
+	copy(process[:], oid[4:9])
+
+	secs := uint64(binary.BigEndian.Uint32(oid[0:4]))
+	random := binary.BigEndian.Uint64(oid[4:12]) & MaxRandom
+	return FromParts(secs*1e6, shardOf(process), random)
+}
+
+// ToObjectID converts u into a 12-byte MongoDB ObjectID. The conversion is
+// lossy: the timestamp is truncated to the second, the process bytes hold
+// the Shard ID followed by random bits 24-31, and the counter holds random
+// bits 0-23. The top 4 random bits are dropped, so IDs from the same shard
+// and second can collide, and are no longer ordered within that second.
+//
+// It fails with ErrUnrepresentable after 2106-02-07, when the 32-bit seconds
+// field overflows.
+func (u MicroShardUUID) ToObjectID() ([12]byte, error) {
+	var oid [12]byte
+	secs := u.micros() / 1e6
+	if secs > 0xFFFFFFFF {
+		return oid, fmt.Errorf("%w: %s is outside the ObjectID time range", ErrUnrepresentable, u.ISOTime())
+	}
+
+	binary.BigEndian.PutUint32(oid[0:4], uint32(secs))
+	binary.BigEndian.PutUint32(oid[4:8], u.ShardID())
+	oid[8] = byte(u.Random() >> 24)
+	oid[9], oid[10], oid[11] = byte(u.Random()>>16), byte(u.Random()>>8), byte(u.Random())
+	return oid, nil
+}
+
+// ObjectIDShard reads the Shard ID that ToObjectID stores in the first 4
+// process bytes. Pass it to FromObjectID to convert such ObjectIDs back.
+func ObjectIDShard(process [5]byte) uint32 {
+	return binary.BigEndian.Uint32(process[0:4])
+}
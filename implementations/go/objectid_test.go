@@ -0,0 +1,48 @@
+package microsharduuid
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFromObjectID(t *testing.T) {
+	raw, _ := hex.DecodeString("507f1f77bcf86cd799439011")
+	var oid [12]byte
+	copy(oid[:], raw)
+
+	hosts := map[[5]byte]uint32{{0xbc, 0xf8, 0x6c, 0xd7, 0x99}: 17}
+	id, err := FromObjectID(oid, func(process [5]byte) uint32 { return hosts[process] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Time() != time.Unix(0x507f1f77, 0).UTC() {
+		t.Errorf("Time %s, expected %s", id.Time(), time.Unix(0x507f1f77, 0).UTC())
+	}
+	if id.ShardID() != 17 || id.Random() != 0x799439011 {
+		t.Errorf("Unexpected shard %d / random %#x", id.ShardID(), id.Random())
+	}
+}
+
+func TestObjectIDRoundTrip(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 123456000, time.UTC)
+	id, _ := FromTime(ts, MaxShardID-1)
+
+	oid, err := id.ToObjectID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := FromObjectID(oid, ObjectIDShard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Time() != ts.Truncate(time.Second) || back.ShardID() != id.ShardID() || back.Random()&0xFFFFFFFF != id.Random()&0xFFFFFFFF {
+		t.Errorf("Round trip %+v, expected second, shard, and low random bits of %+v", back.Decompose(), id.Decompose())
+	}
+
+	far, _ := FromTime(time.Date(2107, 1, 1, 0, 0, 0, 0, time.UTC), 1)
+	if _, err := far.ToObjectID(); !errors.Is(err, ErrUnrepresentable) {
+		t.Errorf("Past 2106: expected ErrUnrepresentable, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package microsharduuid
+
+import (
+	"fmt"
+	"io"
+)
+
+// ==========================================
+// Generator Options
+// ==========================================
+
+// Option configures a Generator. Pass options to NewGenerator or Reconfigure,
+// which validate the combined result, so new features arrive as new options
+// without changing constructor signatures.
+type Option func(*generatorConfig)
+
+// apply runs opts in order; later options override earlier ones.
+func (c *generatorConfig) apply(opts []Option) {
+	c.shardSet = false
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+}
+
+// validate rejects configurations that options cannot check on their own.
+func (c *generatorConfig) validate() error {
+	if c.rollback < RollbackAllow || c.rollback > RollbackReuse {
+		return fmt.Errorf("%w: unknown rollback policy %d", ErrInvalidOption, int(c.rollback))
+	}
+	if c.seeded && c.globalMonotonic {
+		return fmt.Errorf("%w: WithSeed is not deterministic with WithGlobalMonotonic", ErrInvalidOption)
+	}
+	if c.allocation != nil && c.shardSet {
+		return fmt.Errorf("%w: WithShardID conflicts with the Shard ID claimed by WithAllocator", ErrInvalidOption)
+	}
+	if c.allocation != nil && c.allocation.allocator == nil {
+		return fmt.Errorf("%w: nil allocator", ErrInvalidOption)
+	}
+	if c.chaos != nil {
+		if err := c.chaos.cfg.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MonotonicMode selects the ordering guarantee of a Generator (see WithMonotonic).
+type MonotonicMode int
+
+const (
+	// MonotonicOff orders IDs by timestamp only; IDs in the same
+	// microsecond are in random order (default).
+	MonotonicOff MonotonicMode = iota
+	// MonotonicCounter is WithMonotonicCounter.
+	MonotonicCounter
+	// MonotonicGlobal is WithGlobalMonotonic.
+	MonotonicGlobal
+)
+
+// WithMonotonic sets the ordering guarantee in one option, replacing any
+// earlier WithMonotonicCounter or WithGlobalMonotonic.
+func WithMonotonic(mode MonotonicMode) Option {
+	return func(c *generatorConfig) {
+		c.counter = mode == MonotonicCounter
+		c.globalMonotonic = mode == MonotonicGlobal
+	}
+}
+
+// WithShardID sets the Shard ID. Mainly useful with Reconfigure, e.g. when a
+// shard assignment changes at runtime; NewGenerator takes the initial shard
+// as its first argument.
+func WithShardID(shardID uint32) Option {
+	return func(c *generatorConfig) {
+		c.shardID = shardID
+		c.shardSet = true
+	}
+}
+
+// WithGlobalMonotonic makes the Generator share a process-wide clock with
+// every other Generator created with this option, so IDs issued by any of
+// them (regardless of shard) are strictly increasing in issue order.
+// See nextGlobalMicros for the throughput trade-off.
+func WithGlobalMonotonic() Option {
+	return func(c *generatorConfig) {
+		c.globalMonotonic = true
+	}
+}
+
+// WithoutGlobalMonotonic reverts WithGlobalMonotonic (for Reconfigure).
+func WithoutGlobalMonotonic() Option {
+	return func(c *generatorConfig) {
+		c.globalMonotonic = false
+	}
+}
+
+// WithMonotonicCounter guarantees that IDs from this Generator are strictly
+// increasing, even within one microsecond or when the clock steps back.
+//
+// The 36 random bits are split: the top 10 hold a sequence number within
+// the microsecond (0 for the first ID, then counting up) and the low 26 are
+// fresh entropy for every ID. A Generator therefore issues at most 1024 IDs
+// per microsecond; the 1025th carries the next microsecond, so under
+// sustained load beyond that rate timestamps run ahead of the clock until
+// it catches up. The sequence is shared by every shard the Generator is
+// reconfigured to, but not by IDs from NewIDForShard for other shards.
+//
+// The guarantee is per Generator; use WithGlobalMonotonic for ordering
+// across Generators. Disable it with WithoutMonotonicCounter.
+func WithMonotonicCounter() Option {
+	return func(c *generatorConfig) {
+		c.counter = true
+	}
+}
+
+// WithoutMonotonicCounter reverts WithMonotonicCounter (for Reconfigure).
+func WithoutMonotonicCounter() Option {
+	return func(c *generatorConfig) {
+		c.counter = false
+	}
+}
+
+// WithEntropy draws random bits from r instead of crypto/rand, e.g. a
+// hardware RNG, a FIPS-validated module, or a deterministic reader in tests.
+// r must be safe for concurrent use if the Generator is shared between
+// goroutines. A nil r restores the default buffered crypto/rand source.
+//
+// Each ID reads 5 bytes from r; NewIDs reads all of a batch in one call.
+// Read errors are returned as a *GenerateError wrapping the reader's error.
+func WithEntropy(r io.Reader) Option {
+	return func(c *generatorConfig) {
+		c.entropy = r
+	}
+}
+
+// WithFastEntropy draws random bits from a ChaCha8 CSPRNG (math/rand/v2)
+// that is reseeded from crypto/rand every 64 MiB of output, instead of
+// reading OS entropy for every batch of IDs. It trades strict per-ID OS
+// entropy for throughput; the output is still unpredictable without the seed.
+//
+// Requires Go 1.22+. On older toolchains this option keeps the default
+// crypto/rand source.
+func WithFastEntropy() Option {
+	return func(c *generatorConfig) {
+		c.entropy = newFastEntropy()
+	}
+}
+
+// WithDryRun marks every generated ID as a dry-run ID (see IsDryRun), so a
+// staged rollout of a new shard configuration can run through production
+// pipelines and be filtered out downstream. Disable it with WithoutDryRun.
+func WithDryRun() Option {
+	return func(c *generatorConfig) {
+		c.dryRun = true
+	}
+}
+
+// WithoutDryRun reverts WithDryRun (for Reconfigure).
+func WithoutDryRun() Option {
+	return func(c *generatorConfig) {
+		c.dryRun = false
+	}
+}
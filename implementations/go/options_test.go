@@ -0,0 +1,129 @@
+package microsharduuid
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestReconfigure(t *testing.T) {
+	gen, _ := NewGenerator(1)
+
+	gen.Reconfigure(WithShardID(2))
+	if gen.ShardID() != 2 {
+		t.Errorf("Expected shard 2 after Reconfigure, got %d", gen.ShardID())
+	}
+
+	id, _ := gen.NewID()
+	if id.ShardID() != 2 {
+		t.Errorf("NewID used stale shard %d", id.ShardID())
+	}
+
+	// Options not passed to Reconfigure are retained
+	gen.Reconfigure(WithGlobalMonotonic())
+	if gen.ShardID() != 2 || !gen.cfg().globalMonotonic {
+		t.Error("Reconfigure must layer options on the current configuration")
+	}
+	gen.Reconfigure(WithoutGlobalMonotonic())
+	if gen.cfg().globalMonotonic {
+		t.Error("WithoutGlobalMonotonic should disable global monotonic mode")
+	}
+}
+
+func TestReconfigureConcurrent(t *testing.T) {
+	gen, _ := NewGenerator(0)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func(shard uint32) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				gen.Reconfigure(WithShardID(shard))
+			}
+		}(uint32(w))
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				if id, err := gen.NewID(); err != nil || id.ShardID() > 3 {
+					t.Errorf("Unexpected ID during reconfiguration: %s (%v)", id, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWithEntropy(t *testing.T) {
+	gen, _ := NewGenerator(1, WithEntropy(constantReader(0xAB)))
+
+	id, err := gen.NewID()
+	if err != nil {
+		t.Fatalf("NewID failed: %v", err)
+	}
+	// 36 bits of 0xAB bytes
+	if got := id.Low & MaxRandom; got != 0xBABABABAB {
+		t.Errorf("Expected random bits from the injected reader, got %x", got)
+	}
+
+	gen.Reconfigure(WithEntropy(failingReader{}))
+	_, err = gen.NewID()
+	var genErr *GenerateError
+	if !errors.As(err, &genErr) || genErr.Err == nil {
+		t.Fatalf("Expected GenerateError wrapping the reader error, got %v", err)
+	}
+	if _, err := gen.NewIDs(5); err == nil {
+		t.Error("NewIDs should surface entropy errors too")
+	}
+
+	// nil restores crypto/rand
+	gen.Reconfigure(WithEntropy(nil))
+	if _, err := gen.NewID(); err != nil {
+		t.Errorf("Default entropy should be restored: %v", err)
+	}
+}
+
+func TestOptionValidation(t *testing.T) {
+	invalid := [][]Option{
+		{WithRollbackPolicy(RollbackPolicy(42))},
+		{WithSeed(1), WithGlobalMonotonic()},
+		{WithChaos(ChaosConfig{LeaseExpiryProbability: 1.5})},
+	}
+	for i, opts := range invalid {
+		if _, err := NewGenerator(1, opts...); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("Case %d: expected ErrInvalidOption, got %v", i, err)
+		}
+	}
+
+	// Reconfigure rejects invalid results and keeps the old configuration
+	gen, _ := NewGenerator(1, WithSeed(1))
+	if err := gen.Reconfigure(WithShardID(2), WithGlobalMonotonic()); !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("Expected ErrInvalidOption from Reconfigure, got %v", err)
+	}
+	if gen.ShardID() != 1 || gen.cfg().globalMonotonic {
+		t.Error("A rejected Reconfigure must not change the configuration")
+	}
+
+	// nil options are ignored
+	if _, err := NewGenerator(1, nil); err != nil {
+		t.Errorf("nil options should be ignored: %v", err)
+	}
+}
+
+func TestWithMonotonic(t *testing.T) {
+	gen, _ := NewGenerator(1, WithMonotonic(MonotonicCounter))
+	if cfg := gen.cfg(); !cfg.counter || cfg.globalMonotonic {
+		t.Error("MonotonicCounter should enable the counter only")
+	}
+
+	gen.Reconfigure(WithMonotonic(MonotonicGlobal))
+	if cfg := gen.cfg(); cfg.counter || !cfg.globalMonotonic {
+		t.Error("MonotonicGlobal should replace the counter")
+	}
+
+	gen.Reconfigure(WithMonotonic(MonotonicOff))
+	if cfg := gen.cfg(); cfg.counter || cfg.globalMonotonic {
+		t.Error("MonotonicOff should disable both modes")
+	}
+}
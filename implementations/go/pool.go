@@ -0,0 +1,208 @@
+package microsharduuid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ==========================================
+// Pre-generation Pool
+// ==========================================
+
+// ErrPoolClosed is returned by Pool.Get after Close.
+var ErrPoolClosed = errors.New("pool is closed")
+
+const (
+	// DefaultPoolSize is the number of IDs a Pool buffers by default.
+	DefaultPoolSize = 1024
+	// DefaultPoolMaxAge is how old a buffered ID may get by default.
+	DefaultPoolMaxAge = 100 * time.Millisecond
+)
+
+// poolRetryDelay is how long the refill goroutine pauses after a
+// generation error before trying again.
+const poolRetryDelay = 10 * time.Millisecond
+
+// PoolConfig controls a Pool.
+type PoolConfig struct {
+	Size   int           // IDs buffered ahead of demand (DefaultPoolSize if zero)
+	MaxAge time.Duration // Oldest ID handed out (DefaultPoolMaxAge if zero)
+}
+
+// Pool pre-generates IDs in a background goroutine, so latency-critical
+// paths dequeue an ID instead of reading the clock and entropy.
+//
+// Buffered IDs carry the time they were generated, not the time they are
+// handed out. IDs older than MaxAge (by the Generator's clock) are
+// discarded, which bounds how far a pooled ID can sort behind IDs issued
+// directly by other generators. IDs generated for a Shard ID that was since
+// changed with Reconfigure are discarded as well.
+//
+// Get never blocks: when the buffer is empty it falls back to NewID.
+type Pool struct {
+	cutoff uint64 // Oldest usable timestamp in Unix Microseconds (atomic)
+	gen    *Generator
+	maxAge uint64 // Microseconds
+	ids    chan MicroShardUUID
+
+	cancel    context.CancelFunc
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewPool starts a Pool that buffers IDs from g. Call Close to stop the
+// refill goroutine.
+func NewPool(g *Generator, cfg PoolConfig) (*Pool, error) {
+	if cfg.Size < 0 || cfg.MaxAge < 0 {
+		return nil, fmt.Errorf("%w: negative pool size or max age", ErrInvalidOption)
+	}
+	if cfg.Size == 0 {
+		cfg.Size = DefaultPoolSize
+	}
+	if cfg.MaxAge == 0 {
+		cfg.MaxAge = DefaultPoolMaxAge
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		gen:    g,
+		maxAge: uint64(cfg.MaxAge / time.Microsecond),
+		ids:    make(chan MicroShardUUID, cfg.Size),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go p.refill(ctx, cfg.MaxAge)
+	return p, nil
+}
+
+// Get returns a buffered ID, or a freshly generated one if the buffer is
+// empty. It fails with ErrPoolClosed after Close.
+func (p *Pool) Get() (MicroShardUUID, error) {
+	select {
+	case <-p.done:
+		return MicroShardUUID{}, ErrPoolClosed
+	default:
+	}
+
+	cfg := p.gen.cfg()
+	for {
+		select {
+		case id := <-p.ids:
+			if p.usable(cfg, id) {
+				return id, nil
+			}
+		default:
+			return p.gen.NewID()
+		}
+	}
+}
+
+// Len returns the number of buffered IDs.
+func (p *Pool) Len() int {
+	return len(p.ids)
+}
+
+// Close stops the refill goroutine and waits for it to exit. Buffered IDs
+// are dropped. It is safe to call Close more than once.
+func (p *Pool) Close() error {
+	p.closeOnce.Do(func() {
+		p.cancel()
+		<-p.done
+		for len(p.ids) > 0 {
+			<-p.ids
+		}
+	})
+	return nil
+}
+
+// refill keeps the buffer full until ctx is canceled.
+//
+// Every maxAge/2 it publishes a new cutoff, so Get can check staleness
+// without reading the clock: an ID at the cutoff is at most maxAge old by
+// the time the next cutoff is published. It also drops stale IDs then, so
+// an idle pool does not serve a burst of expired IDs when traffic resumes.
+func (p *Pool) refill(ctx context.Context, maxAge time.Duration) {
+	defer close(p.done)
+
+	interval := maxAge / 2
+	if interval <= 0 {
+		interval = maxAge
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	p.updateCutoff()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.updateCutoff()
+		default:
+		}
+
+		id, err := p.gen.NewIDContext(ctx)
+		if err != nil {
+			if ctx.Err() != nil || sleepContext(ctx, poolRetryDelay) != nil {
+				return
+			}
+			continue
+		}
+
+	send:
+		for {
+			select {
+			case p.ids <- id:
+				break send
+			case <-ticker.C:
+				p.updateCutoff()
+				p.dropStale()
+				if !p.usable(p.gen.cfg(), id) {
+					// Our pending ID expired too; generate a fresh one
+					break send
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// dropStale discards expired IDs from the head of the buffer. IDs are
+// buffered in generation order, so it stops at the first usable one (which
+// is discarded as well, to keep the buffer in order).
+func (p *Pool) dropStale() {
+	cfg := p.gen.cfg()
+	for {
+		select {
+		case id := <-p.ids:
+			if p.usable(cfg, id) {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// updateCutoff publishes the oldest timestamp Get may hand out until the
+// next update (half of maxAge from now).
+func (p *Pool) updateCutoff() {
+	cfg := p.gen.cfg()
+	now, err := nowMicros(cfg.clock, cfg.shardID)
+	if err != nil {
+		return
+	}
+	var cutoff uint64
+	if half := p.maxAge / 2; now > half {
+		cutoff = now - half
+	}
+	atomic.StoreUint64(&p.cutoff, cutoff)
+}
+
+// usable reports whether a buffered id may still be handed out.
+func (p *Pool) usable(cfg *generatorConfig, id MicroShardUUID) bool {
+	return id.ShardID() == cfg.shardID && id.micros() >= atomic.LoadUint64(&p.cutoff)
+}
@@ -0,0 +1,115 @@
+package microsharduuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond for up to a second.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPoolGet(t *testing.T) {
+	gen, _ := NewGenerator(21, WithMonotonicCounter())
+	pool, err := NewPool(gen, PoolConfig{Size: 64})
+	if err != nil {
+		t.Fatalf("NewPool failed: %v", err)
+	}
+	defer pool.Close()
+
+	waitFor(t, "the pool to fill", func() bool { return pool.Len() == 64 })
+
+	seen := make(map[MicroShardUUID]bool)
+	for i := 0; i < 500; i++ {
+		id, err := pool.Get()
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if id.ShardID() != 21 || seen[id] {
+			t.Fatalf("Unexpected pooled ID %s", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestPoolDiscardsStale(t *testing.T) {
+	clock := &manualClock{micros: 1700000000000000}
+	gen, _ := NewGenerator(21, WithClock(clock))
+	pool, _ := NewPool(gen, PoolConfig{Size: 16, MaxAge: time.Millisecond})
+	defer pool.Close()
+
+	waitFor(t, "the pool to fill", func() bool { return pool.Len() == 16 })
+	clock.set(1700000000000000 + 5000)
+	time.Sleep(5 * time.Millisecond) // Let the pool publish a new cutoff
+
+	id, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if id.Time().UnixMicro() < 1700000000000000+4000 {
+		t.Errorf("Pool handed out a stale ID from %v", id.Time())
+	}
+}
+
+func TestPoolDiscardsOldShard(t *testing.T) {
+	gen, _ := NewGenerator(21)
+	pool, _ := NewPool(gen, PoolConfig{Size: 16})
+	defer pool.Close()
+
+	waitFor(t, "the pool to fill", func() bool { return pool.Len() == 16 })
+	gen.Reconfigure(WithShardID(22))
+
+	if id, _ := pool.Get(); id.ShardID() != 22 {
+		t.Errorf("Pool handed out an ID for the old shard: %d", id.ShardID())
+	}
+}
+
+func TestPoolClose(t *testing.T) {
+	gen, _ := NewGenerator(21)
+	pool, _ := NewPool(gen, PoolConfig{})
+
+	pool.Close()
+	pool.Close()
+	if _, err := pool.Get(); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Expected ErrPoolClosed, got %v", err)
+	}
+	if pool.Len() != 0 {
+		t.Errorf("Close should drop buffered IDs, %d left", pool.Len())
+	}
+}
+
+func TestPoolInvalidConfig(t *testing.T) {
+	gen, _ := NewGenerator(21)
+	if _, err := NewPool(gen, PoolConfig{Size: -1}); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption, got %v", err)
+	}
+}
+
+func BenchmarkPoolGet(b *testing.B) {
+	const size = 4096
+	gen, _ := NewGenerator(1)
+	pool, _ := NewPool(gen, PoolConfig{Size: size, MaxAge: time.Minute})
+	defer pool.Close()
+
+	// Only time dequeues from a full buffer, not the NewID fallback
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if i%size == 0 {
+			b.StopTimer()
+			for pool.Len() < size {
+				time.Sleep(100 * time.Microsecond)
+			}
+			b.StartTimer()
+		}
+		_, _ = pool.Get()
+	}
+}
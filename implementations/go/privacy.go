@@ -0,0 +1,50 @@
+package microsharduuid
+
+import "time"
+
+// ==========================================
+// Privacy-Preserving Exports
+// ==========================================
+
+// CoarsenTime returns a copy of id whose embedded timestamp is rounded down
+// to a multiple of granularity (e.g. time.Hour), for exports where exact
+// creation times are sensitive. Shard and Random bits are preserved, so the
+// result still sorts correctly at the chosen granularity and stays unique.
+//
+// Granularities of one microsecond or less return id unchanged.
+func CoarsenTime(id MicroShardUUID, granularity time.Duration) MicroShardUUID {
+	step := uint64(granularity / time.Microsecond)
+	if step <= 1 {
+		return id
+	}
+
+	micros := id.micros()
+	return id.withMicros(micros - micros%step)
+}
+
+// MaskShard returns a copy of id with all but the top keepBits bits of the
+// Shard ID zeroed, so exported datasets only reveal coarse shard groupings
+// (k-anonymity). For example keepBits=8 collapses 2^24 shards into each group.
+//
+// keepBits >= 32 returns id unchanged; keepBits = 0 zeroes the whole shard.
+func MaskShard(id MicroShardUUID, keepBits uint) MicroShardUUID {
+	if keepBits >= 32 {
+		return id
+	}
+
+	mask := ^uint32(0) << (32 - keepBits)
+	if keepBits == 0 {
+		mask = 0
+	}
+	return id.withShard(id.ShardID() & mask)
+}
+
+// MaskShards applies MaskShard to every ID, returning a new slice.
+// Intended for ETL jobs exporting datasets under data-sharing agreements.
+func MaskShards(ids []MicroShardUUID, keepBits uint) []MicroShardUUID {
+	out := make([]MicroShardUUID, len(ids))
+	for i, id := range ids {
+		out[i] = MaskShard(id, keepBits)
+	}
+	return out
+}
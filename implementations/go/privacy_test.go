@@ -0,0 +1,84 @@
+package microsharduuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoarsenTime(t *testing.T) {
+	ts := time.Date(2025, 3, 14, 15, 9, 26, 535897000, time.UTC)
+	original, _ := FromTime(ts, 777)
+
+	coarse := CoarsenTime(original, time.Hour)
+
+	expected := time.Date(2025, 3, 14, 15, 0, 0, 0, time.UTC)
+	if !coarse.Time().Equal(expected) {
+		t.Errorf("Coarsen mismatch. Expected %v, got %v", expected, coarse.Time())
+	}
+
+	// Everything except the timestamp is retained
+	if coarse.ShardID() != 777 || coarse.Low != original.Low {
+		t.Error("CoarsenTime must preserve shard and random bits")
+	}
+	if _, err := Parse(coarse.String()); err != nil {
+		t.Errorf("Coarsened UUID should remain valid: %v", err)
+	}
+
+	// Sub-microsecond granularity is a no-op
+	if CoarsenTime(original, time.Nanosecond) != original {
+		t.Error("Nanosecond granularity should return the ID unchanged")
+	}
+}
+
+func TestCoarsenTimeSortability(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	early, _ := FromTime(base.Add(10*time.Minute), 1)
+	late, _ := FromTime(base.Add(2*time.Hour), 1)
+
+	if !CoarsenTime(early, time.Hour).Before(CoarsenTime(late, time.Hour)) {
+		t.Error("Coarsened IDs in different buckets must keep their order")
+	}
+}
+
+func TestMaskShard(t *testing.T) {
+	original, _ := Generate(0xDEADBEEF)
+
+	cases := []struct {
+		keepBits uint
+		expected uint32
+	}{
+		{0, 0},
+		{8, 0xDE000000},
+		{20, 0xDEADB000},
+		{32, 0xDEADBEEF},
+		{40, 0xDEADBEEF},
+	}
+
+	for _, c := range cases {
+		masked := MaskShard(original, c.keepBits)
+		if masked.ShardID() != c.expected {
+			t.Errorf("MaskShard(%d) mismatch. Expected %x, got %x", c.keepBits, c.expected, masked.ShardID())
+		}
+		if !masked.Time().Equal(original.Time()) || masked.Low&MaxRandom != original.Low&MaxRandom {
+			t.Errorf("MaskShard(%d) must preserve time and random bits", c.keepBits)
+		}
+		if _, err := Parse(masked.String()); err != nil {
+			t.Errorf("Masked UUID should remain valid: %v", err)
+		}
+	}
+}
+
+func TestMaskShards(t *testing.T) {
+	a, _ := Generate(0x12345678)
+	b, _ := Generate(0x12FFFFFF)
+	ids := []MicroShardUUID{a, b}
+
+	masked := MaskShards(ids, 8)
+
+	if len(masked) != 2 || masked[0].ShardID() != 0x12000000 || masked[1].ShardID() != 0x12000000 {
+		t.Errorf("Batch masking failed: %v", masked)
+	}
+	if ids[0] != a {
+		t.Error("MaskShards must not modify its input")
+	}
+}
@@ -0,0 +1,109 @@
+package microsharduuid
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"strings"
+)
+
+// ==========================================
+// Proquint (Pronounceable) Encoding
+// ==========================================
+
+// Proquint consonants and vowels (see https://arxiv.org/html/0901.4016).
+// Each 16-bit word is spelled consonant-vowel-consonant-vowel-consonant.
+const (
+	proquintConsonants = "bdfghjklmnprstvz"
+	proquintVowels     = "aiou"
+)
+
+// proquintWords is the number of 16-bit words in an encoded ID:
+// 8 data words (128 bits) plus 1 checksum word.
+const proquintWords = 9
+
+// ProquintLen is the length of a proquint encoded MicroShardUUID
+// (9 five-letter syllables joined by 8 dashes).
+const ProquintLen = proquintWords*5 + proquintWords - 1
+
+// Proquint returns a pronounceable representation for reading IDs aloud,
+// e.g. "lusab-babad-gutih-tugad-...". The 8 syllables encode the 128 bits
+// (Big Endian) and a 9th syllable carries the low 16 bits of the CRC-32
+// (IEEE) of the 16 raw bytes, so a misheard syllable is detected on decode.
+func (u MicroShardUUID) Proquint() string {
+	raw := u.Bytes()
+
+	var buf [ProquintLen]byte
+	pos := 0
+	for i := 0; i < proquintWords; i++ {
+		var word uint16
+		if i < 8 {
+			word = binary.BigEndian.Uint16(raw[i*2:])
+		} else {
+			word = proquintChecksum(raw)
+		}
+
+		if i > 0 {
+			buf[pos] = '-'
+			pos++
+		}
+		buf[pos+0] = proquintConsonants[(word>>12)&0xF]
+		buf[pos+1] = proquintVowels[(word>>10)&0x3]
+		buf[pos+2] = proquintConsonants[(word>>6)&0xF]
+		buf[pos+3] = proquintVowels[(word>>4)&0x3]
+		buf[pos+4] = proquintConsonants[word&0xF]
+		pos += 5
+	}
+	return string(buf[:])
+}
+
+// ParseProquint converts a proquint string produced by Proquint back into a MicroShardUUID.
+// Decoding is case-insensitive. It validates the layout, the checksum syllable,
+// Version (8), and Variant (2).
+func ParseProquint(s string) (MicroShardUUID, error) {
+	if len(s) != ProquintLen {
+		return MicroShardUUID{}, newParseError(FormatProquint, len(s), ErrInvalidLength, "invalid proquint length")
+	}
+	s = strings.ToLower(s)
+
+	raw := make([]byte, 16)
+	var checksum uint16
+	for i := 0; i < proquintWords; i++ {
+		syllable := s[i*6 : i*6+5]
+		if i < proquintWords-1 && s[i*6+5] != '-' {
+			return MicroShardUUID{}, newParseError(FormatProquint, len(s), ErrInvalidEncoding, "invalid proquint separator")
+		}
+
+		var word uint16
+		for j := 0; j < 5; j++ {
+			var idx int
+			if j%2 == 0 {
+				idx = strings.IndexByte(proquintConsonants, syllable[j])
+				word <<= 4
+			} else {
+				idx = strings.IndexByte(proquintVowels, syllable[j])
+				word <<= 2
+			}
+			if idx < 0 {
+				return MicroShardUUID{}, newParseError(FormatProquint, len(s), ErrInvalidEncoding, "invalid proquint character")
+			}
+			word |= uint16(idx)
+		}
+
+		if i < 8 {
+			binary.BigEndian.PutUint16(raw[i*2:], word)
+		} else {
+			checksum = word
+		}
+	}
+
+	if checksum != proquintChecksum(raw) {
+		return MicroShardUUID{}, newParseError(FormatProquint, len(s), ErrInvalidEncoding, "invalid proquint checksum")
+	}
+
+	return fromHighLow(binary.BigEndian.Uint64(raw[0:8]), binary.BigEndian.Uint64(raw[8:16]), FormatProquint, len(s))
+}
+
+// proquintChecksum returns the low 16 bits of the CRC-32 (IEEE) of the raw bytes.
+func proquintChecksum(raw []byte) uint16 {
+	return uint16(crc32.ChecksumIEEE(raw))
+}
@@ -0,0 +1,70 @@
+package microsharduuid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProquintRoundtrip(t *testing.T) {
+	original, _ := Generate(987654)
+	encoded := original.Proquint()
+
+	if len(encoded) != ProquintLen {
+		t.Fatalf("Proquint length mismatch. Expected %d, got %d", ProquintLen, len(encoded))
+	}
+
+	parsed, err := ParseProquint(encoded)
+	if err != nil {
+		t.Fatalf("Failed to parse valid proquint: %v", err)
+	}
+	if parsed != original {
+		t.Errorf("Roundtrip failed. Original %v != Parsed %v", original, parsed)
+	}
+
+	// Agents may type what they hear in any case
+	parsed, err = ParseProquint(strings.ToUpper(encoded))
+	if err != nil || parsed != original {
+		t.Errorf("Uppercase proquint should decode to the same UUID")
+	}
+}
+
+func TestProquintKnownWord(t *testing.T) {
+	// 0x0000 -> "babab", 0xFFFF -> "zuzuz" per the proquint spec
+	uid := MicroShardUUID{High: 0x0000FFFF00008000, Low: 0x8000000000000000}
+	encoded := uid.Proquint()
+
+	if !strings.HasPrefix(encoded, "babab-zuzuz-babab-mabab-mabab-") {
+		t.Errorf("Unexpected proquint encoding: %s", encoded)
+	}
+}
+
+func TestProquintErrors(t *testing.T) {
+	uid, _ := Generate(1)
+	valid := uid.Proquint()
+
+	// Invalid length
+	if _, err := ParseProquint(valid[:len(valid)-1]); err == nil {
+		t.Error("Should have errored on invalid length")
+	}
+
+	// Invalid separator
+	if _, err := ParseProquint(strings.Replace(valid, "-", "_", 1)); err == nil {
+		t.Error("Should have errored on invalid separator")
+	}
+
+	// Invalid character (vowel where a consonant is expected)
+	if _, err := ParseProquint("a" + valid[1:]); err == nil {
+		t.Error("Should have errored on invalid character")
+	}
+
+	// Misheard syllable must fail the checksum
+	swapped := []byte(valid)
+	if swapped[4] == 'b' {
+		swapped[4] = 'd'
+	} else {
+		swapped[4] = 'b'
+	}
+	if _, err := ParseProquint(string(swapped)); err == nil {
+		t.Error("Should have errored on checksum mismatch")
+	}
+}
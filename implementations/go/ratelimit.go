@@ -0,0 +1,168 @@
+package microsharduuid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ==========================================
+// Rate Limiting
+// ==========================================
+
+// ErrRateLimited is returned when an ID cannot be issued within the rate
+// limit: immediately by TryNewID, or before the context deadline by
+// NewIDContext.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimited wraps a Generator with a token bucket that allows perSecond
+// IDs on average and bursts of up to burst IDs. ID services typically keep
+// one per client, so a single tenant cannot exhaust entropy or skew shard
+// hotness for everyone else.
+//
+// It is safe for concurrent use. Waiters are served in arrival order.
+type RateLimited struct {
+	gen   *Generator
+	rate  float64 // Tokens per second
+	burst float64
+	now   func() time.Time // time.Now; replaced in tests
+
+	mu     sync.Mutex
+	tokens float64 // May go negative while callers wait for reserved tokens
+	last   time.Time
+}
+
+// NewRateLimited wraps g with a limit of perSecond IDs per second and a
+// burst of up to burst IDs. The bucket starts full.
+func NewRateLimited(g *Generator, perSecond float64, burst int) (*RateLimited, error) {
+	if perSecond <= 0 || burst < 1 {
+		return nil, fmt.Errorf("%w: rate limit needs a positive rate and burst", ErrInvalidOption)
+	}
+	return &RateLimited{
+		gen:    g,
+		rate:   perSecond,
+		burst:  float64(burst),
+		now:    time.Now,
+		tokens: float64(burst),
+	}, nil
+}
+
+// Generator returns the wrapped Generator.
+func (r *RateLimited) Generator() *Generator {
+	return r.gen
+}
+
+// TryNewID generates an ID if a token is available and fails with
+// ErrRateLimited otherwise, without blocking.
+func (r *RateLimited) TryNewID() (MicroShardUUID, error) {
+	if _, ok := r.reserve(1, 0); !ok {
+		return MicroShardUUID{}, ErrRateLimited
+	}
+	return r.gen.NewID()
+}
+
+// NewID waits for a token and generates an ID.
+func (r *RateLimited) NewID() (MicroShardUUID, error) {
+	return r.NewIDContext(context.Background())
+}
+
+// NewIDContext waits for a token and generates an ID. It fails with
+// ErrRateLimited without waiting if the token would not be available before
+// the ctx deadline, and with ctx.Err() if ctx is canceled while waiting.
+func (r *RateLimited) NewIDContext(ctx context.Context) (MicroShardUUID, error) {
+	if err := r.wait(ctx, 1); err != nil {
+		return MicroShardUUID{}, err
+	}
+	return r.gen.NewIDContext(ctx)
+}
+
+// NewIDsContext waits for n tokens and generates a batch of n IDs (see
+// Generator.NewIDs). n must not exceed the burst.
+func (r *RateLimited) NewIDsContext(ctx context.Context, n int) ([]MicroShardUUID, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	if float64(n) > r.burst {
+		return nil, fmt.Errorf("%w: batch of %d exceeds burst of %.0f", ErrRateLimited, n, r.burst)
+	}
+	if err := r.wait(ctx, n); err != nil {
+		return nil, err
+	}
+	return r.gen.NewIDsContext(ctx, n)
+}
+
+// wait reserves n tokens and sleeps until they are available, returning
+// them if ctx ends first.
+func (r *RateLimited) wait(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	maxWait := time.Duration(-1) // Unbounded
+	if deadline, ok := ctx.Deadline(); ok {
+		if maxWait = time.Until(deadline); maxWait < 0 {
+			maxWait = 0
+		}
+	}
+
+	delay, ok := r.reserve(n, maxWait)
+	if !ok {
+		return ErrRateLimited
+	}
+	if delay <= 0 {
+		return nil
+	}
+	if err := sleepContext(ctx, delay); err != nil {
+		r.refund(n)
+		return err
+	}
+	return nil
+}
+
+// reserve takes n tokens if they become available within maxWait (< 0 =
+// unbounded) and returns how long the caller must wait for them.
+func (r *RateLimited) reserve(n int, maxWait time.Duration) (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.advance(now)
+
+	tokens := r.tokens - float64(n)
+	var delay time.Duration
+	if tokens < 0 {
+		delay = time.Duration(-tokens / r.rate * float64(time.Second))
+	}
+	if maxWait >= 0 && delay > maxWait {
+		return 0, false
+	}
+	r.tokens = tokens
+	return delay, true
+}
+
+// refund returns n reserved tokens after a canceled wait.
+func (r *RateLimited) refund(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.advance(r.now())
+	if r.tokens += float64(n); r.tokens > r.burst {
+		r.tokens = r.burst
+	}
+}
+
+// advance adds the tokens accrued since the last update. It must be called
+// with r.mu held.
+func (r *RateLimited) advance(now time.Time) {
+	if r.last.IsZero() {
+		r.last = now
+		return
+	}
+	if elapsed := now.Sub(r.last); elapsed > 0 {
+		if r.tokens += elapsed.Seconds() * r.rate; r.tokens > r.burst {
+			r.tokens = r.burst
+		}
+		r.last = now
+	}
+}
@@ -0,0 +1,148 @@
+package microsharduuid
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTime is a settable time source for rate limiter tests.
+type fakeTime struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (f *fakeTime) now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.t
+}
+
+func (f *fakeTime) advance(d time.Duration) {
+	f.mu.Lock()
+	f.t = f.t.Add(d)
+	f.mu.Unlock()
+}
+
+func newTestRateLimited(t *testing.T, perSecond float64, burst int) (*RateLimited, *fakeTime) {
+	t.Helper()
+	gen, _ := NewGenerator(30)
+	r, err := NewRateLimited(gen, perSecond, burst)
+	if err != nil {
+		t.Fatalf("NewRateLimited failed: %v", err)
+	}
+	ft := &fakeTime{t: time.Unix(1700000000, 0)}
+	r.now = ft.now
+	return r, ft
+}
+
+func TestRateLimitedTryNewID(t *testing.T) {
+	r, ft := newTestRateLimited(t, 10, 3)
+
+	for i := 0; i < 3; i++ {
+		if id, err := r.TryNewID(); err != nil || id.ShardID() != 30 {
+			t.Fatalf("Burst ID %d failed: %v", i, err)
+		}
+	}
+	if _, err := r.TryNewID(); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited after the burst, got %v", err)
+	}
+
+	// 10/s refills one token every 100ms
+	ft.advance(100 * time.Millisecond)
+	if _, err := r.TryNewID(); err != nil {
+		t.Errorf("Token should have been refilled: %v", err)
+	}
+	if _, err := r.TryNewID(); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+
+	// The bucket never holds more than the burst
+	ft.advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		r.TryNewID()
+	}
+	if _, err := r.TryNewID(); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Refill should be capped at the burst, got %v", err)
+	}
+}
+
+func TestRateLimitedWaits(t *testing.T) {
+	gen, _ := NewGenerator(30)
+	r, _ := NewRateLimited(gen, 1000, 1)
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if _, err := r.NewID(); err != nil {
+			t.Fatalf("NewID failed: %v", err)
+		}
+	}
+	// The first ID uses the burst, the other 5 wait about 1ms each
+	if elapsed := time.Since(start); elapsed < 4*time.Millisecond {
+		t.Errorf("Blocking NewID did not wait for tokens (took %v)", elapsed)
+	}
+}
+
+func TestRateLimitedDeadline(t *testing.T) {
+	r, _ := newTestRateLimited(t, 1, 1)
+	r.TryNewID()
+
+	// The next token is 1s away, beyond the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := r.NewIDContext(ctx); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited for an unreachable deadline, got %v", err)
+	}
+
+	canceled, cancel2 := context.WithCancel(context.Background())
+	cancel2()
+	if _, err := r.NewIDContext(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRateLimitedRefundsCanceledWait(t *testing.T) {
+	gen, _ := NewGenerator(30)
+	r, _ := NewRateLimited(gen, 10, 1)
+	r.TryNewID()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := r.NewIDContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	r.mu.Lock()
+	tokens := r.tokens
+	r.mu.Unlock()
+	if tokens < -0.5 {
+		t.Errorf("Canceled wait kept its reservation (tokens = %.2f)", tokens)
+	}
+}
+
+func TestRateLimitedBatch(t *testing.T) {
+	r, _ := newTestRateLimited(t, 100, 10)
+
+	ids, err := r.NewIDsContext(context.Background(), 10)
+	if err != nil || len(ids) != 10 {
+		t.Fatalf("NewIDsContext failed: %v", err)
+	}
+	if _, err := r.NewIDsContext(context.Background(), 11); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Batch above the burst should fail, got %v", err)
+	}
+}
+
+func TestNewRateLimitedInvalid(t *testing.T) {
+	gen, _ := NewGenerator(30)
+	if _, err := NewRateLimited(gen, 0, 1); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption for a zero rate, got %v", err)
+	}
+	if _, err := NewRateLimited(gen, 1, 0); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption for a zero burst, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package microsharduuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// ==========================================
+// Legacy ID Remapping
+// ==========================================
+
+// RemapLegacy deterministically maps an existing (typically version 4) UUID
+// to a MicroShardUUID, for backfills that must be reproducible and
+// idempotent: the same key, legacy ID, createdAt, and shardID always yield
+// the same ID, so a migration can be re-run or resumed safely.
+//
+// The timestamp is createdAt (the record's known creation time, to the
+// microsecond) and the random bits are the first 36 bits of
+// HMAC-SHA256(key, legacy). The key keeps the mapping one-way: without it,
+// a new ID does not reveal the legacy ID it came from. Keep it for as long
+// as old IDs may still need to be translated.
+//
+// It fails with ErrInvalidOption for an empty key, and with a
+// *GenerateError for a createdAt before the Unix epoch or past MaxTime.
+func RemapLegacy(key []byte, legacy [16]byte, createdAt time.Time, shardID uint32) (MicroShardUUID, error) {
+	if len(key) == 0 {
+		return MicroShardUUID{}, fmt.Errorf("%w: empty remap key", ErrInvalidOption)
+	}
+	micros := createdAt.UnixMicro()
+	if micros < 0 {
+		return MicroShardUUID{}, &GenerateError{ShardID: shardID, Reason: "time is before the Unix epoch"}
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(legacy[:])
+	sum := mac.Sum(nil)
+
+	return FromParts(uint64(micros), shardID, binary.BigEndian.Uint64(sum[0:8])>>28)
+}
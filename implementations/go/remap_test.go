@@ -0,0 +1,48 @@
+package microsharduuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRemapLegacy(t *testing.T) {
+	key := []byte("migration-2025")
+	legacy := [16]byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00}
+	createdAt := time.Date(2021, 3, 4, 5, 6, 7, 891011000, time.UTC)
+
+	id, err := RemapLegacy(key, legacy, createdAt, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !id.Time().Equal(createdAt) || id.ShardID() != 42 {
+		t.Errorf("Unexpected fields: %+v", id.Decompose())
+	}
+
+	// Re-running the migration yields the same ID
+	again, _ := RemapLegacy(key, legacy, createdAt, 42)
+	if again != id {
+		t.Errorf("Not deterministic: %s != %s", again, id)
+	}
+
+	// Different legacy IDs or keys yield different random bits
+	other := legacy
+	other[15] = 1
+	if o, _ := RemapLegacy(key, other, createdAt, 42); o.Random() == id.Random() {
+		t.Error("Different legacy IDs mapped to the same random bits")
+	}
+	if o, _ := RemapLegacy([]byte("other-key"), legacy, createdAt, 42); o.Random() == id.Random() {
+		t.Error("Different keys mapped to the same random bits")
+	}
+}
+
+func TestRemapLegacyErrors(t *testing.T) {
+	var legacy [16]byte
+	if _, err := RemapLegacy(nil, legacy, time.Now(), 1); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Empty key: expected ErrInvalidOption, got %v", err)
+	}
+	var ge *GenerateError
+	if _, err := RemapLegacy([]byte("k"), legacy, time.Unix(-1, 0), 1); !errors.As(err, &ge) {
+		t.Errorf("Pre-epoch time: expected *GenerateError, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+// Package requestid assigns a MicroShardUUID to every request and carries it
+// in the context, for log correlation across services.
+//
+// Middleware wraps a net/http handler: it reuses a valid incoming
+// X-Request-ID header or generates a new ID, stores it in the request
+// context, and echoes it in the response header.
+//
+//	http.ListenAndServe(":8080", requestid.Middleware(gen)(mux))
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		id, _ := requestid.FromContext(r.Context())
+//		log.Printf("request %s", id)
+//	}
+//
+// NewContext and FromContext are shared with the RPC integrations under
+// contrib/, so an ID set by one layer is visible to all others.
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Header is the default request ID header.
+const Header = "X-Request-ID"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id microsharduuid.MicroShardUUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, if any.
+func FromContext(ctx context.Context) (microsharduuid.MicroShardUUID, bool) {
+	id, ok := ctx.Value(contextKey{}).(microsharduuid.MicroShardUUID)
+	return id, ok
+}
+
+// Resolve returns the ID in incoming if it parses, or a new ID from gen
+// (the default Generator if gen is nil). RPC integrations use it to apply
+// the same rules as Middleware.
+func Resolve(ctx context.Context, gen *microsharduuid.Generator, incoming string) (microsharduuid.MicroShardUUID, error) {
+	if incoming != "" {
+		if id, err := microsharduuid.Parse(incoming); err == nil {
+			return id, nil
+		}
+	}
+	if gen == nil {
+		var err error
+		if gen, err = microsharduuid.Default(); err != nil {
+			return microsharduuid.MicroShardUUID{}, err
+		}
+	}
+	return gen.NewIDContext(ctx)
+}
+
+// Option configures Middleware.
+type Option func(*config)
+
+type config struct {
+	header        string
+	trustIncoming bool
+}
+
+// WithHeader sets the request and response header name (Header by default).
+func WithHeader(name string) Option {
+	return func(c *config) {
+		c.header = name
+	}
+}
+
+// WithoutIncoming ignores IDs sent by clients and always generates a new
+// one, for public edges where clients must not pick their own IDs.
+func WithoutIncoming() Option {
+	return func(c *config) {
+		c.trustIncoming = false
+	}
+}
+
+// Middleware returns net/http middleware that assigns a request ID from gen
+// (the default Generator if gen is nil). If no ID can be generated, the
+// request fails with 500 Internal Server Error.
+func Middleware(gen *microsharduuid.Generator, opts ...Option) func(http.Handler) http.Handler {
+	cfg := config{header: Header, trustIncoming: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var incoming string
+			if cfg.trustIncoming {
+				incoming = r.Header.Get(cfg.header)
+			}
+			id, err := Resolve(r.Context(), gen, incoming)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set(cfg.header, id.String())
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+		})
+	}
+}
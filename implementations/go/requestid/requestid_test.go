@@ -0,0 +1,90 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// serve runs one request through the middleware and returns the response
+// and the ID seen by the handler.
+func serve(t *testing.T, mw func(http.Handler) http.Handler, req *http.Request) (*httptest.ResponseRecorder, microsharduuid.MicroShardUUID) {
+	t.Helper()
+	var seen microsharduuid.MicroShardUUID
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := FromContext(r.Context())
+		if !ok {
+			t.Error("Handler context has no request ID")
+		}
+		seen = id
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec, seen
+}
+
+func TestMiddlewareGenerates(t *testing.T) {
+	gen, _ := microsharduuid.NewGenerator(40)
+	rec, seen := serve(t, Middleware(gen), httptest.NewRequest("GET", "/", nil))
+
+	if seen.ShardID() != 40 {
+		t.Errorf("Expected an ID from shard 40, got %d", seen.ShardID())
+	}
+	if rec.Header().Get(Header) != seen.String() {
+		t.Errorf("Response header %q does not match %s", rec.Header().Get(Header), seen)
+	}
+}
+
+func TestMiddlewareRespectsIncoming(t *testing.T) {
+	gen, _ := microsharduuid.NewGenerator(40)
+	incoming, _ := microsharduuid.Generate(7)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(Header, incoming.String())
+	if _, seen := serve(t, Middleware(gen), req); seen != incoming {
+		t.Errorf("Expected the incoming ID %s, got %s", incoming, seen)
+	}
+
+	// Invalid incoming IDs are replaced
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(Header, "not-a-uuid")
+	if _, seen := serve(t, Middleware(gen), req); seen.ShardID() != 40 {
+		t.Errorf("Expected a generated ID, got %s", seen)
+	}
+
+	// WithoutIncoming always generates
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(Header, incoming.String())
+	if _, seen := serve(t, Middleware(gen, WithoutIncoming()), req); seen == incoming {
+		t.Error("WithoutIncoming should ignore the client's ID")
+	}
+}
+
+func TestMiddlewareCustomHeader(t *testing.T) {
+	gen, _ := microsharduuid.NewGenerator(40)
+	rec, seen := serve(t, Middleware(gen, WithHeader("X-Correlation-ID")), httptest.NewRequest("GET", "/", nil))
+	if rec.Header().Get("X-Correlation-ID") != seen.String() || rec.Header().Get(Header) != "" {
+		t.Errorf("Unexpected response headers %v", rec.Header())
+	}
+}
+
+func TestMiddlewareDefaultGenerator(t *testing.T) {
+	microsharduuid.SetDefault(nil)
+	h := Middleware(nil)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("Handler should not run without a request ID")
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 without a default Generator, got %d", rec.Code)
+	}
+}
+
+func TestFromContextEmpty(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("Empty context should carry no request ID")
+	}
+}
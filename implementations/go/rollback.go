@@ -0,0 +1,139 @@
+package microsharduuid
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ==========================================
+// Clock Rollback Policy
+// ==========================================
+
+// RollbackPolicy decides what a Generator does when its clock steps
+// backwards (NTP correction, VM migration), which would otherwise issue IDs
+// that sort before IDs it already issued.
+type RollbackPolicy int
+
+const (
+	// RollbackAllow issues IDs with the earlier timestamp (default).
+	RollbackAllow RollbackPolicy = iota
+	// RollbackError fails with a *ClockRollbackError.
+	RollbackError
+	// RollbackWait sleeps until the clock catches up, failing with a
+	// *ClockRollbackError if that takes longer than the maximum wait
+	// (see WithRollbackMaxWait).
+	RollbackWait
+	// RollbackReuse keeps issuing IDs at the last timestamp, with the random
+	// bits acting as a counter as in WithMonotonicCounter, until the clock
+	// catches up.
+	RollbackReuse
+)
+
+// DefaultRollbackMaxWait is how long RollbackWait sleeps at most by default.
+const DefaultRollbackMaxWait = time.Second
+
+func (p RollbackPolicy) String() string {
+	switch p {
+	case RollbackAllow:
+		return "allow"
+	case RollbackError:
+		return "error"
+	case RollbackWait:
+		return "wait"
+	case RollbackReuse:
+		return "reuse"
+	}
+	return fmt.Sprintf("RollbackPolicy(%d)", int(p))
+}
+
+// ClockRollbackError is returned when the clock stepped backwards and the
+// Generator's RollbackPolicy refused to issue an ID.
+type ClockRollbackError struct {
+	ShardID    uint32         // Configured Shard ID
+	LastMicros uint64         // Latest clock reading seen before (Unix Microseconds)
+	NowMicros  uint64         // Current clock reading (Unix Microseconds)
+	Policy     RollbackPolicy // Policy that rejected the request
+}
+
+func (e *ClockRollbackError) Error() string {
+	return fmt.Sprintf("clock moved backwards by %v (policy %s)", e.Drift(), e.Policy)
+}
+
+// Drift returns how far the clock is behind its latest reading.
+func (e *ClockRollbackError) Drift() time.Duration {
+	return time.Duration(e.LastMicros-e.NowMicros) * time.Microsecond
+}
+
+// WithRollbackPolicy sets what the Generator does when the clock steps
+// backwards. See RollbackPolicy.
+func WithRollbackPolicy(p RollbackPolicy) Option {
+	return func(c *generatorConfig) {
+		c.rollback = p
+	}
+}
+
+// WithRollbackMaxWait bounds how long RollbackWait sleeps for the clock to
+// catch up (DefaultRollbackMaxWait if d <= 0).
+func WithRollbackMaxWait(d time.Duration) Option {
+	return func(c *generatorConfig) {
+		c.rollbackMaxWait = d
+	}
+}
+
+// checkRollback applies the rollback policy to a clock reading and records
+// it. It returns the (possibly re-read) clock value. RollbackWait stops
+// waiting early with ctx.Err() if ctx is done.
+func (g *Generator) checkRollback(ctx context.Context, cfg *generatorConfig, now uint64) (uint64, error) {
+	// Reuse is handled by the counter, which never goes backwards
+	tolerate := cfg.rollback == RollbackAllow || cfg.rollback == RollbackReuse
+	m := loadMetrics()
+	if tolerate && m == nil {
+		return now, nil
+	}
+
+	last := atomic.LoadUint64(&g.lastClock)
+	if now < last && m != nil {
+		m.ClockRollback(cfg.shardID, time.Duration(last-now)*time.Microsecond)
+	}
+	if tolerate {
+		return g.recordClock(now, last), nil
+	}
+	if now < last && cfg.rollback == RollbackWait {
+		maxWait := cfg.rollbackMaxWait
+		if maxWait <= 0 {
+			maxWait = DefaultRollbackMaxWait
+		}
+		deadline := time.Now().Add(maxWait)
+		for now < last && time.Now().Before(deadline) {
+			wait := time.Duration(last-now) * time.Microsecond
+			if remaining := time.Until(deadline); wait > remaining {
+				wait = remaining
+			}
+			if err := sleepContext(ctx, wait); err != nil {
+				return 0, err
+			}
+
+			var err error
+			if now, err = nowMicros(cfg.clock, cfg.shardID); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if now < last {
+		return 0, &ClockRollbackError{ShardID: cfg.shardID, LastMicros: last, NowMicros: now, Policy: cfg.rollback}
+	}
+
+	return g.recordClock(now, last), nil
+}
+
+// recordClock raises the latest clock reading to now, given a previous
+// load of it, and returns now.
+func (g *Generator) recordClock(now, last uint64) uint64 {
+	// Keep the latest reading; concurrent callers may race ahead of us
+	for last < now && !atomic.CompareAndSwapUint64(&g.lastClock, last, now) {
+		last = atomic.LoadUint64(&g.lastClock)
+	}
+	return now
+}
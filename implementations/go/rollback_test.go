@@ -0,0 +1,136 @@
+package microsharduuid
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// manualClock returns a settable time in Unix microseconds.
+type manualClock struct {
+	micros int64 // atomic
+}
+
+func (c *manualClock) Now() time.Time {
+	return time.UnixMicro(atomic.LoadInt64(&c.micros))
+}
+
+func (c *manualClock) set(micros int64) {
+	atomic.StoreInt64(&c.micros, micros)
+}
+
+func TestRollbackError(t *testing.T) {
+	clock := &manualClock{micros: 1700000000000000}
+	gen, _ := NewGenerator(4, WithClock(clock), WithRollbackPolicy(RollbackError))
+
+	if _, err := gen.NewID(); err != nil {
+		t.Fatalf("NewID failed: %v", err)
+	}
+	clock.set(1700000000000000 - 2500)
+
+	_, err := gen.NewID()
+	var rbErr *ClockRollbackError
+	if !errors.As(err, &rbErr) {
+		t.Fatalf("Expected ClockRollbackError, got %v", err)
+	}
+	if rbErr.Policy != RollbackError || rbErr.ShardID != 4 || rbErr.Drift() != 2500*time.Microsecond {
+		t.Errorf("Unexpected error details: %+v", rbErr)
+	}
+	if _, err := gen.NewIDs(3); !errors.As(err, &rbErr) {
+		t.Errorf("NewIDs should apply the rollback policy, got %v", err)
+	}
+
+	// Recovers once the clock catches up
+	clock.set(1700000000000001)
+	if _, err := gen.NewID(); err != nil {
+		t.Errorf("NewID should succeed after the clock caught up: %v", err)
+	}
+}
+
+func TestRollbackWait(t *testing.T) {
+	start := time.Now()
+	clock := &manualClock{micros: start.UnixMicro()}
+	gen, _ := NewGenerator(1, WithClock(clock), WithRollbackPolicy(RollbackWait))
+	gen.NewID()
+
+	// Clock steps back but catches up shortly after
+	clock.set(start.UnixMicro() - 1000)
+	go func() {
+		time.Sleep(2 * time.Millisecond)
+		clock.set(start.UnixMicro() + 1)
+	}()
+	id, err := gen.NewID()
+	if err != nil {
+		t.Fatalf("RollbackWait should wait for the clock: %v", err)
+	}
+	if id.Time().UnixMicro() < start.UnixMicro() {
+		t.Error("RollbackWait issued an ID before the last reading")
+	}
+
+	// Gives up after the maximum wait
+	gen.Reconfigure(WithRollbackMaxWait(3 * time.Millisecond))
+	clock.set(start.UnixMicro() - int64(time.Hour/time.Microsecond))
+	_, err = gen.NewID()
+	var rbErr *ClockRollbackError
+	if !errors.As(err, &rbErr) || rbErr.Policy != RollbackWait {
+		t.Errorf("Expected ClockRollbackError with policy wait, got %v", err)
+	}
+}
+
+func TestRollbackReuse(t *testing.T) {
+	clock := &manualClock{micros: 1700000000000000}
+	gen, _ := NewGenerator(1, WithClock(clock), WithRollbackPolicy(RollbackReuse))
+
+	prev, _ := gen.NewID()
+	clock.set(1700000000000000 - 1000000)
+	for i := 0; i < 10; i++ {
+		id, err := gen.NewID()
+		if err != nil {
+			t.Fatalf("RollbackReuse should not fail: %v", err)
+		}
+		if !id.After(prev) || id.Time().UnixMicro() != 1700000000000000 {
+			t.Fatalf("RollbackReuse must reuse the last timestamp, got %v", id.Time())
+		}
+		prev = id
+	}
+}
+
+func TestRollbackAllowDefault(t *testing.T) {
+	clock := &manualClock{micros: 1700000000000000}
+	gen, _ := NewGenerator(1, WithClock(clock))
+
+	gen.NewID()
+	clock.set(1600000000000000)
+	id, err := gen.NewID()
+	if err != nil || id.Time().UnixMicro() != 1600000000000000 {
+		t.Errorf("Default policy should issue the earlier timestamp: %v, %v", id.Time(), err)
+	}
+}
+
+func TestRollbackPolicyString(t *testing.T) {
+	if RollbackWait.String() != "wait" || RollbackPolicy(9).String() != "RollbackPolicy(9)" {
+		t.Error("Unexpected RollbackPolicy names")
+	}
+}
+
+func TestRollbackWaitContext(t *testing.T) {
+	clock := &manualClock{micros: 1700000000000000}
+	gen, _ := NewGenerator(1, WithClock(clock), WithRollbackPolicy(RollbackWait), WithRollbackMaxWait(time.Minute))
+	gen.NewID()
+
+	// The clock never catches up; the deadline must end the wait
+	clock.set(1700000000000000 - int64(time.Hour/time.Microsecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := gen.NewIDContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait ignored the deadline, took %v", elapsed)
+	}
+}
@@ -0,0 +1,121 @@
+package microsharduuid
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// ==========================================
+// Rendezvous (HRW) Routing
+// ==========================================
+
+// Node is a routing target of a Router, such as a cache server or replica.
+type Node struct {
+	Name   string  // Unique name; it seeds the node's hash, so keep it stable
+	Weight float64 // Relative share of shards (must be positive)
+}
+
+// Router assigns shards to named nodes with weighted rendezvous (highest
+// random weight) hashing: every node scores every shard and the best score
+// wins. Adding or removing a node only moves the shards that it gains or
+// loses, and a node with twice the weight receives about twice the shards.
+//
+// A Router is immutable and safe for concurrent use; build a new one when
+// the node set changes.
+type Router struct {
+	nodes []routerNode
+}
+
+type routerNode struct {
+	Node
+	seed uint64 // FNV-1a of Name
+}
+
+// NewRouter creates a Router for nodes. It returns an error wrapping
+// ErrInvalidOption if nodes is empty, a name is repeated, or a weight is not
+// positive.
+func NewRouter(nodes ...Node) (*Router, error) {
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("%w: router needs at least one node", ErrInvalidOption)
+	}
+	r := &Router{nodes: make([]routerNode, len(nodes))}
+	seen := make(map[string]bool, len(nodes))
+	for i, n := range nodes {
+		if seen[n.Name] {
+			return nil, fmt.Errorf("%w: duplicate router node %q", ErrInvalidOption, n.Name)
+		}
+		if !(n.Weight > 0) || math.IsInf(n.Weight, 1) {
+			return nil, fmt.Errorf("%w: router node %q has weight %v", ErrInvalidOption, n.Name, n.Weight)
+		}
+		seen[n.Name] = true
+
+		h := fnv.New64a()
+		h.Write([]byte(n.Name))
+		r.nodes[i] = routerNode{Node: n, seed: h.Sum64()}
+	}
+	return r, nil
+}
+
+// Route returns the name of the node that owns the shard of id.
+func (r *Router) Route(id MicroShardUUID) string {
+	return r.RouteShard(id.ShardID())
+}
+
+// RouteShard returns the name of the node that owns shardID.
+func (r *Router) RouteShard(shardID uint32) string {
+	best, bestScore := 0, math.Inf(-1)
+	for i := range r.nodes {
+		if s := r.nodes[i].score(shardID); s > bestScore {
+			best, bestScore = i, s
+		}
+	}
+	return r.nodes[best].Name
+}
+
+// Replicas returns the names of the n best nodes for the shard of id, best
+// first, for replica or fallback selection. Its first element is Route(id).
+// n is capped at the number of nodes.
+func (r *Router) Replicas(id MicroShardUUID, n int) []string {
+	if n > len(r.nodes) {
+		n = len(r.nodes)
+	}
+	if n <= 0 {
+		return nil
+	}
+
+	shardID := id.ShardID()
+	order := make([]int, len(r.nodes))
+	scores := make([]float64, len(r.nodes))
+	for i := range r.nodes {
+		order[i] = i
+		scores[i] = r.nodes[i].score(shardID)
+	}
+	sort.Slice(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+
+	names := make([]string, n)
+	for i := range names {
+		names[i] = r.nodes[order[i]].Name
+	}
+	return names
+}
+
+// score is the weighted rendezvous score -Weight / ln(u), where u in (0, 1)
+// is a hash of the node and shard.
+func (n *routerNode) score(shardID uint32) float64 {
+	h := mix64(n.seed ^ uint64(shardID))
+	u := (float64(h>>11) + 0.5) / (1 << 53)
+	return -n.Weight / math.Log(u)
+}
+
+// mix64 is the SplitMix64 finalizer, which spreads every input bit over
+// the whole output.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,72 @@
+package microsharduuid
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRouterWeights(t *testing.T) {
+	r, err := NewRouter(Node{"a", 1}, Node{"b", 1}, Node{"c", 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const shards = 40000
+	counts := map[string]int{}
+	for s := uint32(0); s < shards; s++ {
+		counts[r.RouteShard(s)]++
+	}
+	for name, want := range map[string]float64{"a": 0.25, "b": 0.25, "c": 0.5} {
+		if got := float64(counts[name]) / shards; math.Abs(got-want) > 0.02 {
+			t.Errorf("Node %s got %.3f of the shards, expected %.2f", name, got, want)
+		}
+	}
+}
+
+func TestRouterMinimalMovement(t *testing.T) {
+	before, _ := NewRouter(Node{"a", 1}, Node{"b", 1}, Node{"c", 1})
+	after, _ := NewRouter(Node{"a", 1}, Node{"b", 1}, Node{"c", 1}, Node{"d", 1})
+	for s := uint32(0); s < 10000; s++ {
+		if old, now := before.RouteShard(s), after.RouteShard(s); old != now && now != "d" {
+			t.Fatalf("Shard %d moved from %s to %s", s, old, now)
+		}
+	}
+}
+
+func TestRouterReplicas(t *testing.T) {
+	r, _ := NewRouter(Node{"a", 1}, Node{"b", 1}, Node{"c", 1})
+	id, _ := Generate(1234)
+
+	replicas := r.Replicas(id, 5)
+	if len(replicas) != 3 || replicas[0] != r.Route(id) {
+		t.Fatalf("Unexpected replicas %v (route %s)", replicas, r.Route(id))
+	}
+	seen := map[string]bool{}
+	for _, name := range replicas {
+		if seen[name] {
+			t.Errorf("Duplicate replica %s", name)
+		}
+		seen[name] = true
+	}
+
+	// Without the primary, the second choice takes over
+	fallback, _ := NewRouter(Node{replicas[1], 1}, Node{replicas[2], 1})
+	if got := fallback.Route(id); got != replicas[1] {
+		t.Errorf("Fallback routed to %s, expected %s", got, replicas[1])
+	}
+}
+
+func TestNewRouterErrors(t *testing.T) {
+	cases := [][]Node{
+		nil,
+		{{"a", 1}, {"a", 2}},
+		{{"a", 0}},
+		{{"a", math.NaN()}},
+		{{"a", math.Inf(1)}},
+	}
+	for i, nodes := range cases {
+		if _, err := NewRouter(nodes...); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("Case %d: expected ErrInvalidOption, got %v", i, err)
+		}
+	}
+}
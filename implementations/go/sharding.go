@@ -0,0 +1,121 @@
+package microsharduuid
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// ==========================================
+// Shard Assignment
+// ==========================================
+
+// ShardForKey derives the Shard ID in [0, numShards) for an application key
+// (tenant, customer, account), so every service computes the same shard for
+// the same key. If numShards is 0, it returns 0.
+//
+// The result is jump consistent hash (Lamping & Veach, 2014) of the 64-bit
+// FNV-1a hash of key:
+//
+//	h := 14695981039346656037            // FNV-1a offset basis
+//	for each byte c of key:
+//	    h = (h ^ c) * 1099511628211      // FNV prime, mod 2^64
+//	b, j := -1, 0
+//	while j < numShards:
+//	    b = j
+//	    h = h*2862933555777941757 + 1    // mod 2^64
+//	    j = floor((b+1) * (2^31 / ((h >> 33) + 1)))   // IEEE 754 doubles
+//	return b
+//
+// Other implementations must follow it exactly to agree. Growing numShards
+// from N to N+1 moves only about 1/(N+1) of the keys, all to the new shard.
+func ShardForKey(key []byte, numShards uint32) uint32 {
+	if numShards == 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write(key)
+	return uint32(jumpHash(h.Sum64(), int64(numShards)))
+}
+
+// PhysicalShard maps the 32-bit logical Shard ID onto one of n physical
+// databases or partitions, for the common "many logical shards, few
+// physical shards" layout. It returns ShardID() mod n, so consecutive
+// logical shards are spread round-robin.
+//
+// Any n works, not only powers of two: each physical shard then owns either
+// floor(2^32/n) or ceil(2^32/n) logical shards. Changing n remaps most
+// shards; use Partition when buckets are added over time. PhysicalShard
+// panics if n <= 0.
+func (u MicroShardUUID) PhysicalShard(n int) int {
+	if n <= 0 {
+		panic("microsharduuid: PhysicalShard with n <= 0")
+	}
+	return int(uint64(u.ShardID()) % uint64(n))
+}
+
+// Partition maps the Shard ID onto one of buckets partitions with jump
+// consistent hash (the algorithm in ShardForKey, keyed by the Shard ID as a
+// 64-bit integer), so every ID of a shard lands in the same partition.
+// Growing from N to N+1 buckets moves only about 1/(N+1) of the shards, all
+// into the new bucket. It panics if buckets <= 0.
+func (u MicroShardUUID) Partition(buckets int) int {
+	return ShardPartition(u.ShardID(), buckets)
+}
+
+// ShardPartition is Partition for a bare Shard ID, for callers that only
+// hold the shard (e.g. a message key from ShardKey).
+func ShardPartition(shardID uint32, buckets int) int {
+	if buckets <= 0 {
+		panic("microsharduuid: Partition with buckets <= 0")
+	}
+	return int(jumpHash(uint64(shardID), int64(buckets)))
+}
+
+// PartitionByID is like Partition but keyed by all 128 bits (High XOR Low),
+// spreading the IDs of one hot shard over every bucket.
+func (u MicroShardUUID) PartitionByID(buckets int) int {
+	if buckets <= 0 {
+		panic("microsharduuid: PartitionByID with buckets <= 0")
+	}
+	return int(jumpHash(u.High^u.Low, int64(buckets)))
+}
+
+// ShardKey returns the Shard ID as 4 Big Endian bytes. Used as a message
+// key (Kafka, Kinesis, Pub/Sub ordering keys), it sends every event of a
+// shard to the same partition, in order.
+func (u MicroShardUUID) ShardKey() []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, u.ShardID())
+	return b
+}
+
+// ShardFromKey extracts the Shard ID from a message key holding a ShardKey
+// (4 bytes), the raw bytes of an ID (16 bytes), or an ID string accepted by
+// Parse. It returns false for any other key, so partitioners can fall back
+// to their default hashing.
+func ShardFromKey(key []byte) (uint32, bool) {
+	switch len(key) {
+	case 4:
+		return binary.BigEndian.Uint32(key), true
+	case 16:
+		id, err := FromBytes(key)
+		return id.ShardID(), err == nil
+	}
+	if !IsValid(string(key)) {
+		return 0, false
+	}
+	id, _ := Parse(string(key))
+	return id.ShardID(), true
+}
+
+// jumpHash is Google's jump consistent hash: it maps key to a bucket in
+// [0, buckets) such that growing buckets by one moves the fewest keys.
+func jumpHash(key uint64, buckets int64) int64 {
+	b, j := int64(-1), int64(0)
+	for j < buckets {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return b
+}
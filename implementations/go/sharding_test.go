@@ -0,0 +1,147 @@
+package microsharduuid
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestShardForKeyVectors(t *testing.T) {
+	// Pinned values: other implementations must produce the same shards
+	vectors := []struct {
+		key    string
+		shards uint32
+		want   uint32
+	}{
+		{"", 0, 0},
+		{"", 1, 0},
+		{"", 16, 13},
+		{"", 1000, 266},
+		{"tenant-1", 16, 15},
+		{"tenant-1", 1000, 580},
+		{"tenant-1", MaxShardID, 2981862614},
+		{"acme-corp", 16, 5},
+		{"acme-corp", MaxShardID, 3803337528},
+		{"customer:42", 1000, 818},
+		{"customer:42", MaxShardID, 2053070245},
+	}
+	for _, v := range vectors {
+		if got := ShardForKey([]byte(v.key), v.shards); got != v.want {
+			t.Errorf("ShardForKey(%q, %d) = %d, expected %d", v.key, v.shards, got, v.want)
+		}
+	}
+}
+
+func TestShardForKeyMovesFewKeys(t *testing.T) {
+	const keys, n = 10000, 10
+	moved := 0
+	for i := 0; i < keys; i++ {
+		key := []byte("tenant-" + strconv.Itoa(i))
+		before, after := ShardForKey(key, n), ShardForKey(key, n+1)
+		if before >= n || after >= n+1 {
+			t.Fatalf("Shard out of range: %d, %d", before, after)
+		}
+		if before != after {
+			if after != n {
+				t.Fatalf("Key %s moved between existing shards (%d -> %d)", key, before, after)
+			}
+			moved++
+		}
+	}
+	// About 1/11 of the keys should move
+	if moved < keys/11/2 || moved > keys/11*2 {
+		t.Errorf("%d of %d keys moved, expected about %d", moved, keys, keys/11)
+	}
+}
+
+func TestPhysicalShard(t *testing.T) {
+	cases := []struct {
+		shard uint32
+		n     int
+		want  int
+	}{
+		{0, 1, 0},
+		{5, 3, 2},
+		{1023, 1024, 1023},
+		{1024, 1024, 0},
+		{MaxShardID, 10, 5},
+		{MaxShardID, 1<<31 - 1, 1},
+	}
+	for _, c := range cases {
+		id, _ := FromParts(0, c.shard, 0)
+		if got := id.PhysicalShard(c.n); got != c.want {
+			t.Errorf("PhysicalShard(%d) of shard %d = %d, expected %d", c.n, c.shard, got, c.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for n = 0")
+		}
+	}()
+	Nil.PhysicalShard(0)
+}
+
+func TestPartition(t *testing.T) {
+	const shards, n = 10000, 20
+	counts := make([]int, n+1)
+	moved := 0
+	for s := uint32(0); s < shards; s++ {
+		a, _ := Generate(s)
+		b, _ := Generate(s)
+		before, after := a.Partition(n), a.Partition(n+1)
+		if b.Partition(n) != before {
+			t.Fatalf("IDs of shard %d landed in different partitions", s)
+		}
+		if before != after {
+			if after != n {
+				t.Fatalf("Shard %d moved between existing partitions (%d -> %d)", s, before, after)
+			}
+			moved++
+		}
+		counts[after]++
+	}
+	if moved < shards/(n+1)/2 || moved > shards/(n+1)*2 {
+		t.Errorf("%d of %d shards moved, expected about %d", moved, shards, shards/(n+1))
+	}
+	for i, c := range counts {
+		if c < shards/(n+1)/2 {
+			t.Errorf("Partition %d only got %d shards", i, c)
+		}
+	}
+}
+
+func TestPartitionByID(t *testing.T) {
+	counts := make([]int, 8)
+	for i := 0; i < 8000; i++ {
+		id, _ := Generate(7)
+		counts[id.PartitionByID(8)]++
+	}
+	for i, c := range counts {
+		if c < 500 {
+			t.Errorf("Partition %d only got %d IDs of a single shard", i, c)
+		}
+	}
+}
+
+func TestShardKey(t *testing.T) {
+	id, _ := Generate(0xDEADBEEF)
+	key := id.ShardKey()
+	if len(key) != 4 || key[0] != 0xDE || key[3] != 0xEF {
+		t.Errorf("Unexpected ShardKey %x", key)
+	}
+
+	for _, k := range [][]byte{key, id.Bytes(), []byte(id.String()), []byte(id.URN())} {
+		if shard, ok := ShardFromKey(k); !ok || shard != id.ShardID() {
+			t.Errorf("ShardFromKey(%q) = %d, %v", k, shard, ok)
+		}
+	}
+	for _, k := range [][]byte{nil, []byte("tenant-1"), make([]byte, 16)} {
+		if _, ok := ShardFromKey(k); ok {
+			t.Errorf("ShardFromKey(%q) should fail", k)
+		}
+	}
+
+	if ShardPartition(id.ShardID(), 12) != id.Partition(12) {
+		t.Error("ShardPartition and Partition disagree")
+	}
+}
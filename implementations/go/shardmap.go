@@ -0,0 +1,242 @@
+package microsharduuid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync/atomic"
+)
+
+// ==========================================
+// Logical-to-Physical Shard Map
+// ==========================================
+
+// ErrInvalidShardMap is wrapped by the errors of NewShardMap, LoadShardMap,
+// NewShardMapSet, and ShardMapSet.Begin for unusable configurations.
+var ErrInvalidShardMap = errors.New("invalid shard map")
+
+// ShardRange assigns the logical shards First..Last (inclusive) to a
+// physical cluster.
+type ShardRange struct {
+	First   uint32 `json:"first" yaml:"first"`
+	Last    uint32 `json:"last" yaml:"last"`
+	Cluster string `json:"cluster" yaml:"cluster"`
+}
+
+// ShardMapConfig is the configuration file form of a ShardMap:
+//
+//	{
+//	  "version": 2,
+//	  "ranges": [
+//	    {"first": 0,    "last": 1023,       "cluster": "pg-east"},
+//	    {"first": 1024, "last": 4294967295, "cluster": "pg-west"}
+//	  ]
+//	}
+//
+// The fields also carry yaml tags, so a YAML file decoded with any YAML
+// library into a ShardMapConfig can be passed to NewShardMap.
+type ShardMapConfig struct {
+	Version int64        `json:"version" yaml:"version"` // Increases with every change, for live resharding
+	Ranges  []ShardRange `json:"ranges" yaml:"ranges"`
+}
+
+// ShardMap maps logical Shard IDs onto named physical clusters, so a
+// service can route any ID to its database without a lookup table. Shards
+// outside every range are unmapped. A ShardMap is immutable and safe for
+// concurrent use.
+type ShardMap struct {
+	version int64
+	ranges  []ShardRange // Sorted by First, non-overlapping
+}
+
+// NewShardMap validates cfg and builds a ShardMap. It returns an error
+// wrapping ErrInvalidShardMap if a range is reversed, overlaps another, or
+// has no cluster name.
+func NewShardMap(cfg ShardMapConfig) (*ShardMap, error) {
+	ranges := append([]ShardRange(nil), cfg.Ranges...)
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].First < ranges[j].First })
+
+	for i, r := range ranges {
+		if r.Cluster == "" {
+			return nil, fmt.Errorf("%w: range %d-%d has no cluster", ErrInvalidShardMap, r.First, r.Last)
+		}
+		if r.First > r.Last {
+			return nil, fmt.Errorf("%w: range %d-%d is reversed", ErrInvalidShardMap, r.First, r.Last)
+		}
+		if i > 0 && r.First <= ranges[i-1].Last {
+			return nil, fmt.Errorf("%w: range %d-%d overlaps %d-%d", ErrInvalidShardMap, r.First, r.Last, ranges[i-1].First, ranges[i-1].Last)
+		}
+	}
+	return &ShardMap{version: cfg.Version, ranges: ranges}, nil
+}
+
+// LoadShardMap decodes a JSON ShardMapConfig from r and builds a ShardMap.
+// Unknown fields are rejected, so typos in the file do not go unnoticed.
+func LoadShardMap(r io.Reader) (*ShardMap, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var cfg ShardMapConfig
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidShardMap, err)
+	}
+	return NewShardMap(cfg)
+}
+
+// LoadShardMapFile is LoadShardMap for a JSON file.
+func LoadShardMapFile(path string) (*ShardMap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadShardMap(f)
+}
+
+// Version returns the configured map version.
+func (m *ShardMap) Version() int64 {
+	return m.version
+}
+
+// Ranges returns a copy of the ranges, sorted by First.
+func (m *ShardMap) Ranges() []ShardRange {
+	return append([]ShardRange(nil), m.ranges...)
+}
+
+// Lookup returns the cluster that stores id, or false if its shard is unmapped.
+func (m *ShardMap) Lookup(id MicroShardUUID) (string, bool) {
+	return m.LookupShard(id.ShardID())
+}
+
+// LookupShard returns the cluster of shardID, or false if it is unmapped.
+func (m *ShardMap) LookupShard(shardID uint32) (string, bool) {
+	// First range that ends at or after shardID
+	i := sort.Search(len(m.ranges), func(i int) bool { return m.ranges[i].Last >= shardID })
+	if i == len(m.ranges) || m.ranges[i].First > shardID {
+		return "", false
+	}
+	return m.ranges[i].Cluster, true
+}
+
+// ShardRoute is the routing decision of a ShardMapSet.
+type ShardRoute struct {
+	Cluster string // Cluster under the active map (or the pending map, for shards only it covers)
+	Version int64  // Version of the map that resolved Cluster
+	// Next is the cluster under the pending map while a reshard is in
+	// progress and the shard moves; it is empty otherwise. Writers should
+	// write to both clusters and readers fall back from Cluster to Next
+	// until the reshard is committed.
+	Next string
+}
+
+// ShardMapSet holds the active ShardMap and, during a live reshard, the
+// pending map being migrated to. Lookups, Begin, Commit, and Abort may be
+// called concurrently; every lookup sees one consistent pair of maps.
+type ShardMapSet struct {
+	state atomic.Value // *shardMapState
+}
+
+type shardMapState struct {
+	active, pending *ShardMap
+}
+
+// NewShardMapSet creates a ShardMapSet serving active. It returns an error
+// wrapping ErrInvalidShardMap if active is nil.
+func NewShardMapSet(active *ShardMap) (*ShardMapSet, error) {
+	if active == nil {
+		return nil, fmt.Errorf("%w: nil active map", ErrInvalidShardMap)
+	}
+	s := &ShardMapSet{}
+	s.state.Store(&shardMapState{active: active})
+	return s, nil
+}
+
+func (s *ShardMapSet) load() *shardMapState {
+	return s.state.Load().(*shardMapState)
+}
+
+// Active returns the active map.
+func (s *ShardMapSet) Active() *ShardMap {
+	return s.load().active
+}
+
+// Pending returns the map of the reshard in progress, or nil.
+func (s *ShardMapSet) Pending() *ShardMap {
+	return s.load().pending
+}
+
+// Begin starts a live reshard to next. It returns an error wrapping
+// ErrInvalidShardMap if next is nil, if its version is not above the active
+// version, or if another reshard is already in progress.
+func (s *ShardMapSet) Begin(next *ShardMap) error {
+	if next == nil {
+		return fmt.Errorf("%w: nil pending map", ErrInvalidShardMap)
+	}
+	for {
+		old := s.load()
+		if old.pending != nil {
+			return fmt.Errorf("%w: reshard to version %d already in progress", ErrInvalidShardMap, old.pending.version)
+		}
+		if next.version <= old.active.version {
+			return fmt.Errorf("%w: version %d is not above active version %d", ErrInvalidShardMap, next.version, old.active.version)
+		}
+		if s.state.CompareAndSwap(old, &shardMapState{active: old.active, pending: next}) {
+			return nil
+		}
+	}
+}
+
+// Commit makes the pending map active, once data has been migrated. It
+// returns false if no reshard is in progress.
+func (s *ShardMapSet) Commit() bool {
+	for {
+		old := s.load()
+		if old.pending == nil {
+			return false
+		}
+		if s.state.CompareAndSwap(old, &shardMapState{active: old.pending}) {
+			return true
+		}
+	}
+}
+
+// Abort drops the pending map and keeps the active one. It returns false
+// if no reshard is in progress.
+func (s *ShardMapSet) Abort() bool {
+	for {
+		old := s.load()
+		if old.pending == nil {
+			return false
+		}
+		if s.state.CompareAndSwap(old, &shardMapState{active: old.active}) {
+			return true
+		}
+	}
+}
+
+// Lookup routes id under the active map, reporting where its shard moves
+// if a reshard is in progress. Shards that only the pending map covers
+// (ranges added by the reshard) are routed to their pending cluster. It
+// returns false if neither map covers the shard.
+func (s *ShardMapSet) Lookup(id MicroShardUUID) (ShardRoute, bool) {
+	st := s.load()
+	shardID := id.ShardID()
+
+	cluster, ok := st.active.LookupShard(shardID)
+	if !ok {
+		if st.pending == nil {
+			return ShardRoute{}, false
+		}
+		next, ok := st.pending.LookupShard(shardID)
+		return ShardRoute{Cluster: next, Version: st.pending.version}, ok
+	}
+	route := ShardRoute{Cluster: cluster, Version: st.active.version}
+	if st.pending != nil {
+		if next, ok := st.pending.LookupShard(shardID); ok && next != cluster {
+			route.Next = next
+		}
+	}
+	return route, true
+}
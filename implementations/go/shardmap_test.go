@@ -0,0 +1,159 @@
+package microsharduuid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testShardMap = `{
+  "version": 1,
+  "ranges": [
+    {"first": 1024, "last": 4095, "cluster": "pg-west"},
+    {"first": 0, "last": 1023, "cluster": "pg-east"}
+  ]
+}`
+
+func TestShardMapLookup(t *testing.T) {
+	m, err := LoadShardMap(strings.NewReader(testShardMap))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Version() != 1 || len(m.Ranges()) != 2 || m.Ranges()[0].Cluster != "pg-east" {
+		t.Errorf("Unexpected map: version %d, ranges %+v", m.Version(), m.Ranges())
+	}
+
+	cases := []struct {
+		shard   uint32
+		cluster string
+		ok      bool
+	}{
+		{0, "pg-east", true},
+		{1023, "pg-east", true},
+		{1024, "pg-west", true},
+		{4095, "pg-west", true},
+		{4096, "", false},
+		{MaxShardID, "", false},
+	}
+	for _, c := range cases {
+		id, _ := Generate(c.shard)
+		if cluster, ok := m.Lookup(id); cluster != c.cluster || ok != c.ok {
+			t.Errorf("Lookup(shard %d) = %q, %v; expected %q, %v", c.shard, cluster, ok, c.cluster, c.ok)
+		}
+	}
+}
+
+func TestShardMapInvalid(t *testing.T) {
+	cases := []string{
+		`{"version": 1, "ranges": [{"first": 0, "last": 10, "cluster": ""}]}`,
+		`{"version": 1, "ranges": [{"first": 10, "last": 0, "cluster": "a"}]}`,
+		`{"version": 1, "ranges": [{"first": 0, "last": 10, "cluster": "a"}, {"first": 10, "last": 20, "cluster": "b"}]}`,
+		`{"version": 1, "ranges": [], "shards": 5}`,
+		`{"version": 1, "ranges": [{"first": -1, "last": 0, "cluster": "a"}]}`,
+	}
+	for i, c := range cases {
+		if _, err := LoadShardMap(strings.NewReader(c)); !errors.Is(err, ErrInvalidShardMap) {
+			t.Errorf("Case %d: expected ErrInvalidShardMap, got %v", i, err)
+		}
+	}
+}
+
+func TestLoadShardMapFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shards.json")
+	os.WriteFile(path, []byte(testShardMap), 0o644)
+	if _, err := LoadShardMapFile(path); err != nil {
+		t.Error(err)
+	}
+	if _, err := LoadShardMapFile(path + ".missing"); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestShardMapSetReshard(t *testing.T) {
+	v1, _ := LoadShardMap(strings.NewReader(testShardMap))
+	v2, _ := NewShardMap(ShardMapConfig{Version: 2, Ranges: []ShardRange{
+		{First: 0, Last: 511, Cluster: "pg-east"},
+		{First: 512, Last: 1023, Cluster: "pg-central"},
+		{First: 1024, Last: 8191, Cluster: "pg-west"},
+	}})
+	set, err := NewShardMapSet(v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	staying, _ := Generate(100)
+	moving, _ := Generate(600)
+	added, _ := Generate(5000)
+
+	if _, ok := set.Lookup(added); ok {
+		t.Error("Shard 5000 should be unmapped before the reshard")
+	}
+	if _, err := NewShardMapSet(nil); !errors.Is(err, ErrInvalidShardMap) {
+		t.Errorf("A nil active map should be rejected, got %v", err)
+	}
+	if err := set.Begin(nil); !errors.Is(err, ErrInvalidShardMap) {
+		t.Errorf("A nil pending map should be rejected, got %v", err)
+	}
+	if err := set.Begin(v1); !errors.Is(err, ErrInvalidShardMap) {
+		t.Errorf("Same version: expected ErrInvalidShardMap, got %v", err)
+	}
+	if err := set.Begin(v2); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.Begin(v2); !errors.Is(err, ErrInvalidShardMap) {
+		t.Errorf("Concurrent reshard: expected ErrInvalidShardMap, got %v", err)
+	}
+
+	if r, _ := set.Lookup(staying); r != (ShardRoute{Cluster: "pg-east", Version: 1}) {
+		t.Errorf("Staying shard routed to %+v", r)
+	}
+	if r, _ := set.Lookup(moving); r != (ShardRoute{Cluster: "pg-east", Version: 1, Next: "pg-central"}) {
+		t.Errorf("Moving shard routed to %+v", r)
+	}
+	if r, ok := set.Lookup(added); !ok || r != (ShardRoute{Cluster: "pg-west", Version: 2}) {
+		t.Errorf("Added shard routed to %+v, %v", r, ok)
+	}
+
+	if !set.Commit() || set.Active() != v2 || set.Pending() != nil {
+		t.Fatal("Commit did not activate the pending map")
+	}
+	if r, _ := set.Lookup(moving); r != (ShardRoute{Cluster: "pg-central", Version: 2}) {
+		t.Errorf("After commit, moving shard routed to %+v", r)
+	}
+	if set.Commit() || set.Abort() {
+		t.Error("Commit/Abort without a pending map should report false")
+	}
+}
+
+func TestShardMapSetAbortConcurrent(t *testing.T) {
+	v1, _ := LoadShardMap(strings.NewReader(testShardMap))
+	v2, _ := NewShardMap(ShardMapConfig{Version: 2, Ranges: []ShardRange{{First: 0, Last: 4095, Cluster: "pg-all"}}})
+	set, _ := NewShardMapSet(v1)
+	id, _ := Generate(2000)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				r, ok := set.Lookup(id)
+				if !ok || r.Cluster != "pg-west" || (r.Next != "" && r.Next != "pg-all") {
+					t.Errorf("Inconsistent route %+v", r)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		set.Begin(v2)
+		set.Abort()
+	}
+	wg.Wait()
+
+	if set.Active() != v1 {
+		t.Error("Abort changed the active map")
+	}
+}
@@ -0,0 +1,63 @@
+package microsharduuid
+
+import (
+	"fmt"
+	"time"
+)
+
+// ==========================================
+// Snowflake Interop
+// ==========================================
+
+// FormatSnowflake is reported in ParseError.Format by FromSnowflake.
+const FormatSnowflake = "snowflake"
+
+// TwitterEpoch is the epoch of the original Twitter Snowflake IDs
+// (2010-11-04T01:42:54.657Z). Other deployments usually pick their own.
+var TwitterEpoch = time.UnixMilli(1288834974657).UTC()
+
+// MaxSnowflakeShard is the largest Shard ID that fits the 10 machine bits
+// (datacenter and worker) of a Snowflake ID.
+const MaxSnowflakeShard = 1023
+
+// FromSnowflake converts a Twitter-layout Snowflake ID
+//
+//	[sign 1] [millis since epoch 41] [datacenter 5] [worker 5] [sequence 12]
+//
+// into a MicroShardUUID. The timestamp is kept to the millisecond, the
+// datacenter and worker bits become the Shard ID (datacenter<<5 | worker),
+// and the sequence becomes the random bits, so distinct Snowflakes map to
+// distinct IDs in the same order.
+//
+// It returns a *ParseError wrapping ErrInvalidEncoding for negative IDs,
+// and an error wrapping ErrUnrepresentable if epoch puts the time before
+// the Unix epoch.
+func FromSnowflake(id int64, epoch time.Time) (MicroShardUUID, error) {
+	if id < 0 {
+		return MicroShardUUID{}, newParseError(FormatSnowflake, 8, ErrInvalidEncoding, "invalid Snowflake ID (negative)")
+	}
+	millis := epoch.UnixMilli() + id>>22
+	if millis < 0 {
+		return MicroShardUUID{}, fmt.Errorf("%w: Snowflake time is before the Unix epoch", ErrUnrepresentable)
+	}
+	shardID := uint32(id>>12) & MaxSnowflakeShard
+	return FromParts(uint64(millis)*1000, shardID, uint64(id&0xFFF))
+}
+
+// ToSnowflake converts u into a Twitter-layout Snowflake ID relative to
+// epoch (see FromSnowflake). The conversion is lossy: sub-millisecond time
+// is dropped and only the low 12 random bits are kept as the sequence, so
+// two IDs from the same shard and millisecond can collide.
+//
+// It fails with ErrUnrepresentable if the Shard ID exceeds
+// MaxSnowflakeShard or the time is outside the 41-bit range after epoch.
+func (u MicroShardUUID) ToSnowflake(epoch time.Time) (int64, error) {
+	if u.ShardID() > MaxSnowflakeShard {
+		return 0, fmt.Errorf("%w: shard %d exceeds the 10 Snowflake machine bits", ErrUnrepresentable, u.ShardID())
+	}
+	delta := int64(u.micros()/1000) - epoch.UnixMilli()
+	if delta < 0 || delta >= 1<<41 {
+		return 0, fmt.Errorf("%w: %s is outside the Snowflake time range", ErrUnrepresentable, u.ISOTime())
+	}
+	return delta<<22 | int64(u.ShardID())<<12 | int64(u.Random()&0xFFF), nil
+}
@@ -0,0 +1,62 @@
+package microsharduuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFromSnowflake(t *testing.T) {
+	// 2013-01-09T02:34:17.497Z, datacenter 1, worker 1, sequence 5
+	const tweet = 68863882840<<22 | 1<<17 | 1<<12 | 5
+	id, err := FromSnowflake(tweet, TwitterEpoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := TwitterEpoch.UnixMilli() + tweet>>22; id.UnixMilli() != want || id.UnixMicro()%1000 != 0 {
+		t.Errorf("Time %d, expected %d", id.UnixMicro(), want*1000)
+	}
+	if id.ShardID() != 1<<5|1 || id.Random() != 5 {
+		t.Errorf("Unexpected shard %d / random %d", id.ShardID(), id.Random())
+	}
+
+	back, err := id.ToSnowflake(TwitterEpoch)
+	if err != nil || back != tweet {
+		t.Errorf("ToSnowflake = %d, %v; expected %d", back, err, int64(tweet))
+	}
+
+	if _, err := FromSnowflake(1<<22, time.UnixMilli(-2)); !errors.Is(err, ErrUnrepresentable) {
+		t.Errorf("A time before the Unix epoch should fail with ErrUnrepresentable, got %v", err)
+	}
+	if _, err := FromSnowflake(-1, TwitterEpoch); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Negative ID: expected ErrInvalidEncoding, got %v", err)
+	}
+}
+
+func TestSnowflakeKeepsOrder(t *testing.T) {
+	prev, _ := FromSnowflake(0, TwitterEpoch)
+	for _, sf := range []int64{1, 1 << 12, 1<<12 + 1, 1 << 22, 1<<22 + 1, 1<<62 - 1} {
+		id, err := FromSnowflake(sf, TwitterEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !prev.Before(id) {
+			t.Errorf("Snowflake %d out of order", sf)
+		}
+		prev = id
+	}
+}
+
+func TestToSnowflakeErrors(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	wide, _ := FromTime(ts, MaxSnowflakeShard+1)
+	if _, err := wide.ToSnowflake(TwitterEpoch); !errors.Is(err, ErrUnrepresentable) {
+		t.Errorf("Wide shard: expected ErrUnrepresentable, got %v", err)
+	}
+
+	early, _ := FromTime(TwitterEpoch.Add(-time.Second), 1)
+	if _, err := early.ToSnowflake(TwitterEpoch); !errors.Is(err, ErrUnrepresentable) {
+		t.Errorf("Before epoch: expected ErrUnrepresentable, got %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !go1.21
+
+package microsharduuid
+
+import "sort"
+
+// Sort sorts ids chronologically in place (see Compare).
+func Sort(ids []MicroShardUUID) {
+	sort.Sort(ByTime(ids))
+}
+
+// IsSorted reports whether ids are in chronological order.
+func IsSorted(ids []MicroShardUUID) bool {
+	return sort.IsSorted(ByTime(ids))
+}
@@ -0,0 +1,17 @@
+//go:build go1.21
+
+package microsharduuid
+
+import "slices"
+
+// Sort sorts ids chronologically in place (see Compare).
+// On Go 1.21+ it uses slices.SortFunc, which avoids the interface
+// overhead of sort.Sort(ByTime(ids)).
+func Sort(ids []MicroShardUUID) {
+	slices.SortFunc(ids, MicroShardUUID.Compare)
+}
+
+// IsSorted reports whether ids are in chronological order.
+func IsSorted(ids []MicroShardUUID) bool {
+	return slices.IsSortedFunc(ids, MicroShardUUID.Compare)
+}
@@ -0,0 +1,24 @@
+package microsharduuid
+
+import "testing"
+
+func TestSort(t *testing.T) {
+	var ids []MicroShardUUID
+	for i := 0; i < 50; i++ {
+		id, _ := FromParts(uint64(1000-i), uint32(i%3), uint64(i))
+		ids = append(ids, id)
+	}
+	if IsSorted(ids) {
+		t.Fatal("Input should start out of order")
+	}
+
+	Sort(ids)
+	if !IsSorted(ids) {
+		t.Fatal("Sort did not order the IDs")
+	}
+	for i := 1; i < len(ids); i++ {
+		if !ids[i-1].Before(ids[i]) {
+			t.Fatalf("IDs %d and %d are out of order", i-1, i)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package microsharduuid
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ==========================================
+// Executable Specification
+// ==========================================
+//
+// specModel is a deliberately naive model of the layout: the 128 bits are
+// built as a string of '0'/'1' characters from the field table below, in
+// MSB-first order. It shares no code with the implementation, so the checker
+// catches shift/mask regressions when the layout code changes.
+
+type specField struct {
+	name  string
+	width int
+}
+
+// specLayout is the bit layout from the README, MSB first.
+var specLayout = []specField{
+	{"timeHigh", 48},
+	{"version", 4},
+	{"timeLow", 6},
+	{"shardHigh", 6},
+	{"variant", 2},
+	{"shardLow", 26},
+	{"random", 36},
+}
+
+type specModel struct {
+	micros uint64
+	shard  uint32
+	random uint64
+}
+
+func (m specModel) fields() map[string]uint64 {
+	return map[string]uint64{
+		"timeHigh":  m.micros >> 6,
+		"version":   8,
+		"timeLow":   m.micros & 0x3F,
+		"shardHigh": uint64(m.shard) >> 26,
+		"variant":   2,
+		"shardLow":  uint64(m.shard) & (1<<26 - 1),
+		"random":    m.random,
+	}
+}
+
+// bits renders the model as 128 '0'/'1' characters.
+func (m specModel) bits() string {
+	values := m.fields()
+	var sb strings.Builder
+	for _, f := range specLayout {
+		s := strconv.FormatUint(values[f.name], 2)
+		sb.WriteString(strings.Repeat("0", f.width-len(s)) + s)
+	}
+	return sb.String()
+}
+
+// canonical renders the model as an 8-4-4-4-12 string.
+func (m specModel) canonical() string {
+	bits := m.bits()
+	var hex strings.Builder
+	for i := 0; i < 128; i += 4 {
+		v, _ := strconv.ParseUint(bits[i:i+4], 2, 8)
+		hex.WriteString(strconv.FormatUint(v, 16))
+	}
+	h := hex.String()
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// less is the specified ordering: time, then shard, then random.
+func (m specModel) less(o specModel) bool {
+	if m.micros != o.micros {
+		return m.micros < o.micros
+	}
+	if m.shard != o.shard {
+		return m.shard < o.shard
+	}
+	return m.random < o.random
+}
+
+func randomSpecModel(rng *rand.Rand) specModel {
+	m := specModel{
+		micros: uint64(rng.Int63()) & MaxTime,
+		shard:  rng.Uint32() & MaxShardID,
+		random: uint64(rng.Int63()) & MaxRandom,
+	}
+	// Bias towards shared prefixes so tie-breaking rules get exercised
+	switch rng.Intn(4) {
+	case 0:
+		m.micros = 1700000000000000
+	case 1:
+		m.micros, m.shard = 1700000000000000, 7
+	}
+	return m
+}
+
+// checkAgainstSpec cross-validates every extractor and codec against m.
+func checkAgainstSpec(t *testing.T, m specModel, id MicroShardUUID) {
+	t.Helper()
+	want := m.canonical()
+
+	if got := id.String(); got != want {
+		t.Fatalf("%+v: String() = %s, spec says %s", m, got, want)
+	}
+	if got := fmt.Sprintf("%064b%064b", id.High, id.Low); got != m.bits() {
+		t.Fatalf("%+v: bit layout mismatch\n got  %s\n spec %s", m, got, m.bits())
+	}
+	if id.ShardID() != m.shard {
+		t.Fatalf("%+v: ShardID() = %d", m, id.ShardID())
+	}
+	if !id.Time().Equal(time.UnixMicro(int64(m.micros))) {
+		t.Fatalf("%+v: Time() = %v", m, id.Time())
+	}
+	if id.Low&MaxRandom != m.random {
+		t.Fatalf("%+v: random bits = %x", m, id.Low&MaxRandom)
+	}
+
+	parsed, err := Parse(want)
+	if err != nil || parsed != id {
+		t.Fatalf("%+v: Parse(spec string) = %s, %v", m, parsed, err)
+	}
+}
+
+func TestSpecModelLayout(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		m := randomSpecModel(rng)
+		id, err := FromParts(m.micros, m.shard, m.random)
+		if err != nil {
+			t.Fatalf("FromParts(%+v) failed: %v", m, err)
+		}
+		checkAgainstSpec(t, m, id)
+	}
+}
+
+func TestSpecModelOrdering(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 2000; i++ {
+		a, b := randomSpecModel(rng), randomSpecModel(rng)
+		idA, _ := FromParts(a.micros, a.shard, a.random)
+		idB, _ := FromParts(b.micros, b.shard, b.random)
+
+		if idA.Before(idB) != a.less(b) || idB.Before(idA) != b.less(a) {
+			t.Fatalf("Ordering of %+v and %+v disagrees with the spec", a, b)
+		}
+		// Canonical strings must sort like the IDs
+		if (idA.String() < idB.String()) != a.less(b) {
+			t.Fatalf("String ordering of %+v and %+v disagrees with the spec", a, b)
+		}
+	}
+}
+
+func TestSpecModelGenerate(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 500; i++ {
+		shard := rng.Uint32() & MaxShardID
+		ts := time.UnixMicro(int64(uint64(rng.Int63()) & MaxTime))
+
+		id, err := FromTime(ts, shard)
+		if err != nil {
+			t.Fatalf("FromTime failed: %v", err)
+		}
+		m := specModel{micros: uint64(ts.UnixMicro()), shard: shard, random: id.Low & MaxRandom}
+		checkAgainstSpec(t, m, id)
+	}
+
+	id, _ := Generate(12345)
+	checkAgainstSpec(t, specModel{micros: uint64(id.Time().UnixMicro()), shard: 12345, random: id.Low & MaxRandom}, id)
+}
@@ -0,0 +1,89 @@
+// Package sqlrange builds the WHERE clauses of time-window queries over an
+// ID-sorted primary key, so retention jobs and analytics do not hand-roll
+// boundary math.
+//
+// Because the timestamp leads the ID, a time window is one contiguous key
+// range, and the clause is an index range scan:
+//
+//	q, err := sqlrange.Build(sqlrange.Postgres, "id", from, to, nil)
+//	res, err := db.Exec("DELETE FROM events WHERE "+q.Where, q.Args...)
+//
+// An optional shard filter adds a microshard_get_shard_id(column) = ?
+// predicate, which needs the SQL functions from db-extensions/ in the
+// repository.
+package sqlrange
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// Dialect selects the placeholder syntax and the bound value encoding.
+type Dialect int
+
+const (
+	// Postgres uses $1, $2, ... placeholders and a native uuid column;
+	// bounds are passed as canonical strings.
+	Postgres Dialect = iota
+	// MySQL uses ? placeholders and a BINARY(16) column; bounds are passed
+	// as 16-byte slices.
+	MySQL
+)
+
+// ErrEmptyRange is returned by Build when to is not after from.
+var ErrEmptyRange = errors.New("sqlrange: empty time range")
+
+// Query is a parameterized WHERE fragment selecting the IDs created in
+// [From, To), optionally restricted to one shard.
+type Query struct {
+	Lo, Hi microsharduuid.MicroShardUUID // Lo <= id < Hi
+	Where  string                        // e.g. "id >= $1 AND id < $2"
+	Args   []interface{}                 // Bound values, in placeholder order
+}
+
+// Build returns the Query for IDs in column created in [from, to). If shard
+// is not nil, only IDs of that shard match. Bounds are MinForTime of from
+// and to, so every ID of the window matches, including dry-run IDs, and
+// consecutive windows neither overlap nor leave gaps.
+//
+// column is inserted verbatim and must be a trusted identifier. Build fails
+// with ErrEmptyRange if to is not after from, or with the error of
+// MinForTime if a bound is out of range.
+func Build(d Dialect, column string, from, to time.Time, shard *uint32) (Query, error) {
+	if !to.After(from) {
+		return Query{}, ErrEmptyRange
+	}
+	lo, err := microsharduuid.MinForTime(from)
+	if err != nil {
+		return Query{}, err
+	}
+	hi, err := microsharduuid.MinForTime(to)
+	if err != nil {
+		return Query{}, err
+	}
+
+	q := Query{Lo: lo, Hi: hi, Args: []interface{}{d.value(lo), d.value(hi)}}
+	q.Where = column + " >= " + d.placeholder(1) + " AND " + column + " < " + d.placeholder(2)
+	if shard != nil {
+		q.Where += " AND microshard_get_shard_id(" + column + ") = " + d.placeholder(3)
+		q.Args = append(q.Args, int64(*shard))
+	}
+	return q, nil
+}
+
+func (d Dialect) placeholder(n int) string {
+	if d == Postgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+func (d Dialect) value(id microsharduuid.MicroShardUUID) interface{} {
+	if d == Postgres {
+		return id.String()
+	}
+	return id.Bytes()
+}
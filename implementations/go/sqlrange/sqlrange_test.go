@@ -0,0 +1,63 @@
+package sqlrange
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+var (
+	march = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	april = time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestBuildPostgres(t *testing.T) {
+	q, err := Build(Postgres, "id", march, april, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Where != "id >= $1 AND id < $2" {
+		t.Errorf("Unexpected WHERE: %s", q.Where)
+	}
+	lo, _ := microsharduuid.MinForTime(march)
+	hi, _ := microsharduuid.MinForTime(april)
+	if q.Lo != lo || q.Hi != hi || len(q.Args) != 2 || q.Args[0] != lo.String() || q.Args[1] != hi.String() {
+		t.Errorf("Unexpected bounds: %+v", q)
+	}
+
+	// The whole window matches, and nothing after it
+	last, _ := microsharduuid.FromParts(uint64(april.UnixMicro())-1, microsharduuid.MaxShardID, microsharduuid.MaxRandom)
+	if !last.Before(q.Hi) {
+		t.Error("The last ID of March is outside the range")
+	}
+	first, _ := microsharduuid.FromTime(april, 0)
+	if first.Before(q.Hi) {
+		t.Error("The first ID of April is inside the range")
+	}
+}
+
+func TestBuildMySQLWithShard(t *testing.T) {
+	shard := uint32(42)
+	q, err := Build(MySQL, "t.pk", march, april, &shard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Where != "t.pk >= ? AND t.pk < ? AND microshard_get_shard_id(t.pk) = ?" {
+		t.Errorf("Unexpected WHERE: %s", q.Where)
+	}
+	if len(q.Args) != 3 || !bytes.Equal(q.Args[0].([]byte), q.Lo.Bytes()) || q.Args[2] != int64(42) {
+		t.Errorf("Unexpected args: %v", q.Args)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	if _, err := Build(Postgres, "id", april, march, nil); !errors.Is(err, ErrEmptyRange) {
+		t.Errorf("Reversed range: expected ErrEmptyRange, got %v", err)
+	}
+	if _, err := Build(Postgres, "id", time.Unix(-10, 0), march, nil); err == nil {
+		t.Error("Expected an error for a pre-epoch bound")
+	}
+}
@@ -0,0 +1,49 @@
+package microsharduuid
+
+import "context"
+
+// ==========================================
+// Streaming Generation
+// ==========================================
+
+// StreamChan generates IDs in a background goroutine and delivers them on
+// the returned channel, which holds up to buffer pre-generated IDs. A slow
+// consumer blocks the producer, so at most buffer+1 IDs are generated ahead.
+//
+// The stream stops when ctx is canceled or generation fails. Both channels
+// are then closed; the error channel first receives the failure, if any.
+//
+//	ids, errc := gen.StreamChan(ctx, 64)
+//	for id := range ids {
+//		// ...
+//	}
+//	if err := <-errc; err != nil {
+//		log.Fatal(err)
+//	}
+func (g *Generator) StreamChan(ctx context.Context, buffer int) (<-chan MicroShardUUID, <-chan error) {
+	if buffer < 0 {
+		buffer = 0
+	}
+	ids := make(chan MicroShardUUID, buffer)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(ids)
+		for {
+			id, err := g.NewIDContext(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					errc <- err
+				}
+				return
+			}
+			select {
+			case ids <- id:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ids, errc
+}
@@ -0,0 +1,26 @@
+//go:build go1.23
+
+package microsharduuid
+
+import "iter"
+
+// Stream returns an infinite sequence of IDs for range-over-func loops.
+// IDs are generated lazily, one per iteration, so breaking out of the loop
+// stops generation. A generation error ends the sequence; call NewID
+// directly where the error itself must be handled.
+//
+//	for id := range gen.Stream() {
+//		if done() {
+//			break
+//		}
+//	}
+func (g *Generator) Stream() iter.Seq[MicroShardUUID] {
+	return func(yield func(MicroShardUUID) bool) {
+		for {
+			id, err := g.NewID()
+			if err != nil || !yield(id) {
+				return
+			}
+		}
+	}
+}
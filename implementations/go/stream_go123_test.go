@@ -0,0 +1,27 @@
+//go:build go1.23
+
+package microsharduuid
+
+import "testing"
+
+func TestStream(t *testing.T) {
+	gen, _ := NewGenerator(7, WithMonotonicCounter())
+
+	var got []MicroShardUUID
+	for id := range gen.Stream() {
+		got = append(got, id)
+		if len(got) == 50 {
+			break
+		}
+	}
+	if len(got) != 50 || !IsSorted(got) {
+		t.Fatalf("Expected 50 increasing IDs, got %d", len(got))
+	}
+}
+
+func TestStreamStopsOnError(t *testing.T) {
+	gen, _ := NewGenerator(7, WithEntropy(failingReader{}))
+	for range gen.Stream() {
+		t.Fatal("Failing generator should yield no IDs")
+	}
+}
@@ -0,0 +1,43 @@
+package microsharduuid
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStreamChan(t *testing.T) {
+	gen, _ := NewGenerator(7, WithMonotonicCounter())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ids, errc := gen.StreamChan(ctx, 8)
+	var last MicroShardUUID
+	for i := 0; i < 100; i++ {
+		id := <-ids
+		if id.ShardID() != 7 || !id.After(last) {
+			t.Fatalf("Unexpected stream ID %d: %s", i, id)
+		}
+		last = id
+	}
+
+	cancel()
+	for range ids {
+		// Drain IDs generated before the cancellation was seen
+	}
+	if err := <-errc; err != nil {
+		t.Errorf("Canceled stream should not report an error, got %v", err)
+	}
+}
+
+func TestStreamChanError(t *testing.T) {
+	gen, _ := NewGenerator(7, WithEntropy(failingReader{}))
+
+	ids, errc := gen.StreamChan(context.Background(), 0)
+	if _, ok := <-ids; ok {
+		t.Error("Failing stream should close without delivering IDs")
+	}
+	var ge *GenerateError
+	if err := <-errc; !errors.As(err, &ge) {
+		t.Errorf("Expected a GenerateError, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+// Package streamcodec compresses streams of MicroShardUUIDs for export files.
+//
+// Sorted ID streams are highly redundant: consecutive IDs share most of their
+// timestamp and usually come from a small set of shards. The codec stores
+//
+//   - the timestamp as a zig-zag varint delta from the previous ID,
+//   - the shard as a varint index into a dictionary built on the fly,
+//   - the 36 random bits raw (they are incompressible).
+//
+// Version and Variant are implied. A sorted stream from a handful of shards
+// typically takes 7-8 bytes per ID instead of 16. Unsorted streams still
+// round-trip correctly, only with larger deltas.
+//
+// Stream layout:
+//
+//	"MSZ1" then for each ID:
+//	[Time Delta: varint] [Shard: uvarint] ([New Shard: uvarint]) [Random: 5 bytes]
+//
+// A shard value of 0 means "new shard" and is followed by the Shard ID, which
+// is appended to the dictionary; any other value n refers to entry n-1.
+package streamcodec
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// magic identifies a stream and its format version.
+var magic = [4]byte{'M', 'S', 'Z', '1'}
+
+var (
+	// ErrInvalidStream is returned when the input is not a valid stream.
+	ErrInvalidStream = errors.New("streamcodec: invalid stream")
+
+	// ErrNotMicroShard is returned when writing an ID without the standard Version and Variant.
+	ErrNotMicroShard = errors.New("streamcodec: not a version 8 MicroShard UUID")
+)
+
+// Writer compresses IDs to an underlying io.Writer.
+// Call Close (or Flush) to write out buffered data.
+type Writer struct {
+	w           *bufio.Writer
+	wroteHeader bool
+	lastMicros  int64
+	shards      map[uint32]uint64 // Shard ID -> dictionary index
+	buf         [2*binary.MaxVarintLen64 + 5]byte
+}
+
+// NewWriter returns a Writer that compresses IDs to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w), shards: make(map[uint32]uint64)}
+}
+
+// Write appends one ID to the stream.
+func (z *Writer) Write(id microsharduuid.MicroShardUUID) error {
+	// Version and Variant are implied by the format, so they must be standard
+	if (id.High>>12)&0xF != microsharduuid.Version || id.Low>>62 != microsharduuid.Variant {
+		return ErrNotMicroShard
+	}
+
+	if !z.wroteHeader {
+		if _, err := z.w.Write(magic[:]); err != nil {
+			return err
+		}
+		z.wroteHeader = true
+	}
+
+	micros := id.Time().UnixMicro()
+	n := binary.PutVarint(z.buf[:], micros-z.lastMicros)
+	z.lastMicros = micros
+
+	shard := id.ShardID()
+	if idx, ok := z.shards[shard]; ok {
+		n += binary.PutUvarint(z.buf[n:], idx+1)
+	} else {
+		z.shards[shard] = uint64(len(z.shards))
+		n += binary.PutUvarint(z.buf[n:], 0)
+		n += binary.PutUvarint(z.buf[n:], uint64(shard))
+	}
+
+	// Random: 36 bits, Big Endian in 5 bytes
+	rnd := id.Low & microsharduuid.MaxRandom
+	for i := 4; i >= 0; i-- {
+		z.buf[n+i] = byte(rnd)
+		rnd >>= 8
+	}
+	n += 5
+
+	_, err := z.w.Write(z.buf[:n])
+	return err
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (z *Writer) Flush() error {
+	if !z.wroteHeader {
+		// An empty stream still carries its header
+		if _, err := z.w.Write(magic[:]); err != nil {
+			return err
+		}
+		z.wroteHeader = true
+	}
+	return z.w.Flush()
+}
+
+// Close flushes the stream. It does not close the underlying io.Writer.
+func (z *Writer) Close() error {
+	return z.Flush()
+}
+
+// Reader decompresses IDs from an underlying io.Reader.
+type Reader struct {
+	r          *bufio.Reader
+	readHeader bool
+	lastMicros int64
+	shards     []uint32
+	rnd        [5]byte
+}
+
+// NewReader returns a Reader that decompresses IDs from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next ID, or io.EOF at the end of the stream.
+func (z *Reader) Read() (microsharduuid.MicroShardUUID, error) {
+	if !z.readHeader {
+		var hdr [4]byte
+		if _, err := io.ReadFull(z.r, hdr[:]); err != nil || hdr != magic {
+			return microsharduuid.MicroShardUUID{}, ErrInvalidStream
+		}
+		z.readHeader = true
+	}
+
+	delta, err := binary.ReadVarint(z.r)
+	if err == io.EOF {
+		return microsharduuid.MicroShardUUID{}, io.EOF
+	}
+	if err != nil {
+		return microsharduuid.MicroShardUUID{}, ErrInvalidStream
+	}
+	micros := z.lastMicros + delta
+
+	ref, err := binary.ReadUvarint(z.r)
+	if err != nil {
+		return microsharduuid.MicroShardUUID{}, ErrInvalidStream
+	}
+	var shard uint32
+	if ref == 0 {
+		v, err := binary.ReadUvarint(z.r)
+		if err != nil || v > uint64(microsharduuid.MaxShardID) {
+			return microsharduuid.MicroShardUUID{}, ErrInvalidStream
+		}
+		shard = uint32(v)
+		z.shards = append(z.shards, shard)
+	} else {
+		if ref > uint64(len(z.shards)) {
+			return microsharduuid.MicroShardUUID{}, ErrInvalidStream
+		}
+		shard = z.shards[ref-1]
+	}
+
+	if _, err := io.ReadFull(z.r, z.rnd[:]); err != nil {
+		return microsharduuid.MicroShardUUID{}, ErrInvalidStream
+	}
+	var rnd uint64
+	for _, b := range z.rnd {
+		rnd = rnd<<8 | uint64(b)
+	}
+
+	if micros < 0 {
+		return microsharduuid.MicroShardUUID{}, ErrInvalidStream
+	}
+	id, err := microsharduuid.FromParts(uint64(micros), shard, rnd)
+	if err != nil {
+		return microsharduuid.MicroShardUUID{}, ErrInvalidStream
+	}
+	z.lastMicros = micros
+	return id, nil
+}
@@ -0,0 +1,95 @@
+package streamcodec
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+func TestRoundtripSorted(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var ids []microsharduuid.MicroShardUUID
+	for i := 0; i < 1000; i++ {
+		id, _ := microsharduuid.FromTime(base.Add(time.Duration(i)*time.Millisecond), uint32(i%4))
+		ids = append(ids, id)
+	}
+	sort.Sort(microsharduuid.ByTime(ids))
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, id := range ids {
+		if err := w.Write(id); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	w.Close()
+
+	// 1ms deltas take 2 bytes, the shard 1, and the random bits 5
+	if buf.Len() > len(ids)*9 {
+		t.Errorf("Poor compression: %d bytes for %d IDs", buf.Len(), len(ids))
+	}
+
+	r := NewReader(&buf)
+	for i, want := range ids {
+		got, err := r.Read()
+		if err != nil {
+			t.Fatalf("Read %d failed: %v", i, err)
+		}
+		if got != want {
+			t.Fatalf("Mismatch at %d. Expected %s, got %s", i, want, got)
+		}
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("Expected io.EOF at end of stream, got %v", err)
+	}
+}
+
+func TestRoundtripUnsorted(t *testing.T) {
+	newer := microsharduuid.MustGenerate(5)
+	older := microsharduuid.MustFromTime(time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC), 6)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Write(newer)
+	w.Write(older)
+	w.Close()
+
+	r := NewReader(&buf)
+	if got, _ := r.Read(); got != newer {
+		t.Errorf("Expected %s, got %s", newer, got)
+	}
+	if got, _ := r.Read(); got != older {
+		t.Errorf("Expected %s, got %s", older, got)
+	}
+}
+
+func TestInvalidInput(t *testing.T) {
+	if _, err := NewReader(bytes.NewReader([]byte("nope"))).Read(); err != ErrInvalidStream {
+		t.Errorf("Expected ErrInvalidStream for bad header, got %v", err)
+	}
+
+	// Truncated record
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Write(microsharduuid.MustGenerate(1))
+	w.Close()
+	truncated := buf.Bytes()[:buf.Len()-2]
+	if _, err := NewReader(bytes.NewReader(truncated)).Read(); err != ErrInvalidStream {
+		t.Errorf("Expected ErrInvalidStream for truncated record, got %v", err)
+	}
+
+	// Empty stream
+	buf.Reset()
+	NewWriter(&buf).Close()
+	if _, err := NewReader(&buf).Read(); err != io.EOF {
+		t.Errorf("Expected io.EOF for empty stream, got %v", err)
+	}
+
+	if err := NewWriter(io.Discard).Write(microsharduuid.MicroShardUUID{}); err != ErrNotMicroShard {
+		t.Errorf("Expected ErrNotMicroShard, got %v", err)
+	}
+}
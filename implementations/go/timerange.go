@@ -0,0 +1,109 @@
+package microsharduuid
+
+import (
+	"fmt"
+	"time"
+)
+
+// ==========================================
+// Time Range Boundaries
+// ==========================================
+
+// MinForTime returns the smallest MicroShardUUID that can carry timestamp t
+// (truncated to the microsecond): Shard ID and random bits all zero. Every
+// ID created at or after t sorts at or above it, so it is the lower bound of
+// a range scan over an ID-sorted primary key:
+//
+//	lo, _ := MinForTime(march)  // first ID of March
+//	hi, _ := MinForTime(april)  // first ID of April
+//	// WHERE id >= lo AND id < hi
+//
+// It returns a *GenerateError for times before the Unix epoch or past MaxTime.
+func MinForTime(t time.Time) (MicroShardUUID, error) {
+	micros, err := boundaryMicros(t)
+	if err != nil {
+		return MicroShardUUID{}, err
+	}
+	return pack(micros, 0, 0), nil
+}
+
+// MaxForTime returns the largest MicroShardUUID that can carry timestamp t
+// (truncated to the microsecond): Shard ID and random bits all one. Every
+// regular ID created at or before t sorts at or below it.
+//
+// Dry-run IDs (see DryRunVariant) of the same microsecond sort above it;
+// prefer a half-open range with MinForTime of the next boundary as the upper
+// bound when dry-run IDs must be included.
+//
+// It returns a *GenerateError for times before the Unix epoch or past MaxTime.
+func MaxForTime(t time.Time) (MicroShardUUID, error) {
+	micros, err := boundaryMicros(t)
+	if err != nil {
+		return MicroShardUUID{}, err
+	}
+	return pack(micros, MaxShardID, MaxRandom), nil
+}
+
+// boundaryMicros validates t for the boundary constructors.
+func boundaryMicros(t time.Time) (uint64, error) {
+	micros := t.UnixMicro()
+	if micros < 0 {
+		return 0, &GenerateError{Reason: "time is before the Unix epoch"}
+	}
+	if uint64(micros) > MaxTime {
+		return 0, errTimeOverflow(0, uint64(micros))
+	}
+	return uint64(micros), nil
+}
+
+// PrefixForTimeBucket returns the longest byte prefix shared by every ID
+// created in the bucket of length granularity that contains t (bucket
+// starts are aligned as by t.Truncate(granularity)), so key-value stores
+// keyed by ID bytes (RocksDB, Badger, Bigtable) can prefix-scan a minute,
+// hour, or day instead of seeking a full range.
+//
+// Bucket lengths are not powers of two in microseconds, so the prefix can
+// also match IDs from neighbouring buckets; stop the scan at MinForTime of
+// the bucket end, or check Time, when exact edges matter. It fails with
+// ErrInvalidOption for a non-positive granularity and with the error of
+// MinForTime if the bucket is out of range.
+func PrefixForTimeBucket(t time.Time, granularity time.Duration) ([]byte, error) {
+	if granularity <= 0 {
+		return nil, fmt.Errorf("%w: non-positive time bucket %v", ErrInvalidOption, granularity)
+	}
+	start := t.Truncate(granularity)
+	lo, err := MinForTime(start)
+	if err != nil {
+		return nil, err
+	}
+	// Largest ID of the bucket, dry-run variant included
+	hi, err := MaxForTime(start.Add(granularity - time.Microsecond))
+	if err != nil {
+		return nil, err
+	}
+	hi = hi.markDryRun()
+
+	a, b := lo.Bytes(), hi.Bytes()
+	n := 0
+	for n < len(a) && a[n] == b[n] {
+		n++
+	}
+	return a[:n], nil
+}
+
+// TruncateTime returns the start of the time bucket of length d that
+// contains the ID's timestamp, aligned as by time.Time.Truncate (so hour and
+// day buckets start on UTC hour and day boundaries). If d <= 0 it returns
+// Time unchanged.
+func (u MicroShardUUID) TruncateTime(d time.Duration) time.Time {
+	return u.Time().Truncate(d)
+}
+
+// Bucket returns a canonical key for the time bucket of length d that
+// contains the ID: the bucket start in the ISOTime layout, e.g.
+// "2025-03-14T15:00:00.000000Z" for hourly buckets. Keys sort in time
+// order, so they work as map keys, metric labels, or partition names in
+// streaming aggregations.
+func (u MicroShardUUID) Bucket(d time.Duration) string {
+	return u.TruncateTime(d).Format("2006-01-02T15:04:05.000000Z")
+}
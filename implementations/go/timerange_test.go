@@ -0,0 +1,127 @@
+package microsharduuid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMinMaxForTime(t *testing.T) {
+	ts := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	lo, err := MinForTime(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hi, err := MaxForTime(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !lo.Time().Equal(ts) || lo.ShardID() != 0 || lo.Random() != 0 {
+		t.Errorf("Unexpected MinForTime: %+v", lo.Decompose())
+	}
+	if !hi.Time().Equal(ts) || hi.ShardID() != MaxShardID || hi.Random() != MaxRandom {
+		t.Errorf("Unexpected MaxForTime: %+v", hi.Decompose())
+	}
+	if _, err := Parse(lo.String()); err != nil {
+		t.Errorf("MinForTime is not a valid ID: %v", err)
+	}
+	if _, err := Parse(hi.String()); err != nil {
+		t.Errorf("MaxForTime is not a valid ID: %v", err)
+	}
+
+	// Every ID of the microsecond lies within the bounds, and neighbours do not
+	for _, shard := range []uint32{0, 1, MaxShardID} {
+		id, _ := FromTime(ts, shard)
+		if id.Before(lo) || id.After(hi) {
+			t.Errorf("ID %s outside [%s, %s]", id, lo, hi)
+		}
+	}
+	before, _ := FromParts(uint64(ts.UnixMicro())-1, MaxShardID, MaxRandom)
+	after, _ := FromParts(uint64(ts.UnixMicro())+1, 0, 0)
+	if !before.Before(lo) || !after.After(hi) {
+		t.Error("Neighbouring microseconds overlap the bounds")
+	}
+
+	next, _ := MinForTime(ts.Add(time.Microsecond))
+	if dry := hi.markDryRun(); !dry.Before(next) {
+		t.Error("Dry-run IDs should sort below MinForTime of the next microsecond")
+	}
+}
+
+func TestMinMaxForTimeRange(t *testing.T) {
+	var ge *GenerateError
+	if _, err := MinForTime(time.Unix(-1, 0)); !errors.As(err, &ge) {
+		t.Errorf("Pre-epoch: expected *GenerateError, got %v", err)
+	}
+	if _, err := MaxForTime(time.UnixMicro(int64(MaxTime) + 1)); !errors.Is(err, ErrTimeOverflow) {
+		t.Errorf("Past MaxTime: expected ErrTimeOverflow, got %v", err)
+	}
+}
+
+func TestPrefixForTimeBucket(t *testing.T) {
+	ts := time.Date(2025, 3, 14, 15, 9, 26, 535897000, time.UTC)
+	for _, g := range []time.Duration{time.Minute, time.Hour, 24 * time.Hour} {
+		prefix, err := PrefixForTimeBucket(ts, g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(prefix) == 0 || len(prefix) > 6 {
+			t.Errorf("%v: unexpected prefix length %d", g, len(prefix))
+		}
+
+		start := ts.Truncate(g)
+		first, _ := MinForTime(start)
+		last, _ := MaxForTime(start.Add(g - time.Microsecond))
+		for _, id := range []MicroShardUUID{first, last, last.markDryRun()} {
+			if !bytes.HasPrefix(id.Bytes(), prefix) {
+				t.Errorf("%v: %s does not start with %x", g, id, prefix)
+			}
+		}
+	}
+
+	// Coarser buckets never have longer prefixes
+	minute, _ := PrefixForTimeBucket(ts, time.Minute)
+	day, _ := PrefixForTimeBucket(ts, 24*time.Hour)
+	if !bytes.HasPrefix(minute, day) {
+		t.Errorf("Day prefix %x is not a prefix of minute prefix %x", day, minute)
+	}
+
+	if _, err := PrefixForTimeBucket(ts, 0); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Zero granularity: expected ErrInvalidOption, got %v", err)
+	}
+}
+
+func TestTruncateTimeAndBucket(t *testing.T) {
+	ts := time.Date(2025, 3, 14, 15, 9, 26, 535897000, time.UTC)
+	id, _ := FromTime(ts, 3)
+
+	cases := []struct {
+		d      time.Duration
+		bucket string
+	}{
+		{time.Minute, "2025-03-14T15:09:00.000000Z"},
+		{time.Hour, "2025-03-14T15:00:00.000000Z"},
+		{24 * time.Hour, "2025-03-14T00:00:00.000000Z"},
+		{0, "2025-03-14T15:09:26.535897Z"},
+	}
+	for _, c := range cases {
+		if got := id.Bucket(c.d); got != c.bucket {
+			t.Errorf("Bucket(%v) = %s, expected %s", c.d, got, c.bucket)
+		}
+		if got := id.TruncateTime(c.d); got.Format(time.RFC3339Nano) != ts.Truncate(c.d).Format(time.RFC3339Nano) {
+			t.Errorf("TruncateTime(%v) = %s", c.d, got)
+		}
+	}
+
+	// IDs in one bucket share a key; the prefix scan finds them
+	later, _ := FromTime(ts.Add(20*time.Minute), 9)
+	if id.Bucket(time.Hour) != later.Bucket(time.Hour) {
+		t.Error("IDs of the same hour have different bucket keys")
+	}
+	prefix, _ := PrefixForTimeBucket(id.TruncateTime(time.Hour), time.Hour)
+	if !bytes.HasPrefix(later.Bytes(), prefix) {
+		t.Error("Bucket prefix does not match an ID of the bucket")
+	}
+}
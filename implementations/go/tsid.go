@@ -0,0 +1,71 @@
+package microsharduuid
+
+import (
+	"fmt"
+	"time"
+)
+
+// ==========================================
+// TSID Interop
+// ==========================================
+
+// FormatTSID is reported in ParseError.Format by FromTSID.
+const FormatTSID = "tsid"
+
+// TSIDEpoch is the epoch of TSIDs (2020-01-01T00:00:00Z).
+var TSIDEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// MaxTSIDNodeBits is the largest node field a TSID layout can have.
+const MaxTSIDNodeBits = 20
+
+// ToTSID converts u into a 64-bit TSID
+//
+//	[millis since TSIDEpoch 42] [node nodeBits] [counter 22-nodeBits]
+//
+// for services that expose compact 64-bit external IDs. The truncation
+// rules are:
+//   - sub-millisecond time is dropped;
+//   - the Shard ID becomes the node and must fit in nodeBits, otherwise
+//     ToTSID fails rather than merging shards;
+//   - only the low 22-nodeBits random bits are kept as the counter, so IDs
+//     from the same shard and millisecond can collide.
+//
+// It fails with ErrUnrepresentable if nodeBits exceeds MaxTSIDNodeBits, the
+// Shard ID does not fit, or the time is before TSIDEpoch or past 2159.
+func (u MicroShardUUID) ToTSID(nodeBits uint) (int64, error) {
+	if nodeBits > MaxTSIDNodeBits {
+		return 0, fmt.Errorf("%w: %d TSID node bits (max %d)", ErrUnrepresentable, nodeBits, MaxTSIDNodeBits)
+	}
+	if uint64(u.ShardID()) >= 1<<nodeBits {
+		return 0, fmt.Errorf("%w: shard %d exceeds %d TSID node bits", ErrUnrepresentable, u.ShardID(), nodeBits)
+	}
+	delta := int64(u.micros()/1000) - TSIDEpoch.UnixMilli()
+	if delta < 0 || delta >= 1<<42 {
+		return 0, fmt.Errorf("%w: %s is outside the TSID time range", ErrUnrepresentable, u.ISOTime())
+	}
+
+	counterBits := 22 - nodeBits
+	counter := int64(u.Random() & (1<<counterBits - 1))
+	return delta<<22 | int64(u.ShardID())<<counterBits | counter, nil
+}
+
+// FromTSID converts a 64-bit TSID with a nodeBits-wide node field (see
+// ToTSID) into a MicroShardUUID. The timestamp is kept to the millisecond,
+// the node becomes the Shard ID, and the counter becomes the random bits,
+// so distinct TSIDs map to distinct IDs in the same order.
+//
+// It returns a *ParseError wrapping ErrInvalidEncoding for negative TSIDs,
+// and fails with ErrUnrepresentable if nodeBits exceeds MaxTSIDNodeBits.
+func FromTSID(tsid int64, nodeBits uint) (MicroShardUUID, error) {
+	if tsid < 0 {
+		return MicroShardUUID{}, newParseError(FormatTSID, 8, ErrInvalidEncoding, "invalid TSID (negative)")
+	}
+	if nodeBits > MaxTSIDNodeBits {
+		return MicroShardUUID{}, fmt.Errorf("%w: %d TSID node bits (max %d)", ErrUnrepresentable, nodeBits, MaxTSIDNodeBits)
+	}
+
+	counterBits := 22 - nodeBits
+	millis := uint64(TSIDEpoch.UnixMilli() + tsid>>22)
+	shardID := uint32(tsid>>counterBits) & (1<<nodeBits - 1)
+	return FromParts(millis*1000, shardID, uint64(tsid)&(1<<counterBits-1))
+}
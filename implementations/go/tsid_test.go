@@ -0,0 +1,56 @@
+package microsharduuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTSIDRoundTrip(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 123456000, time.UTC)
+	for _, nodeBits := range []uint{0, 8, 10, MaxTSIDNodeBits} {
+		shard := uint32(1<<nodeBits - 1)
+		id, _ := FromTime(ts, shard)
+
+		tsid, err := id.ToTSID(nodeBits)
+		if err != nil {
+			t.Fatalf("nodeBits=%d: %v", nodeBits, err)
+		}
+		if want := ts.Sub(TSIDEpoch).Milliseconds(); tsid>>22 != want {
+			t.Errorf("nodeBits=%d: TSID millis %d, expected %d", nodeBits, tsid>>22, want)
+		}
+
+		back, err := FromTSID(tsid, nodeBits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mask := uint64(1)<<(22-nodeBits) - 1
+		if back.UnixMilli() != id.UnixMilli() || back.ShardID() != shard || back.Random() != id.Random()&mask {
+			t.Errorf("nodeBits=%d: round trip %+v from %+v", nodeBits, back.Decompose(), id.Decompose())
+		}
+
+		again, _ := back.ToTSID(nodeBits)
+		if again != tsid {
+			t.Errorf("nodeBits=%d: TSID %d did not survive FromTSID/ToTSID (%d)", nodeBits, tsid, again)
+		}
+	}
+}
+
+func TestTSIDErrors(t *testing.T) {
+	id, _ := FromTime(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), 1024)
+	if _, err := id.ToTSID(10); !errors.Is(err, ErrUnrepresentable) {
+		t.Errorf("Wide shard: expected ErrUnrepresentable, got %v", err)
+	}
+	if _, err := id.ToTSID(MaxTSIDNodeBits + 1); !errors.Is(err, ErrUnrepresentable) {
+		t.Errorf("Wide node field: expected ErrUnrepresentable, got %v", err)
+	}
+
+	early, _ := FromTime(TSIDEpoch.Add(-time.Millisecond), 1)
+	if _, err := early.ToTSID(10); !errors.Is(err, ErrUnrepresentable) {
+		t.Errorf("Before epoch: expected ErrUnrepresentable, got %v", err)
+	}
+
+	if _, err := FromTSID(-1, 10); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Negative TSID: expected ErrInvalidEncoding, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package microsharduuid
+
+import "encoding/binary"
+
+// ==========================================
+// ULID Interop
+// ==========================================
+
+// ToULID converts u into a 128-bit ULID with the same millisecond timestamp,
+// for pipelines that key storage by ULID. Its 80 entropy bits are filled as
+//
+//	[sub-millisecond micros 10] [zero 2] [Shard ID 32] [Random 36]
+//
+// so ULIDs minted from IDs in the same millisecond keep their order. The
+// version and variant fields are dropped; everything else is kept, but
+// ULID consumers see the shard only as entropy.
+func (u MicroShardUUID) ToULID() [16]byte {
+	micros := u.micros()
+
+	shard := uint64(u.ShardID())
+	high := (micros/1000)<<16 | (micros%1000)<<6 | shard>>28
+	low := shard<<36 | u.Random()
+
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[0:8], high)
+	binary.BigEndian.PutUint64(b[8:16], low)
+	return b
+}
+
+// FromULID converts a 128-bit ULID into a MicroShardUUID for shardID. The
+// conversion is lossy:
+//   - the 48-bit millisecond timestamp is kept;
+//   - the top 10 entropy bits are read as sub-millisecond micros (as written
+//     by ToULID), with values above 999 clamped to 999, so time order holds;
+//   - the low 36 entropy bits become the random bits;
+//   - the remaining 34 entropy bits are discarded.
+//
+// Converting a ToULID result back with the original Shard ID returns the
+// original ID. It fails with ErrTimeOverflow for timestamps past MaxTime.
+func FromULID(b [16]byte, shardID uint32) (MicroShardUUID, error) {
+	high := binary.BigEndian.Uint64(b[0:8])
+	low := binary.BigEndian.Uint64(b[8:16])
+
+	sub := (high >> 6) & 0x3FF
+	if sub > 999 {
+		sub = 999
+	}
+	return FromParts((high>>16)*1000+sub, shardID, low&MaxRandom)
+}
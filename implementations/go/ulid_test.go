@@ -0,0 +1,85 @@
+package microsharduuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestULIDRoundTrip(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 999999000, time.UTC)
+	for _, shard := range []uint32{0, 1, 1 << 26, MaxShardID} {
+		id, _ := FromTime(ts, shard)
+		b := id.ToULID()
+
+		ms := binaryMillis(b)
+		if int64(ms) != ts.UnixMilli() {
+			t.Errorf("ULID time %d, expected %d", ms, ts.UnixMilli())
+		}
+
+		back, err := FromULID(b, shard)
+		if err != nil || back != id {
+			t.Errorf("shard=%d: FromULID = %v, %v; expected %v", shard, back, err, id)
+		}
+	}
+}
+
+func TestULIDEntropy(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 123456000, time.UTC)
+	for _, shard := range []uint32{0, 0xF0000000, MaxShardID} {
+		id, _ := FromParts(uint64(ts.UnixMicro()), shard, 0xABCDEF123)
+		b := id.ToULID()
+		hi, lo := binary.BigEndian.Uint16(b[6:8]), binary.BigEndian.Uint64(b[8:16])
+		if sub := hi >> 6; sub != 456 {
+			t.Errorf("shard=%#x: sub-millisecond micros %d, expected 456", shard, sub)
+		}
+		if zero := hi >> 4 & 0x3; zero != 0 {
+			t.Errorf("shard=%#x: zero bits are %#x", shard, zero)
+		}
+		if got := uint32(uint64(hi)<<28 | lo>>36); got != shard {
+			t.Errorf("Entropy shard %#x, expected %#x", got, shard)
+		}
+		if random := lo & MaxRandom; random != 0xABCDEF123 {
+			t.Errorf("shard=%#x: entropy random %#x", shard, random)
+		}
+	}
+}
+
+func TestULIDKeepsOrder(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	prev := [16]byte{}
+	for i := 0; i < 2000; i++ {
+		id, _ := FromTime(ts.Add(time.Duration(i)*time.Microsecond), uint32(i))
+		b := id.ToULID()
+		if string(b[:]) <= string(prev[:]) {
+			t.Fatalf("ULID %d out of order", i)
+		}
+		prev = b
+	}
+}
+
+func TestFromULIDForeign(t *testing.T) {
+	// Entropy with a sub-millisecond field above 999 is clamped
+	foreign := [16]byte{0x01, 0x8E, 0x65, 0xC9, 0x3A, 0x10, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	id, err := FromULID(foreign, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.UnixMicro()%1000 != 999 || id.ShardID() != 7 || id.Random() != MaxRandom {
+		t.Errorf("Unexpected conversion: %+v", id.Decompose())
+	}
+	if id.UnixMilli() != int64(binaryMillis(foreign)) {
+		t.Errorf("Millisecond timestamp not kept")
+	}
+
+	far := [16]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	if _, err := FromULID(far, 1); !errors.Is(err, ErrTimeOverflow) {
+		t.Errorf("Far future: expected ErrTimeOverflow, got %v", err)
+	}
+}
+
+// binaryMillis reads the 48-bit millisecond prefix shared by ULID and UUIDv7.
+func binaryMillis(b [16]byte) uint64 {
+	return uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 | uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5])
+}
@@ -0,0 +1,30 @@
+//go:build go1.21
+
+package microsharduuid
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer, rendering the ID as its canonical
+// string instead of {High: ..., Low: ...}.
+func (u MicroShardUUID) LogValue() slog.Value {
+	return slog.StringValue(u.String())
+}
+
+// LogDetails wraps u for slog so it renders as a group of the canonical
+// string, Shard ID and timestamp, e.g. id.id=... id.shard_id=500 id.time=...
+//
+//	logger.Info("created", "id", microsharduuid.LogDetails(id))
+func LogDetails(u MicroShardUUID) slog.LogValuer {
+	return detailedID(u)
+}
+
+type detailedID MicroShardUUID
+
+func (d detailedID) LogValue() slog.Value {
+	u := MicroShardUUID(d)
+	return slog.GroupValue(
+		slog.String("id", u.String()),
+		slog.Uint64("shard_id", uint64(u.ShardID())),
+		slog.Time("time", u.Time()),
+	)
+}
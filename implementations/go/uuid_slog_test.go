@@ -0,0 +1,37 @@
+//go:build go1.21
+
+package microsharduuid
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestUUIDLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	id := MustParse("018e2b4a-5c3d-8f01-8000-0001f2a3b4c5")
+	logger.Info("created", "id", id)
+
+	if out := buf.String(); !strings.Contains(out, "id=018e2b4a-5c3d-8f01-8000-0001f2a3b4c5") {
+		t.Errorf("Expected canonical ID in log output: %s", out)
+	}
+}
+
+func TestLogDetails(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	id, _ := FromParts(1700000000000000, 500, 1)
+	logger.Info("created", "id", LogDetails(id))
+
+	out := buf.String()
+	for _, want := range []string{`"id":{"id":"` + id.String() + `"`, `"shard_id":500`, `"time":"2023-11-14T22:13:20Z"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Log output missing %s: %s", want, out)
+		}
+	}
+}
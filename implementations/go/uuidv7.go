@@ -0,0 +1,70 @@
+package microsharduuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ==========================================
+// UUIDv7 Interop
+// ==========================================
+
+// FormatUUIDv7 is reported in ParseError.Format by FromUUIDv7.
+const FormatUUIDv7 = "uuidv7"
+
+// ToUUIDv7 converts u into an RFC 9562 version 7 UUID with the same
+// millisecond timestamp, so services that only understand v7 can store and
+// sort it alongside their own IDs during a migration.
+//
+// The result is laid out as follows:
+//   - unix_ts_ms (48 bits): the millisecond part of the timestamp.
+//   - rand_a (12 bits): the sub-millisecond remainder, scaled to 1/4096 ms
+//     (RFC 9562 Section 6.2, Method 3), so IDs minted in the same millisecond
+//     keep their order.
+//   - rand_b (62 bits): the low 26 bits of the Shard ID followed by the 36
+//     random bits. They are filler to v7 consumers; the top 6 shard bits are
+//     dropped.
+//
+// Converting back with FromUUIDv7 and the original Shard ID returns u.
+func (u MicroShardUUID) ToUUIDv7() [16]byte {
+	micros := u.micros()
+	ms := micros / 1000
+	fraction := (micros % 1000) << 12 / 1000
+
+	high := ms<<16 | 7<<12 | fraction
+	low := Variant<<62 | uint64(u.ShardID()&0x3FFFFFF)<<36 | u.Random()
+
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[0:8], high)
+	binary.BigEndian.PutUint64(b[8:16], low)
+	return b
+}
+
+// FromUUIDv7 converts an RFC 9562 version 7 UUID into a MicroShardUUID for
+// shardID, keeping its place in time order: the millisecond timestamp is
+// copied, rand_a is read as a sub-millisecond fraction (as written by
+// ToUUIDv7), and the low 36 bits of rand_b become the random bits.
+//
+// It returns a *ParseError wrapping ErrInvalidVersion or ErrInvalidVariant
+// if b is not a v7 UUID, and ErrTimeOverflow for timestamps past MaxTime.
+func FromUUIDv7(b [16]byte, shardID uint32) (MicroShardUUID, error) {
+	high := binary.BigEndian.Uint64(b[0:8])
+	low := binary.BigEndian.Uint64(b[8:16])
+
+	ver, varnt := (high>>12)&0xF, low>>62
+	if ver != 7 {
+		return MicroShardUUID{}, parseFailed(&ParseError{Format: FormatUUIDv7, InputLen: 16, Version: int(ver), Variant: int(varnt),
+			Reason: fmt.Sprintf("invalid version: %d (expected 7)", ver), Err: ErrInvalidVersion})
+	}
+	if varnt != Variant {
+		return MicroShardUUID{}, parseFailed(&ParseError{Format: FormatUUIDv7, InputLen: 16, Version: int(ver), Variant: int(varnt),
+			Reason: fmt.Sprintf("invalid variant: %d (expected %d)", varnt, Variant), Err: ErrInvalidVariant})
+	}
+
+	// Round up so that fractions written by ToUUIDv7 map back exactly
+	sub := ((high&0xFFF)*1000 + 4095) >> 12
+	if sub > 999 {
+		sub = 999
+	}
+	return FromParts((high>>16)*1000+sub, shardID, low&MaxRandom)
+}
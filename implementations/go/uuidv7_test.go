@@ -0,0 +1,71 @@
+package microsharduuid
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestUUIDv7RoundTrip(t *testing.T) {
+	base := uint64(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC).UnixMicro())
+
+	// Every sub-millisecond offset must survive the 1/4096 ms scaling
+	for sub := uint64(0); sub < 1000; sub++ {
+		id, _ := FromParts(base+sub, MaxShardID, MaxRandom-sub)
+		v7 := id.ToUUIDv7()
+
+		if v7[6]>>4 != 7 || v7[8]>>6 != 2 {
+			t.Fatalf("Not a v7 UUID: %x", v7)
+		}
+		back, err := FromUUIDv7(v7, MaxShardID)
+		if err != nil || back != id {
+			t.Fatalf("sub=%d: FromUUIDv7 = %v, %v; expected %v", sub, back, err, id)
+		}
+	}
+}
+
+func TestUUIDv7KeepsMillisAndOrder(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 123456000, time.UTC)
+	id, _ := FromTime(ts, 42)
+	v7 := id.ToUUIDv7()
+
+	if ms := binaryMillis(v7); int64(ms) != ts.UnixMilli() {
+		t.Errorf("unix_ts_ms %d, expected %d", ms, ts.UnixMilli())
+	}
+
+	ids := make([]MicroShardUUID, 200)
+	for i := range ids {
+		ids[i], _ = FromTime(ts.Add(time.Duration(i*7)*time.Microsecond), uint32(i))
+	}
+	v7s := make([][16]byte, len(ids))
+	for i, id := range ids {
+		v7s[i] = id.ToUUIDv7()
+	}
+	if !sort.SliceIsSorted(v7s, func(i, j int) bool { return string(v7s[i][:]) < string(v7s[j][:]) }) {
+		t.Error("ToUUIDv7 did not preserve time order")
+	}
+}
+
+func TestFromUUIDv7Errors(t *testing.T) {
+	id, _ := Generate(1)
+	var v8 [16]byte
+	copy(v8[:], id.Bytes())
+
+	var pe *ParseError
+	if _, err := FromUUIDv7(v8, 1); !errors.As(err, &pe) || !errors.Is(err, ErrInvalidVersion) || pe.Format != FormatUUIDv7 || pe.Version != 8 {
+		t.Errorf("v8 input: expected ErrInvalidVersion, got %v", err)
+	}
+
+	v7 := id.ToUUIDv7()
+	v7[8] &= 0x3F // NCS variant
+	if _, err := FromUUIDv7(v7, 1); !errors.Is(err, ErrInvalidVariant) {
+		t.Errorf("Bad variant: expected ErrInvalidVariant, got %v", err)
+	}
+
+	// The 48-bit millisecond range reaches far past MaxTime
+	far := [16]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x70, 0, 0x80}
+	if _, err := FromUUIDv7(far, 1); !errors.Is(err, ErrTimeOverflow) {
+		t.Errorf("Far future: expected ErrTimeOverflow, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+// Package vectors holds the cross-language golden test vectors of the
+// MicroShard UUID format.
+//
+// Every implementation in this repository is checked against the same
+// vectors.json file: each vector gives the inputs (timestamp, Shard ID,
+// random bits) and the expected encodings (canonical string, 16 bytes, and
+// the two 64-bit halves). The file is embedded here and the Go tests verify
+// the implementation against it, so a change to the layout code that breaks
+// bit compatibility fails before it ships. Regenerate it with
+//
+//	msuuid vectors > vectors/vectors.json
+//
+// only when the format itself changes.
+package vectors
+
+import (
+	_ "embed" // For the golden file
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// FormatVersion is the version of the vector file layout.
+const FormatVersion = 1
+
+// Defaults used for the embedded file.
+const (
+	DefaultSeed  = 20250101
+	DefaultCount = 64
+)
+
+//go:embed vectors.json
+var golden []byte
+
+// File is the JSON document holding the vectors.
+type File struct {
+	Version int      `json:"version"`
+	Vectors []Vector `json:"vectors"`
+}
+
+// Vector is one test case. Values that can exceed 2^53 are encoded as
+// strings, so JavaScript and other float-based JSON parsers read them
+// exactly.
+type Vector struct {
+	Name string `json:"name"`
+
+	// Inputs
+	Time   string `json:"time"`          // ISO 8601, UTC, microsecond precision
+	Micros uint64 `json:"micros,string"` // Unix microseconds (same instant as Time)
+	Shard  uint32 `json:"shard"`
+	Random uint64 `json:"random"` // 36 bits
+
+	// Outputs
+	String string `json:"string"` // Canonical 8-4-4-4-12 form
+	Bytes  string `json:"bytes"`  // 16 bytes, lowercase hex, big-endian
+	High   string `json:"high"`   // Upper 64 bits, 16 lowercase hex digits
+	Low    string `json:"low"`    // Lower 64 bits, 16 lowercase hex digits
+}
+
+// Load decodes the embedded golden file.
+func Load() (File, error) {
+	var f File
+	if err := json.Unmarshal(golden, &f); err != nil {
+		return File{}, fmt.Errorf("vectors: decoding embedded file: %w", err)
+	}
+	return f, nil
+}
+
+// Golden returns the raw embedded vectors.json, for tools that copy it
+// into other implementations.
+func Golden() []byte {
+	return append([]byte(nil), golden...)
+}
+
+// Generate builds a vector file: a fixed set of boundary cases followed by
+// count cases drawn from a PRNG seeded with seed. The same arguments always
+// produce the same file.
+func Generate(seed int64, count int) (File, error) {
+	f := File{Version: FormatVersion}
+	for _, c := range edgeCases {
+		v, err := NewVector(c.name, c.micros, c.shard, c.random)
+		if err != nil {
+			return File{}, err
+		}
+		f.Vectors = append(f.Vectors, v)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	for i := 0; i < count; i++ {
+		micros := uint64(rng.Int63n(int64(microsharduuid.MaxTime) + 1))
+		shard := rng.Uint32()
+		random := uint64(rng.Int63n(int64(microsharduuid.MaxRandom) + 1))
+		v, err := NewVector(fmt.Sprintf("random-%d", i), micros, shard, random)
+		if err != nil {
+			return File{}, err
+		}
+		f.Vectors = append(f.Vectors, v)
+	}
+	return f, nil
+}
+
+// NewVector computes the outputs of one case with this implementation.
+func NewVector(name string, micros uint64, shard uint32, random uint64) (Vector, error) {
+	id, err := microsharduuid.FromParts(micros, shard, random)
+	if err != nil {
+		return Vector{}, err
+	}
+	return Vector{
+		Name:   name,
+		Time:   id.ISOTime(),
+		Micros: micros,
+		Shard:  shard,
+		Random: random,
+		String: id.String(),
+		Bytes:  hex.EncodeToString(id.Bytes()),
+		High:   fmt.Sprintf("%016x", id.High),
+		Low:    fmt.Sprintf("%016x", id.Low),
+	}, nil
+}
+
+// Write encodes f in the canonical form of vectors.json: two-space
+// indentation and a trailing newline.
+func Write(w io.Writer, f File) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(f)
+}
+
+// edgeCases exercise every field at its limits and the bit boundaries
+// where a field is split across the version and variant fields.
+var edgeCases = []struct {
+	name   string
+	micros uint64
+	shard  uint32
+	random uint64
+}{
+	{"zero", 0, 0, 0},
+	{"max", microsharduuid.MaxTime, microsharduuid.MaxShardID, microsharduuid.MaxRandom},
+	{"max-time", microsharduuid.MaxTime, 0, 0},
+	{"max-shard", 0, microsharduuid.MaxShardID, 0},
+	{"max-random", 0, 0, microsharduuid.MaxRandom},
+	{"time-low-bits", 63, 0, 0},
+	{"time-high-bits", 64, 0, 0},
+	{"shard-low-bits", 0, 1<<26 - 1, 0},
+	{"shard-high-bits", 0, 1 << 26, 0},
+	{"random-one", 0, 0, 1},
+	{"typical", 1735689600123456, 42, 0x123456789},
+}
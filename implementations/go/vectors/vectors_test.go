@@ -0,0 +1,95 @@
+package vectors
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	microsharduuid "github.com/dilipvamsi/microshard-uuid/implementations/go"
+)
+
+// TestGoldenVectors decodes every expected output of the embedded file and
+// checks it against the inputs.
+func TestGoldenVectors(t *testing.T) {
+	f, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Version != FormatVersion || len(f.Vectors) != len(edgeCases)+DefaultCount {
+		t.Fatalf("Unexpected file: version %d, %d vectors", f.Version, len(f.Vectors))
+	}
+
+	for _, v := range f.Vectors {
+		t.Run(v.Name, func(t *testing.T) {
+			want, err := microsharduuid.FromParts(v.Micros, v.Shard, v.Random)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			id, err := microsharduuid.ParseStrict(v.String)
+			if err != nil || id != want {
+				t.Errorf("String %s decodes to %v (%v), expected %v", v.String, id, err, want)
+			}
+
+			raw, err := hex.DecodeString(v.Bytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id, err := microsharduuid.FromBytes(raw); err != nil || id != want {
+				t.Errorf("Bytes %s decode to %v (%v), expected %v", v.Bytes, id, err, want)
+			}
+
+			if high, low := fmt.Sprintf("%016x", want.High), fmt.Sprintf("%016x", want.Low); v.High != high || v.Low != low {
+				t.Errorf("Unexpected halves %s %s (expected %s %s)", v.High, v.Low, high, low)
+			}
+
+			ts, err := time.Parse(time.RFC3339Nano, v.Time)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if micros := ts.Unix()*1e6 + int64(ts.Nanosecond()/1e3); uint64(micros) != v.Micros {
+				t.Errorf("Time %s is not %d microseconds", v.Time, v.Micros)
+			}
+			if got := want.ISOTime(); got != v.Time {
+				t.Errorf("Unexpected time %s (expected %s)", got, v.Time)
+			}
+			if want.ShardID() != v.Shard || want.Random() != v.Random || want.UnixMicro() != int64(v.Micros) {
+				t.Errorf("Fields of %v do not match the inputs", want)
+			}
+		})
+	}
+}
+
+// TestGoldenUpToDate fails when the embedded file differs from what the
+// generator writes, i.e. when the encoding changed or the file was edited
+// by hand.
+func TestGoldenUpToDate(t *testing.T) {
+	f, err := Generate(DefaultSeed, DefaultCount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, f); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), Golden()) {
+		t.Error("vectors.json is out of date: regenerate it with 'msuuid vectors > vectors/vectors.json' if the format changed on purpose")
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	a, _ := Generate(1, 8)
+	b, _ := Generate(1, 8)
+	c, _ := Generate(2, 8)
+	if fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Error("The same seed must produce the same vectors")
+	}
+	if fmt.Sprint(a) == fmt.Sprint(c) {
+		t.Error("Different seeds should produce different vectors")
+	}
+	if len(a.Vectors) != len(edgeCases)+8 {
+		t.Errorf("Unexpected vector count: %d", len(a.Vectors))
+	}
+}
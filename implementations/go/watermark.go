@@ -0,0 +1,156 @@
+package microsharduuid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ==========================================
+// Persisted Timestamp Watermark
+// ==========================================
+
+// WatermarkStore persists a timestamp watermark across restarts.
+type WatermarkStore interface {
+	// LoadWatermark returns the stored watermark in Unix microseconds,
+	// or 0 if none was stored yet.
+	LoadWatermark() (uint64, error)
+	// StoreWatermark durably records micros before returning.
+	StoreWatermark(micros uint64) error
+}
+
+// DefaultWatermarkWindow is how far ahead WithWatermark reserves timestamps.
+const DefaultWatermarkWindow = time.Second
+
+// WithWatermark makes the Generator crash-safe against clock regressions
+// that happen while the process is down: it never issues an ID with a
+// timestamp below the watermark in store.
+//
+// To avoid a write per ID, the Generator reserves timestamps ahead of use:
+// before returning an ID at time T it makes sure the stored watermark is
+// above T, writing T+window (DefaultWatermarkWindow if window <= 0) when it
+// is not. After a restart, IDs start at the stored watermark, so they sort
+// after every ID issued before the crash. The watermark is loaded on first
+// use; load and store failures are returned as a *GenerateError.
+//
+// Share a store between Generators only if they run in the same process.
+func WithWatermark(store WatermarkStore, window time.Duration) Option {
+	if window <= 0 {
+		window = DefaultWatermarkWindow
+	}
+	w := &watermark{store: store, window: uint64(window / time.Microsecond)}
+	return func(c *generatorConfig) {
+		c.watermark = w
+	}
+}
+
+// watermark tracks the timestamps reserved in a WatermarkStore.
+type watermark struct {
+	store  WatermarkStore
+	window uint64 // Microseconds reserved per write
+
+	mu       sync.Mutex
+	loaded   uint32 // 1 once floor is known (atomic)
+	floor    uint64 // Watermark found at startup
+	reserved uint64 // Stored watermark; issued timestamps stay below it (atomic)
+}
+
+// clamp returns now raised to the watermark found at startup.
+func (w *watermark) clamp(now uint64, shardID uint32) (uint64, error) {
+	if atomic.LoadUint32(&w.loaded) == 0 {
+		w.mu.Lock()
+		if w.loaded == 0 {
+			floor, err := w.store.LoadWatermark()
+			if err != nil {
+				w.mu.Unlock()
+				return 0, &GenerateError{ShardID: shardID, Micros: now, Reason: "watermark load failed", Err: err}
+			}
+			w.floor = floor
+			atomic.StoreUint64(&w.reserved, floor)
+			atomic.StoreUint32(&w.loaded, 1)
+		}
+		w.mu.Unlock()
+	}
+	if now < w.floor {
+		return w.floor, nil
+	}
+	return now, nil
+}
+
+// reserve makes sure the stored watermark is above micros.
+func (w *watermark) reserve(micros uint64, shardID uint32) error {
+	if micros < atomic.LoadUint64(&w.reserved) {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if micros < w.reserved {
+		return nil
+	}
+	next := micros + w.window
+	if err := w.store.StoreWatermark(next); err != nil {
+		return &GenerateError{ShardID: shardID, Micros: micros, Reason: "watermark store failed", Err: err}
+	}
+	atomic.StoreUint64(&w.reserved, next)
+	return nil
+}
+
+// ==========================================
+// File Store
+// ==========================================
+
+// FileWatermark is a WatermarkStore backed by a small text file. Writes go
+// to a temporary file that is synced and renamed over path, so a crash
+// leaves either the old or the new watermark, never a torn one.
+type FileWatermark struct {
+	Path string
+}
+
+// LoadWatermark reads the watermark, returning 0 if the file does not exist.
+func (f FileWatermark) LoadWatermark() (uint64, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// StoreWatermark atomically replaces the watermark file.
+func (f FileWatermark) StoreWatermark(micros uint64) error {
+	tmp := f.Path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(strconv.FormatUint(micros, 10) + "\n"); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, f.Path); err != nil {
+		return err
+	}
+
+	// Persist the rename itself
+	dir, err := os.Open(filepath.Dir(f.Path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
@@ -0,0 +1,109 @@
+package microsharduuid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// memWatermark is an in-memory WatermarkStore that counts writes.
+type memWatermark struct {
+	micros uint64
+	writes int
+	err    error
+}
+
+func (m *memWatermark) LoadWatermark() (uint64, error) { return m.micros, m.err }
+
+func (m *memWatermark) StoreWatermark(micros uint64) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.micros = micros
+	m.writes++
+	return nil
+}
+
+func TestWatermarkSurvivesRestart(t *testing.T) {
+	store := FileWatermark{Path: filepath.Join(t.TempDir(), "watermark")}
+	clock := &manualClock{micros: 1700000000000000}
+
+	before, _ := NewGenerator(1, WithClock(clock), WithWatermark(store, time.Second))
+	last, err := before.NewID()
+	if err != nil {
+		t.Fatalf("NewID failed: %v", err)
+	}
+
+	// Restart with a clock that regressed by an hour while the process was down
+	clock.set(1700000000000000 - int64(time.Hour/time.Microsecond))
+	after, _ := NewGenerator(1, WithClock(clock), WithWatermark(store, time.Second))
+	id, err := after.NewID()
+	if err != nil {
+		t.Fatalf("NewID after restart failed: %v", err)
+	}
+	if !id.After(last) {
+		t.Errorf("ID after restart (%v) sorts before one issued earlier (%v)", id.Time(), last.Time())
+	}
+}
+
+func TestWatermarkBatchesWrites(t *testing.T) {
+	store := &memWatermark{}
+	clock := &manualClock{micros: 1700000000000000}
+	gen, _ := NewGenerator(1, WithClock(clock), WithWatermark(store, time.Second))
+
+	for i := 0; i < 1000; i++ {
+		clock.set(1700000000000000 + int64(i))
+		gen.NewID()
+	}
+	gen.NewIDs(100)
+	if store.writes != 1 {
+		t.Errorf("Expected a single watermark write within the window, got %d", store.writes)
+	}
+	if store.micros != 1700000000000000+1000000 {
+		t.Errorf("Expected watermark one window ahead, got %d", store.micros)
+	}
+
+	clock.set(1700000000000000 + 2000000)
+	gen.NewID()
+	if store.writes != 2 {
+		t.Errorf("Expected a new reservation after the window, got %d writes", store.writes)
+	}
+}
+
+func TestWatermarkErrors(t *testing.T) {
+	store := &memWatermark{err: errors.New("disk full")}
+	gen, _ := NewGenerator(1, WithWatermark(store, 0))
+
+	_, err := gen.NewID()
+	var genErr *GenerateError
+	if !errors.As(err, &genErr) || !errors.Is(err, store.err) {
+		t.Fatalf("Expected GenerateError wrapping the store error, got %v", err)
+	}
+	if _, err := gen.NewIDs(5); err == nil {
+		t.Error("NewIDs should surface watermark errors")
+	}
+}
+
+func TestFileWatermark(t *testing.T) {
+	store := FileWatermark{Path: filepath.Join(t.TempDir(), "wm")}
+
+	if v, err := store.LoadWatermark(); err != nil || v != 0 {
+		t.Fatalf("Missing file should load as 0, got %d (%v)", v, err)
+	}
+	if err := store.StoreWatermark(42); err != nil {
+		t.Fatalf("StoreWatermark failed: %v", err)
+	}
+	if v, err := store.LoadWatermark(); err != nil || v != 42 {
+		t.Errorf("Expected 42, got %d (%v)", v, err)
+	}
+	if _, err := os.Stat(store.Path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Temporary file should be renamed away")
+	}
+
+	os.WriteFile(store.Path, []byte("garbage"), 0o644)
+	if _, err := store.LoadWatermark(); err == nil {
+		t.Error("Expected error for a corrupt watermark file")
+	}
+}